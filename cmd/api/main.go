@@ -11,10 +11,14 @@ import (
 	"time"
 
 	"github.com/joho/godotenv"
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/vellalasantosh/wound_iq_api_claude/internal/config"
 	"github.com/vellalasantosh/wound_iq_api_claude/internal/db"
 	"github.com/vellalasantosh/wound_iq_api_claude/internal/handlers"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/mailer"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/middleware"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/oidc"
 	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository"
 	"github.com/vellalasantosh/wound_iq_api_claude/internal/router"
 	"github.com/vellalasantosh/wound_iq_api_claude/internal/service"
@@ -34,12 +38,50 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
-	// Load JWT secret
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		log.Fatal("JWT_SECRET environment variable is required")
+	// "api migrate ..." manages the schema instead of starting the server
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(cfg, os.Args[2:]); err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+		return
+	}
+
+	// Load JWT signing keys, or fall back to the shared HS256 secret
+	if cfg.JWTKeysDir != "" {
+		keySet, err := utils.LoadKeySet(cfg.JWTKeysDir, cfg.JWTActiveKID)
+		if err != nil {
+			log.Fatalf("Failed to load JWT keys: %v", err)
+		}
+		utils.SetKeySet(keySet)
+		log.Printf("Signing tokens with key %s from %s", keySet.ActiveKeyID(), cfg.JWTKeysDir)
+	} else {
+		jwtSecret := os.Getenv("JWT_SECRET")
+		if jwtSecret == "" {
+			log.Fatal("JWT_SECRET or JWT_KEYS_DIR environment variable is required")
+		}
+		utils.SetJWTSecret(jwtSecret)
+	}
+	utils.SetTokenIssuer(cfg.JWTIssuer, cfg.JWTAudience)
+
+	// Select the hasher for new passwords
+	switch cfg.PasswordHasher {
+	case "bcrypt":
+		if cfg.BcryptCost < bcrypt.MinCost || cfg.BcryptCost > bcrypt.MaxCost {
+			log.Fatalf("BCRYPT_COST must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+		utils.SetPasswordHasher(utils.BcryptHasher{Cost: cfg.BcryptCost})
+	case "argon2id":
+		if cfg.Argon2Memory < 8*cfg.Argon2Parallelism || cfg.Argon2Iterations < 1 || cfg.Argon2Parallelism < 1 || cfg.Argon2Parallelism > 255 {
+			log.Fatal("Invalid ARGON2_* settings")
+		}
+		hasher := utils.DefaultArgon2idHasher()
+		hasher.Memory = uint32(cfg.Argon2Memory)
+		hasher.Iterations = uint32(cfg.Argon2Iterations)
+		hasher.Parallelism = uint8(cfg.Argon2Parallelism)
+		utils.SetPasswordHasher(hasher)
+	default:
+		log.Fatalf("Unknown PASSWORD_HASHER %q (expected \"bcrypt\" or \"argon2id\")", cfg.PasswordHasher)
 	}
-	utils.SetJWTSecret(jwtSecret)
 
 	// Initialize database
 	database, err := db.NewPostgresDB(cfg.DBDSN)
@@ -50,13 +92,86 @@ func main() {
 
 	log.Println("Successfully connected to PostgreSQL")
 
+	repos := repository.NewPostgresRepositories(database.DB)
+
+	// Initialize mail delivery
+	mail, err := mailer.New(cfg.MailDriver, cfg.MailDir)
+	if err != nil {
+		log.Fatalf("Failed to initialize mailer: %v", err)
+	}
+
+	// Initialize access-token denylist
+	var denylist utils.TokenDenylist
+	switch cfg.TokenDenylist {
+	case "memory":
+		denylist = utils.NewMemoryDenylist()
+	case "postgres":
+		denylist = repository.NewTokenDenylistRepository(database.DB)
+	default:
+		log.Fatalf("Unknown TOKEN_DENYLIST %q (expected \"memory\" or \"postgres\")", cfg.TokenDenylist)
+	}
+	middleware.SetTokenDenylist(denylist)
+
+	// Accept machine API keys via X-API-Key
+	middleware.SetAPIKeyVerifier(service.NewAPIKeyService(repos.APIKeys))
+
+	// Build the password policy, with the optional breached-password list
+	passwordPolicy := utils.PasswordPolicy{
+		MinLength:     cfg.PasswordMinLength,
+		MaxLength:     cfg.PasswordMaxLength,
+		RequireUpper:  cfg.PasswordRequireUpper,
+		RequireLower:  cfg.PasswordRequireLower,
+		RequireDigit:  cfg.PasswordRequireDigit,
+		RequireSymbol: cfg.PasswordRequireSymbol,
+		HistorySize:   cfg.PasswordHistory,
+		MaxAge:        cfg.PasswordMaxAge,
+	}
+	if cfg.BreachedPasswordsFile != "" {
+		breached, err := utils.LoadBreachedPasswordList(cfg.BreachedPasswordsFile)
+		if err != nil {
+			log.Fatalf("Failed to load breached password list: %v", err)
+		}
+		passwordPolicy.Breached = breached
+		log.Printf("Loaded %d breached passwords from %s", len(breached), cfg.BreachedPasswordsFile)
+	}
+
+	// Initialize OIDC single sign-on (optional)
+	var sso *oidc.Provider
+	if cfg.OIDCIssuerURL != "" {
+		sso = oidc.NewProvider(oidc.Config{
+			IssuerURL:    cfg.OIDCIssuerURL,
+			ClientID:     cfg.OIDCClientID,
+			ClientSecret: cfg.OIDCClientSecret,
+			RedirectURL:  cfg.OIDCRedirectURL,
+			Scopes:       cfg.OIDCScopes,
+		}, nil)
+		log.Printf("Single sign-on enabled for issuer %s", cfg.OIDCIssuerURL)
+	}
+
 	// Initialize Auth components
-	authRepo := repository.NewAuthRepository(database.DB)
-	authService := service.NewAuthService(authRepo)
+	authService := service.NewAuthService(repos.Auth, mail, denylist, service.AuthConfig{
+		AppBaseURL:               cfg.AppBaseURL,
+		PasswordResetURL:         cfg.PasswordResetURL,
+		RequireEmailVerification: cfg.RequireEmailVerification,
+		MFARequiredRoles:         cfg.MFARequiredRoles,
+		MFAIssuer:                cfg.MFAIssuer,
+		LockoutThreshold:         cfg.LockoutThreshold,
+		LockoutDuration:          cfg.LockoutDuration,
+		IPThrottleThreshold:      cfg.IPThrottleThreshold,
+		IPThrottleWindow:         cfg.IPThrottleWindow,
+		PasswordPolicy:           passwordPolicy,
+		SSO:                      sso,
+		SSOProvisionClinicians:   cfg.OIDCProvisionClinicians,
+		SSOTrustIdPMFA:           cfg.OIDCTrustMFA,
+	})
 	authHandler := handlers.NewAuthHandler(authService)
+	adminHandler := handlers.NewAdminHandler(service.NewAdminService(repos.Auth, authService, denylist))
 
 	// Initialize Router
-	r := router.SetupRouter(database, authHandler)
+	r := router.SetupRouter(repos, authHandler, adminHandler)
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
 
 	// Attach Auth Routes under unified /api/v1
 	// log.Println("Registering auth routes...")
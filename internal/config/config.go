@@ -3,12 +3,91 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Config holds application configuration
 type Config struct {
 	DBDSN string
 	Port  string
+
+	// AppBaseURL is the public URL used to build links in outgoing email
+	AppBaseURL string
+
+	// PasswordResetURL is the client page that accepts a password reset token
+	PasswordResetURL string
+
+	// Mail delivery ("log" or "file"); MailDir is used by the file driver
+	MailDriver string
+	MailDir    string
+
+	// RequireEmailVerification blocks login until the user verifies their email
+	RequireEmailVerification bool
+
+	// MFARequiredRoles lists the roles that must enroll in TOTP MFA
+	MFARequiredRoles []string
+
+	// MFAIssuer is the issuer name shown in authenticator apps
+	MFAIssuer string
+
+	// Account lockout: lock for LockoutDuration after LockoutThreshold
+	// consecutive failures (0 disables)
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+
+	// Per-IP throttling: refuse logins from an IP with IPThrottleThreshold
+	// failures inside IPThrottleWindow (0 disables)
+	IPThrottleThreshold int
+	IPThrottleWindow    time.Duration
+
+	// TrustedProxies lists the proxy addresses or CIDRs whose
+	// X-Forwarded-For header is believed; empty uses the socket address
+	TrustedProxies []string
+
+	// JWTKeysDir holds PEM signing keys named <kid>.pem; when set, tokens are
+	// signed with the JWTActiveKID key instead of the JWT_SECRET HS256 secret
+	JWTKeysDir   string
+	JWTActiveKID string
+
+	// JWTIssuer and JWTAudience are the iss and aud claims of access tokens;
+	// services verifying our tokens against the JWKS must check both
+	JWTIssuer   string
+	JWTAudience string
+
+	// Password policy for new passwords; see utils.PasswordPolicy.
+	// BreachedPasswordsFile, when set, lists passwords that are refused.
+	PasswordMinLength     int
+	PasswordMaxLength     int
+	PasswordRequireUpper  bool
+	PasswordRequireLower  bool
+	PasswordRequireDigit  bool
+	PasswordRequireSymbol bool
+	PasswordHistory       int
+	PasswordMaxAge        time.Duration
+	BreachedPasswordsFile string
+
+	// PasswordHasher ("bcrypt" or "argon2id") hashes new passwords; stored
+	// hashes from the other algorithm or weaker settings are upgraded at login
+	PasswordHasher    string
+	BcryptCost        int
+	Argon2Memory      int // KiB
+	Argon2Iterations  int
+	Argon2Parallelism int
+
+	// OIDC single sign-on; disabled unless OIDCIssuerURL is set
+	OIDCIssuerURL           string
+	OIDCClientID            string
+	OIDCClientSecret        string
+	OIDCRedirectURL         string
+	OIDCScopes              []string
+	OIDCProvisionClinicians bool
+	OIDCTrustMFA            bool
+
+	// TokenDenylist selects where revoked access tokens are kept
+	// ("memory" or "postgres")
+	TokenDenylist string
 }
 
 // Load reads configuration from environment variables
@@ -23,8 +102,204 @@ func Load() (*Config, error) {
 		port = "8080" // default port
 	}
 
+	requireVerification, err := getEnvBool("REQUIRE_EMAIL_VERIFICATION", false)
+	if err != nil {
+		return nil, err
+	}
+
+	appBaseURL := getEnv("APP_BASE_URL", "http://localhost:"+port)
+
+	oidcProvision, err := getEnvBool("OIDC_PROVISION_CLINICIANS", false)
+	if err != nil {
+		return nil, err
+	}
+
+	oidcTrustMFA, err := getEnvBool("OIDC_TRUST_MFA", false)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordMinLength, err := getEnvInt("PASSWORD_MIN_LENGTH", 6)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordMaxLength, err := getEnvInt("PASSWORD_MAX_LENGTH", 100)
+	if err != nil {
+		return nil, err
+	}
+
+	requireUpper, err := getEnvBool("PASSWORD_REQUIRE_UPPER", false)
+	if err != nil {
+		return nil, err
+	}
+
+	requireLower, err := getEnvBool("PASSWORD_REQUIRE_LOWER", false)
+	if err != nil {
+		return nil, err
+	}
+
+	requireDigit, err := getEnvBool("PASSWORD_REQUIRE_DIGIT", false)
+	if err != nil {
+		return nil, err
+	}
+
+	requireSymbol, err := getEnvBool("PASSWORD_REQUIRE_SYMBOL", false)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordHistory, err := getEnvInt("PASSWORD_HISTORY", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordMaxAge, err := getEnvDuration("PASSWORD_MAX_AGE", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	bcryptCost, err := getEnvInt("BCRYPT_COST", 10)
+	if err != nil {
+		return nil, err
+	}
+
+	argon2Memory, err := getEnvInt("ARGON2_MEMORY_KIB", 64*1024)
+	if err != nil {
+		return nil, err
+	}
+
+	argon2Iterations, err := getEnvInt("ARGON2_ITERATIONS", 3)
+	if err != nil {
+		return nil, err
+	}
+
+	argon2Parallelism, err := getEnvInt("ARGON2_PARALLELISM", 2)
+	if err != nil {
+		return nil, err
+	}
+
+	lockoutThreshold, err := getEnvInt("LOCKOUT_THRESHOLD", 5)
+	if err != nil {
+		return nil, err
+	}
+
+	lockoutDuration, err := getEnvDuration("LOCKOUT_DURATION", 15*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	ipThrottleThreshold, err := getEnvInt("LOGIN_IP_THRESHOLD", 20)
+	if err != nil {
+		return nil, err
+	}
+
+	ipThrottleWindow, err := getEnvDuration("LOGIN_IP_WINDOW", 15*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
-		DBDSN: dbDSN,
-		Port:  port,
+		DBDSN:                    dbDSN,
+		Port:                     port,
+		AppBaseURL:               appBaseURL,
+		PasswordResetURL:         getEnv("PASSWORD_RESET_URL", appBaseURL+"/reset-password"),
+		MailDriver:               getEnv("MAIL_DRIVER", "log"),
+		MailDir:                  getEnv("MAIL_FILE_DIR", "./mail"),
+		RequireEmailVerification: requireVerification,
+		MFARequiredRoles:         getEnvList("MFA_REQUIRED_ROLES"),
+		MFAIssuer:                getEnv("MFA_ISSUER", "WoundIQ"),
+		LockoutThreshold:         lockoutThreshold,
+		LockoutDuration:          lockoutDuration,
+		IPThrottleThreshold:      ipThrottleThreshold,
+		IPThrottleWindow:         ipThrottleWindow,
+		TrustedProxies:           getEnvList("TRUSTED_PROXIES"),
+		TokenDenylist:            getEnv("TOKEN_DENYLIST", "memory"),
+		JWTKeysDir:               os.Getenv("JWT_KEYS_DIR"),
+		JWTActiveKID:             os.Getenv("JWT_ACTIVE_KID"),
+		JWTIssuer:                getEnv("JWT_ISSUER", appBaseURL),
+		JWTAudience:              getEnv("JWT_AUDIENCE", "wound-iq-api"),
+		PasswordMinLength:        passwordMinLength,
+		PasswordMaxLength:        passwordMaxLength,
+		PasswordRequireUpper:     requireUpper,
+		PasswordRequireLower:     requireLower,
+		PasswordRequireDigit:     requireDigit,
+		PasswordRequireSymbol:    requireSymbol,
+		PasswordHistory:          passwordHistory,
+		PasswordMaxAge:           passwordMaxAge,
+		BreachedPasswordsFile:    os.Getenv("BREACHED_PASSWORDS_FILE"),
+		PasswordHasher:           getEnv("PASSWORD_HASHER", "bcrypt"),
+		BcryptCost:               bcryptCost,
+		Argon2Memory:             argon2Memory,
+		Argon2Iterations:         argon2Iterations,
+		Argon2Parallelism:        argon2Parallelism,
+		OIDCIssuerURL:            os.Getenv("OIDC_ISSUER_URL"),
+		OIDCClientID:             os.Getenv("OIDC_CLIENT_ID"),
+		OIDCClientSecret:         os.Getenv("OIDC_CLIENT_SECRET"),
+		OIDCRedirectURL:          getEnv("OIDC_REDIRECT_URL", appBaseURL+"/api/v1/auth/sso/callback"),
+		OIDCScopes:               strings.Fields(getEnv("OIDC_SCOPES", "openid email profile")),
+		OIDCProvisionClinicians:  oidcProvision,
+		OIDCTrustMFA:             oidcTrustMFA,
 	}, nil
 }
+
+// getEnv returns the environment variable or a default if unset
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getEnvList splits a comma-separated environment variable, dropping empty items
+func getEnvList(key string) []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getEnvBool parses a boolean environment variable
+func getEnvBool(key string, fallback bool) (bool, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be a boolean: %w", key, err)
+	}
+	return b, nil
+}
+
+// getEnvInt parses an integer environment variable
+func getEnvInt(key string, fallback int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer: %w", key, err)
+	}
+	return n, nil
+}
+
+// getEnvDuration parses a duration environment variable such as "15m"
+func getEnvDuration(key string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a duration (e.g. 15m): %w", key, err)
+	}
+	return d, nil
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrations_Embedded(t *testing.T) {
+	migrations, err := Migrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	assert.Equal(t, "core_schema", migrations[0].Name)
+	for _, m := range migrations {
+		assert.NotEmpty(t, strings.TrimSpace(m.Up), "%d_%s up", m.Version, m.Name)
+		assert.NotEmpty(t, strings.TrimSpace(m.Down), "%d_%s down", m.Version, m.Name)
+	}
+
+	var functions strings.Builder
+	for _, m := range migrations {
+		functions.WriteString(m.Up)
+	}
+	for _, fn := range []string{"add_patient", "add_full_assessment", "get_assessment_full", "get_patient_wound_history"} {
+		assert.Contains(t, functions.String(), "CREATE OR REPLACE FUNCTION "+fn+"(")
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	file := func(body string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(body)} }
+
+	t.Run("ordered by version", func(t *testing.T) {
+		migrations, err := loadMigrations(fstest.MapFS{
+			"m/0002_second.up.sql":   file("up 2"),
+			"m/0002_second.down.sql": file("down 2"),
+			"m/0001_first.up.sql":    file("up 1"),
+			"m/0001_first.down.sql":  file("down 1"),
+		}, "m")
+		require.NoError(t, err)
+		require.Len(t, migrations, 2)
+		assert.Equal(t, Migration{Version: 1, Name: "first", Up: "up 1", Down: "down 1"}, migrations[0])
+		assert.Equal(t, "second", migrations[1].Name)
+	})
+
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+	}{
+		{"missing down", fstest.MapFS{"m/0001_first.up.sql": file("up")}},
+		{"gap in versions", fstest.MapFS{
+			"m/0001_first.up.sql": file("up"), "m/0001_first.down.sql": file("down"),
+			"m/0003_third.up.sql": file("up"), "m/0003_third.down.sql": file("down"),
+		}},
+		{"mismatched names", fstest.MapFS{
+			"m/0001_first.up.sql": file("up"), "m/0001_other.down.sql": file("down"),
+		}},
+		{"bad file name", fstest.MapFS{"m/first.sql": file("up")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadMigrations(tt.files, "m")
+			assert.Error(t, err)
+		})
+	}
+}
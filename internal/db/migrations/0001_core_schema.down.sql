@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS treatment;
+DROP TABLE IF EXISTS exudate;
+DROP TABLE IF EXISTS wound_condition;
+DROP TABLE IF EXISTS vitals;
+DROP TABLE IF EXISTS tissue_status;
+DROP TABLE IF EXISTS infection_and_pain;
+DROP TABLE IF EXISTS assessment;
+DROP TABLE IF EXISTS clinician;
+DROP TABLE IF EXISTS patient;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,137 @@
+-- Core tables: user accounts, the patient and clinician profiles linked to
+-- them, and wound assessments with their child sections. Every statement is
+-- guarded so the migration is a no-op on databases created from the original
+-- wound_iq_schema_creation.sql script.
+
+CREATE TABLE IF NOT EXISTS users (
+    id             SERIAL PRIMARY KEY,
+    email          VARCHAR(255) NOT NULL UNIQUE,
+    password_hash  TEXT         NOT NULL,
+    role           VARCHAR(20)  NOT NULL CHECK (role IN ('admin', 'clinician', 'patient')),
+    is_active      BOOLEAN      NOT NULL DEFAULT true,
+    email_verified BOOLEAN      NOT NULL DEFAULT false,
+    created_at     TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+-- Refresh tokens as first issued; 0010_refresh_token_rotation replaces the
+-- plaintext token with a hash
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id         SERIAL PRIMARY KEY,
+    user_id    INT         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token      TEXT        NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked    BOOLEAN     NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user ON refresh_tokens(user_id);
+
+-- user_id is set for patients who registered an account; patients entered
+-- by staff have none
+CREATE TABLE IF NOT EXISTS patient (
+    patient_id            SERIAL PRIMARY KEY,
+    user_id               INT UNIQUE REFERENCES users(id) ON DELETE SET NULL,
+    first_name            VARCHAR(50),
+    last_name             VARCHAR(50),
+    full_name             VARCHAR(100) NOT NULL,
+    date_of_birth         DATE         NOT NULL,
+    gender                VARCHAR(10)  NOT NULL,
+    medical_record_number VARCHAR(50)  NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS clinician (
+    clinician_id   SERIAL PRIMARY KEY,
+    user_id        INT UNIQUE REFERENCES users(id) ON DELETE SET NULL,
+    first_name     VARCHAR(50),
+    last_name      VARCHAR(50),
+    full_name      VARCHAR(100) NOT NULL,
+    role           VARCHAR(20)  NOT NULL,
+    department     VARCHAR(20)  NOT NULL,
+    contact_info   VARCHAR(500) NOT NULL,
+    license_number VARCHAR(50)  NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS assessment (
+    assessment_id    SERIAL PRIMARY KEY,
+    clinician_id     INT         NOT NULL REFERENCES clinician(clinician_id),
+    patient_id       INT         NOT NULL REFERENCES patient(patient_id) ON DELETE CASCADE,
+    date             TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    location         VARCHAR(50) NOT NULL,
+    etiology         VARCHAR(50) NOT NULL,
+    depth_of_injury  VARCHAR(50) NOT NULL,
+    stage            VARCHAR(15) NOT NULL,
+    chronicity       VARCHAR(15) NOT NULL,
+    healing_status   VARCHAR(20) NOT NULL,
+    return_to_clinic BOOLEAN     NOT NULL DEFAULT false
+);
+
+CREATE INDEX IF NOT EXISTS idx_assessment_patient ON assessment(patient_id, date DESC);
+CREATE INDEX IF NOT EXISTS idx_assessment_clinician ON assessment(clinician_id);
+
+-- Child sections, one row per assessment each
+
+CREATE TABLE IF NOT EXISTS infection_and_pain (
+    infection_pain_id  SERIAL PRIMARY KEY,
+    assessment_id      INT NOT NULL UNIQUE REFERENCES assessment(assessment_id) ON DELETE CASCADE,
+    localized_symptoms VARCHAR(20) NOT NULL,
+    systemic_symptoms  VARCHAR(20) NOT NULL,
+    pain_present       VARCHAR(20) NOT NULL,
+    pain_score         VARCHAR(20) NOT NULL,
+    culture_results    VARCHAR(20) NOT NULL,
+    antibiotic         VARCHAR(20) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS tissue_status (
+    tissue_status_id    SERIAL PRIMARY KEY,
+    assessment_id       INT NOT NULL UNIQUE REFERENCES assessment(assessment_id) ON DELETE CASCADE,
+    granulation_percent INT NOT NULL CHECK (granulation_percent BETWEEN 0 AND 100),
+    epithelial_percent  INT NOT NULL CHECK (epithelial_percent BETWEEN 0 AND 100),
+    slough_percent      INT NOT NULL CHECK (slough_percent BETWEEN 0 AND 100),
+    eschar_percent      INT NOT NULL CHECK (eschar_percent BETWEEN 0 AND 100),
+    necrotic_percent    INT NOT NULL CHECK (necrotic_percent BETWEEN 0 AND 100),
+    debridement         VARCHAR(15) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS vitals (
+    vitals_id         SERIAL PRIMARY KEY,
+    assessment_id     INT NOT NULL UNIQUE REFERENCES assessment(assessment_id) ON DELETE CASCADE,
+    blood_pressure    VARCHAR(10)  NOT NULL,
+    temperature       NUMERIC(4,1) NOT NULL,
+    pulse             INT          NOT NULL,
+    respiration_rate  INT          NOT NULL,
+    oxygen_saturation INT          NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS wound_condition (
+    wound_condition_id SERIAL PRIMARY KEY,
+    assessment_id      INT NOT NULL UNIQUE REFERENCES assessment(assessment_id) ON DELETE CASCADE,
+    length             NUMERIC(6,2) NOT NULL,
+    width              NUMERIC(6,2) NOT NULL,
+    depth              NUMERIC(6,2) NOT NULL,
+    tunneling          BOOLEAN      NOT NULL DEFAULT false,
+    undermining        BOOLEAN      NOT NULL DEFAULT false,
+    edges              VARCHAR(15)  NOT NULL,
+    skin_condition     VARCHAR(20)  NOT NULL,
+    edema              VARCHAR(20)  NOT NULL,
+    blister            VARCHAR(20)  NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS exudate (
+    exudate_id     SERIAL PRIMARY KEY,
+    assessment_id  INT NOT NULL UNIQUE REFERENCES assessment(assessment_id) ON DELETE CASCADE,
+    exudate_type   VARCHAR(20) NOT NULL,
+    exudate_amount VARCHAR(20) NOT NULL,
+    odor           VARCHAR(20) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS treatment (
+    treatment_id       SERIAL PRIMARY KEY,
+    assessment_id      INT NOT NULL UNIQUE REFERENCES assessment(assessment_id) ON DELETE CASCADE,
+    primary_dressing   VARCHAR(15)  NOT NULL,
+    secondary_dressing VARCHAR(15)  NOT NULL,
+    tertiary_dressing  VARCHAR(15)  NOT NULL,
+    frequency          VARCHAR(15)  NOT NULL,
+    supplies           VARCHAR(500) NOT NULL DEFAULT '',
+    orders             VARCHAR(200) NOT NULL DEFAULT ''
+);
@@ -0,0 +1,12 @@
+DROP FUNCTION IF EXISTS get_patient_wound_history(INT);
+DROP FUNCTION IF EXISTS get_assessment_full(INT);
+DROP FUNCTION IF EXISTS add_full_assessment(
+    INT, INT, VARCHAR, VARCHAR, VARCHAR, VARCHAR, VARCHAR, VARCHAR, BOOLEAN,
+    VARCHAR, VARCHAR, VARCHAR, VARCHAR, VARCHAR, VARCHAR,
+    INT, INT, INT, INT, INT, VARCHAR,
+    VARCHAR, NUMERIC, INT, INT, INT,
+    NUMERIC, NUMERIC, NUMERIC, BOOLEAN, BOOLEAN, VARCHAR, VARCHAR, VARCHAR, VARCHAR,
+    VARCHAR, VARCHAR, VARCHAR,
+    VARCHAR, VARCHAR, VARCHAR, VARCHAR, VARCHAR, VARCHAR
+);
+DROP FUNCTION IF EXISTS add_patient(VARCHAR, DATE, VARCHAR, VARCHAR);
@@ -0,0 +1,171 @@
+-- Functions called by the patient and assessment repositories
+
+-- Creates a patient entered by staff and returns its ID
+CREATE OR REPLACE FUNCTION add_patient(
+    p_full_name     VARCHAR,
+    p_date_of_birth DATE,
+    p_gender        VARCHAR,
+    p_mrn           VARCHAR
+) RETURNS INT
+LANGUAGE sql
+AS $$
+    INSERT INTO patient (full_name, date_of_birth, gender, medical_record_number)
+    VALUES (p_full_name, p_date_of_birth, p_gender, p_mrn)
+    RETURNING patient_id;
+$$;
+
+-- Creates an assessment and all six child sections in one statement and
+-- returns the assessment ID
+CREATE OR REPLACE FUNCTION add_full_assessment(
+    -- Assessment
+    p_clinician_id     INT,
+    p_patient_id       INT,
+    p_location         VARCHAR,
+    p_etiology         VARCHAR,
+    p_depth_of_injury  VARCHAR,
+    p_stage            VARCHAR,
+    p_chronicity       VARCHAR,
+    p_healing_status   VARCHAR,
+    p_return_to_clinic BOOLEAN,
+    -- Infection and pain
+    p_localized_symptoms VARCHAR,
+    p_systemic_symptoms  VARCHAR,
+    p_pain_present       VARCHAR,
+    p_pain_score         VARCHAR,
+    p_culture_results    VARCHAR,
+    p_antibiotic         VARCHAR,
+    -- Tissue status
+    p_granulation_percent INT,
+    p_epithelial_percent  INT,
+    p_slough_percent      INT,
+    p_eschar_percent      INT,
+    p_necrotic_percent    INT,
+    p_debridement         VARCHAR,
+    -- Vitals
+    p_blood_pressure    VARCHAR,
+    p_temperature       NUMERIC,
+    p_pulse             INT,
+    p_respiration_rate  INT,
+    p_oxygen_saturation INT,
+    -- Wound condition
+    p_length         NUMERIC,
+    p_width          NUMERIC,
+    p_depth          NUMERIC,
+    p_tunneling      BOOLEAN,
+    p_undermining    BOOLEAN,
+    p_edges          VARCHAR,
+    p_skin_condition VARCHAR,
+    p_edema          VARCHAR,
+    p_blister        VARCHAR,
+    -- Exudate
+    p_exudate_type   VARCHAR,
+    p_exudate_amount VARCHAR,
+    p_odor           VARCHAR,
+    -- Treatment
+    p_primary_dressing   VARCHAR,
+    p_secondary_dressing VARCHAR,
+    p_tertiary_dressing  VARCHAR,
+    p_frequency          VARCHAR,
+    p_supplies           VARCHAR,
+    p_orders             VARCHAR
+) RETURNS INT
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    v_assessment_id INT;
+BEGIN
+    INSERT INTO assessment (clinician_id, patient_id, date, location, etiology,
+                            depth_of_injury, stage, chronicity, healing_status, return_to_clinic)
+    VALUES (p_clinician_id, p_patient_id, NOW(), p_location, p_etiology,
+            p_depth_of_injury, p_stage, p_chronicity, p_healing_status, p_return_to_clinic)
+    RETURNING assessment_id INTO v_assessment_id;
+
+    INSERT INTO infection_and_pain (assessment_id, localized_symptoms, systemic_symptoms,
+                                    pain_present, pain_score, culture_results, antibiotic)
+    VALUES (v_assessment_id, p_localized_symptoms, p_systemic_symptoms,
+            p_pain_present, p_pain_score, p_culture_results, p_antibiotic);
+
+    INSERT INTO tissue_status (assessment_id, granulation_percent, epithelial_percent,
+                               slough_percent, eschar_percent, necrotic_percent, debridement)
+    VALUES (v_assessment_id, p_granulation_percent, p_epithelial_percent,
+            p_slough_percent, p_eschar_percent, p_necrotic_percent, p_debridement);
+
+    INSERT INTO vitals (assessment_id, blood_pressure, temperature, pulse,
+                        respiration_rate, oxygen_saturation)
+    VALUES (v_assessment_id, p_blood_pressure, p_temperature, p_pulse,
+            p_respiration_rate, p_oxygen_saturation);
+
+    INSERT INTO wound_condition (assessment_id, length, width, depth, tunneling, undermining,
+                                 edges, skin_condition, edema, blister)
+    VALUES (v_assessment_id, p_length, p_width, p_depth, p_tunneling, p_undermining,
+            p_edges, p_skin_condition, p_edema, p_blister);
+
+    INSERT INTO exudate (assessment_id, exudate_type, exudate_amount, odor)
+    VALUES (v_assessment_id, p_exudate_type, p_exudate_amount, p_odor);
+
+    INSERT INTO treatment (assessment_id, primary_dressing, secondary_dressing,
+                           tertiary_dressing, frequency, supplies, orders)
+    VALUES (v_assessment_id, p_primary_dressing, p_secondary_dressing,
+            p_tertiary_dressing, p_frequency, COALESCE(p_supplies, ''), COALESCE(p_orders, ''));
+
+    RETURN v_assessment_id;
+END;
+$$;
+
+-- The original functions script declared different result columns, which
+-- CREATE OR REPLACE cannot change
+DROP FUNCTION IF EXISTS get_assessment_full(INT);
+DROP FUNCTION IF EXISTS get_patient_wound_history(INT);
+
+-- Summary of one assessment with its patient, clinician and key measurements.
+-- Sections missing for header-only assessments come back as zero values.
+CREATE OR REPLACE FUNCTION get_assessment_full(p_assessment_id INT)
+RETURNS TABLE (
+    assessment_id       INT,
+    assessment_date     TIMESTAMPTZ,
+    patient_id          INT,
+    patient_name        VARCHAR,
+    clinician_id        INT,
+    clinician_name      VARCHAR,
+    location            VARCHAR,
+    etiology            VARCHAR,
+    stage               VARCHAR,
+    healing_status      VARCHAR,
+    pain_score          VARCHAR,
+    granulation_percent INT,
+    length              DOUBLE PRECISION,
+    width               DOUBLE PRECISION
+)
+LANGUAGE sql STABLE
+AS $$
+    SELECT a.assessment_id, a.date, p.patient_id, p.full_name,
+           c.clinician_id, c.full_name, a.location, a.etiology, a.stage, a.healing_status,
+           COALESCE(ip.pain_score, '')::VARCHAR,
+           COALESCE(ts.granulation_percent, 0),
+           COALESCE(wc.length, 0)::DOUBLE PRECISION,
+           COALESCE(wc.width, 0)::DOUBLE PRECISION
+    FROM assessment a
+    JOIN patient p ON p.patient_id = a.patient_id
+    JOIN clinician c ON c.clinician_id = a.clinician_id
+    LEFT JOIN infection_and_pain ip ON ip.assessment_id = a.assessment_id
+    LEFT JOIN tissue_status ts ON ts.assessment_id = a.assessment_id
+    LEFT JOIN wound_condition wc ON wc.assessment_id = a.assessment_id
+    WHERE a.assessment_id = p_assessment_id;
+$$;
+
+-- A patient's assessments, newest first
+CREATE OR REPLACE FUNCTION get_patient_wound_history(p_patient_id INT)
+RETURNS TABLE (
+    assessment_id   INT,
+    assessment_date TIMESTAMPTZ,
+    location        VARCHAR,
+    stage           VARCHAR,
+    healing_status  VARCHAR
+)
+LANGUAGE sql STABLE
+AS $$
+    SELECT a.assessment_id, a.date, a.location, a.stage, a.healing_status
+    FROM assessment a
+    WHERE a.patient_id = p_patient_id
+    ORDER BY a.date DESC, a.assessment_id DESC;
+$$;
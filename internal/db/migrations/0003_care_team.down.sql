@@ -0,0 +1,2 @@
+-- btree_gist is left installed; dropping an extension can break other objects
+DROP TABLE IF EXISTS care_team_assignment;
@@ -0,0 +1,27 @@
+-- Care-team assignments link clinicians to the patients they treat.
+-- An assignment is active from start_date (inclusive) until end_date (exclusive);
+-- a NULL end_date means open-ended.
+
+-- btree_gist lets the exclusion constraint below compare the integer ids
+-- with = alongside the date-range overlap
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TABLE IF NOT EXISTS care_team_assignment (
+    assignment_id SERIAL PRIMARY KEY,
+    patient_id    INT  NOT NULL REFERENCES patient(patient_id) ON DELETE CASCADE,
+    clinician_id  INT  NOT NULL REFERENCES clinician(clinician_id) ON DELETE CASCADE,
+    start_date    DATE NOT NULL DEFAULT CURRENT_DATE,
+    end_date      DATE,
+    assigned_by   INT  REFERENCES users(id) ON DELETE SET NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT care_team_dates_chk CHECK (end_date IS NULL OR end_date >= start_date),
+    -- A clinician's assignments to the same patient may not overlap
+    CONSTRAINT care_team_no_overlap EXCLUDE USING gist (
+        patient_id WITH =,
+        clinician_id WITH =,
+        daterange(start_date, end_date, '[)') WITH &&
+    )
+);
+
+CREATE INDEX IF NOT EXISTS idx_care_team_patient ON care_team_assignment(patient_id);
+CREATE INDEX IF NOT EXISTS idx_care_team_clinician ON care_team_assignment(clinician_id);
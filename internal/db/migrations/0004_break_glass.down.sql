@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS break_glass_access;
+DROP TABLE IF EXISTS break_glass_event;
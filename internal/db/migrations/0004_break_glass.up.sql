@@ -0,0 +1,26 @@
+-- Break-glass (emergency access) events. A clinician states a reason and
+-- receives a short-lived token that bypasses care-team scoping; every
+-- patient record touched with that token is logged for later review.
+CREATE TABLE IF NOT EXISTS break_glass_event (
+    event_id     SERIAL PRIMARY KEY,
+    user_id      INT  NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason       TEXT NOT NULL,
+    client_ip    TEXT,
+    started_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at   TIMESTAMPTZ NOT NULL,
+    reviewed_by  INT  REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at  TIMESTAMPTZ,
+    review_notes TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_break_glass_event_user ON break_glass_event(user_id);
+
+CREATE TABLE IF NOT EXISTS break_glass_access (
+    access_id   SERIAL PRIMARY KEY,
+    event_id    INT  NOT NULL REFERENCES break_glass_event(event_id) ON DELETE CASCADE,
+    patient_id  INT,
+    resource    TEXT NOT NULL,
+    accessed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_break_glass_access_event ON break_glass_access(event_id);
@@ -0,0 +1,21 @@
+-- TOTP multi-factor authentication. A row is created (disabled) when the
+-- user starts enrollment and enabled once they confirm a code.
+CREATE TABLE IF NOT EXISTS user_mfa (
+    user_id        INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret         TEXT    NOT NULL,
+    enabled        BOOLEAN NOT NULL DEFAULT false,
+    confirmed_at   TIMESTAMPTZ,
+    last_used_step BIGINT  NOT NULL DEFAULT 0, -- rejects replay of an accepted code
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Single-use recovery codes; only SHA-256 hashes are stored.
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    id         SERIAL PRIMARY KEY,
+    user_id    INT  NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash  TEXT NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_mfa_recovery_user ON mfa_recovery_codes(user_id);
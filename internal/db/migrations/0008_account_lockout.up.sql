@@ -0,0 +1,16 @@
+-- Account lockout after repeated failed logins
+ALTER TABLE users ADD COLUMN IF NOT EXISTS failed_login_attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS last_failed_login_at  TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locked_until          TIMESTAMPTZ;
+
+-- Failed logins by client IP, used to throttle password guessing across
+-- accounts. Only rows inside the throttle window are read; older rows may be
+-- pruned at any time.
+CREATE TABLE IF NOT EXISTS login_failures (
+    id           BIGSERIAL PRIMARY KEY,
+    client_ip    TEXT NOT NULL,
+    email        TEXT,
+    attempted_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_failures_ip ON login_failures(client_ip, attempted_at);
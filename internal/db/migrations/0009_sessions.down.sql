@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_refresh_tokens_user_active;
+
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS client_ip;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS user_agent;
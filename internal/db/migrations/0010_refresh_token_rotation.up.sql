@@ -0,0 +1,34 @@
+-- Refresh tokens are stored as SHA-256 hashes and grouped into rotation
+-- families. Presenting a revoked token from a family revokes the family.
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS token_hash  TEXT;
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS family_id   TEXT;
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS revoked_at  TIMESTAMPTZ;
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS replaced_by INT REFERENCES refresh_tokens(id);
+
+-- Hash existing tokens, give each its own family and drop the plaintext
+UPDATE refresh_tokens
+SET token_hash = encode(sha256(convert_to(token, 'UTF8')), 'hex'),
+    family_id  = id::text
+WHERE token_hash IS NULL AND token IS NOT NULL;
+
+ALTER TABLE refresh_tokens ALTER COLUMN token DROP NOT NULL;
+UPDATE refresh_tokens SET token = NULL WHERE token IS NOT NULL;
+
+ALTER TABLE refresh_tokens ALTER COLUMN token_hash SET NOT NULL;
+ALTER TABLE refresh_tokens ALTER COLUMN family_id  SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refresh_tokens_hash   ON refresh_tokens(token_hash);
+CREATE INDEX        IF NOT EXISTS idx_refresh_tokens_family ON refresh_tokens(family_id);
+
+-- Security-relevant events (e.g. refresh token reuse) for later review
+CREATE TABLE IF NOT EXISTS security_events (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    INT REFERENCES users(id) ON DELETE SET NULL,
+    event_type TEXT NOT NULL,
+    detail     TEXT,
+    client_ip  TEXT,
+    user_agent TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_security_events_user ON security_events(user_id, created_at);
@@ -0,0 +1,13 @@
+-- Revoked access tokens, used when TOKEN_DENYLIST=postgres.
+-- Rows can be deleted once expires_at has passed.
+CREATE TABLE IF NOT EXISTS revoked_access_tokens (
+    jti        TEXT PRIMARY KEY,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Every access token issued to the user before revoked_before is rejected
+CREATE TABLE IF NOT EXISTS user_token_revocations (
+    user_id        INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    revoked_before TIMESTAMPTZ NOT NULL
+);
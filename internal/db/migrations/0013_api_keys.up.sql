@@ -0,0 +1,14 @@
+-- Admin-managed API keys for machine-to-machine integrations.
+-- Only the SHA-256 hash of a key is stored; key_prefix identifies it in listings.
+CREATE TABLE IF NOT EXISTS api_keys (
+    id           SERIAL PRIMARY KEY,
+    name         TEXT        NOT NULL,
+    key_prefix   TEXT        NOT NULL,
+    key_hash     TEXT        NOT NULL UNIQUE,
+    permissions  TEXT[]      NOT NULL DEFAULT '{}',
+    created_by   INT         REFERENCES users(id) ON DELETE SET NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at   TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at   TIMESTAMPTZ
+);
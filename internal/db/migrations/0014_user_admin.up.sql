@@ -0,0 +1,5 @@
+-- Set by an administrator to block password login until the user completes
+-- a password reset; cleared when the password is changed or reset
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_reset_required BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS idx_users_role ON users(role);
@@ -0,0 +1,66 @@
+-- Restore the report functions without the deleted_at filters, then drop
+-- the soft-delete columns (deleted rows become visible again)
+
+CREATE OR REPLACE FUNCTION get_assessment_full(p_assessment_id INT)
+RETURNS TABLE (
+    assessment_id       INT,
+    assessment_date     TIMESTAMPTZ,
+    patient_id          INT,
+    patient_name        VARCHAR,
+    clinician_id        INT,
+    clinician_name      VARCHAR,
+    location            VARCHAR,
+    etiology            VARCHAR,
+    stage               VARCHAR,
+    healing_status      VARCHAR,
+    pain_score          VARCHAR,
+    granulation_percent INT,
+    length              DOUBLE PRECISION,
+    width               DOUBLE PRECISION
+)
+LANGUAGE sql STABLE
+AS $$
+    SELECT a.assessment_id, a.date, p.patient_id, p.full_name,
+           c.clinician_id, c.full_name, a.location, a.etiology, a.stage, a.healing_status,
+           COALESCE(ip.pain_score, '')::VARCHAR,
+           COALESCE(ts.granulation_percent, 0),
+           COALESCE(wc.length, 0)::DOUBLE PRECISION,
+           COALESCE(wc.width, 0)::DOUBLE PRECISION
+    FROM assessment a
+    JOIN patient p ON p.patient_id = a.patient_id
+    JOIN clinician c ON c.clinician_id = a.clinician_id
+    LEFT JOIN infection_and_pain ip ON ip.assessment_id = a.assessment_id
+    LEFT JOIN tissue_status ts ON ts.assessment_id = a.assessment_id
+    LEFT JOIN wound_condition wc ON wc.assessment_id = a.assessment_id
+    WHERE a.assessment_id = p_assessment_id;
+$$;
+
+-- A patient's assessments, newest first
+CREATE OR REPLACE FUNCTION get_patient_wound_history(p_patient_id INT)
+RETURNS TABLE (
+    assessment_id   INT,
+    assessment_date TIMESTAMPTZ,
+    location        VARCHAR,
+    stage           VARCHAR,
+    healing_status  VARCHAR
+)
+LANGUAGE sql STABLE
+AS $$
+    SELECT a.assessment_id, a.date, a.location, a.stage, a.healing_status
+    FROM assessment a
+    WHERE a.patient_id = p_patient_id
+    ORDER BY a.date DESC, a.assessment_id DESC;
+$$;
+
+DROP INDEX IF EXISTS idx_assessment_deleted;
+DROP INDEX IF EXISTS idx_patient_deleted;
+
+ALTER TABLE assessment
+    DROP COLUMN IF EXISTS delete_reason,
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE patient
+    DROP COLUMN IF EXISTS delete_reason,
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at;
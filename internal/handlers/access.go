@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/middleware"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// currentCaller builds the access-control identity of the authenticated caller
+func currentCaller(c *gin.Context) service.Caller {
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetUserRole(c)
+	breakGlassID, _ := middleware.GetBreakGlassID(c)
+
+	return service.Caller{UserID: userID, Role: role, BreakGlassID: breakGlassID}
+}
+
+// authorizePatientAccess writes a 403 (or 500) response and returns false
+// unless the authenticated caller may access the given patient's records.
+// Accesses made under a break-glass token are audited.
+func authorizePatientAccess(c *gin.Context, access *service.AccessService, patientID int) bool {
+	caller := currentCaller(c)
+
+	allowed, err := access.CanAccessPatient(caller, patientID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to check access",
+			Message: err.Error(),
+		})
+		return false
+	}
+
+	if !allowed {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "Access denied",
+			Message: "You do not have access to this patient's records",
+		})
+		return false
+	}
+
+	return recordAccess(c, access, caller, patientID)
+}
+
+// auditListAccess records a list query made under a break-glass token: one
+// access per patient whose records were returned, or a single access with no
+// patient when the page is empty. It writes a 500 response and returns false
+// if an audit record cannot be saved.
+func auditListAccess(c *gin.Context, access *service.AccessService, patientIDs []int) bool {
+	caller := currentCaller(c)
+	if caller.BreakGlassID == 0 {
+		return true
+	}
+	if len(patientIDs) == 0 {
+		return recordAccess(c, access, caller, 0)
+	}
+
+	seen := make(map[int]bool, len(patientIDs))
+	for _, patientID := range patientIDs {
+		if seen[patientID] {
+			continue
+		}
+		seen[patientID] = true
+		if !recordAccess(c, access, caller, patientID) {
+			return false
+		}
+	}
+	return true
+}
+
+func recordAccess(c *gin.Context, access *service.AccessService, caller service.Caller, patientID int) bool {
+	resource := c.Request.Method + " " + c.Request.URL.RequestURI()
+	if err := access.RecordAccess(caller, patientID, resource); err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to record emergency access",
+			Message: err.Error(),
+		})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,399 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/middleware"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/service"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHandler handles account administration requests
+type AdminHandler struct {
+	admin *service.AdminService
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(admin *service.AdminService) *AdminHandler {
+	return &AdminHandler{admin: admin}
+}
+
+// ListUsers searches user accounts
+// Query params: q (email or name), role, active, page, page_size
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	var filter models.UserFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	users, total, err := h.admin.ListUsers(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to query users",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	totalPages := (total + filter.GetLimit() - 1) / filter.GetLimit()
+
+	c.JSON(http.StatusOK, models.PaginatedResponse{
+		Data:       users,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalCount: total,
+		TotalPages: totalPages,
+	})
+}
+
+// GetUser returns one user account
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	userID, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.admin.GetUser(userID)
+	if errors.Is(err, utils.ErrUserNotFound) {
+		userNotFound(c, userID)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to query user",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// CreateUser creates a clinician or patient account
+func (h *AdminHandler) CreateUser(c *gin.Context) {
+	var req models.CreateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	adminID, _ := middleware.GetUserID(c)
+
+	user, err := h.admin.CreateUser(req, adminID)
+	switch {
+	case err == nil:
+		c.JSON(http.StatusCreated, user)
+	case errors.Is(err, utils.ErrEmailExists), errors.Is(err, utils.ErrPasswordPolicy):
+		c.JSON(http.StatusBadRequest, passwordErrorBody(err))
+	default:
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to create user",
+			Message: err.Error(),
+		})
+	}
+}
+
+// ChangeUserRole moves a user to another role and signs them out
+func (h *AdminHandler) ChangeUserRole(c *gin.Context) {
+	userID, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.ChangeRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	adminID, _ := middleware.GetUserID(c)
+	if userID == adminID {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request",
+			Message: "You cannot change your own role",
+		})
+		return
+	}
+
+	user, err := h.admin.ChangeRole(userID, req.Role, adminID)
+	if errors.Is(err, utils.ErrUserNotFound) {
+		userNotFound(c, userID)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to change role",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// ForcePasswordReset blocks password login and emails the user a reset link
+func (h *AdminHandler) ForcePasswordReset(c *gin.Context) {
+	userID, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	adminID, _ := middleware.GetUserID(c)
+
+	err := h.admin.ForcePasswordReset(userID, adminID)
+	if errors.Is(err, utils.ErrUserNotFound) {
+		userNotFound(c, userID)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to force password reset",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: fmt.Sprintf("User %d must reset their password; a reset link has been emailed", userID),
+	})
+}
+
+// RevokeAllUserSessions signs a user out everywhere
+func (h *AdminHandler) RevokeAllUserSessions(c *gin.Context) {
+	userID, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	adminID, _ := middleware.GetUserID(c)
+
+	err := h.admin.RevokeAllUserSessions(userID, adminID)
+	if errors.Is(err, utils.ErrUserNotFound) {
+		userNotFound(c, userID)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to revoke sessions",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: fmt.Sprintf("All sessions of user %d revoked", userID),
+	})
+}
+
+// ListLockedUsers returns the accounts currently locked after failed logins
+func (h *AdminHandler) ListLockedUsers(c *gin.Context) {
+	users, err := h.admin.ListLockedUsers()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to query locked users",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"users":       users,
+		"total_count": len(users),
+	})
+}
+
+// GetUserLock returns a user's lockout state
+func (h *AdminHandler) GetUserLock(c *gin.Context) {
+	userID, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	status, err := h.admin.GetLockStatus(userID)
+	if errors.Is(err, utils.ErrUserNotFound) {
+		userNotFound(c, userID)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to query lock status",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// UnlockUser clears a user's lockout
+func (h *AdminHandler) UnlockUser(c *gin.Context) {
+	userID, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	adminID, _ := middleware.GetUserID(c)
+
+	status, err := h.admin.UnlockUser(userID, adminID)
+	if errors.Is(err, utils.ErrUserNotFound) {
+		userNotFound(c, userID)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to unlock user",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// ListUserSessions returns a user's active sessions
+func (h *AdminHandler) ListUserSessions(c *gin.Context) {
+	userID, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.admin.ListUserSessions(userID)
+	if errors.Is(err, utils.ErrUserNotFound) {
+		userNotFound(c, userID)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to query sessions",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id":  userID,
+		"sessions": sessions,
+	})
+}
+
+// RevokeUserSession revokes one of a user's sessions
+func (h *AdminHandler) RevokeUserSession(c *gin.Context) {
+	userID, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	sessionID, err := strconv.Atoi(c.Param("session_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid session ID",
+			Message: "Session ID must be a valid integer",
+		})
+		return
+	}
+
+	adminID, _ := middleware.GetUserID(c)
+
+	err = h.admin.RevokeUserSession(userID, sessionID, adminID)
+	if errors.Is(err, utils.ErrNotFound) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Session not found",
+			Message: fmt.Sprintf("No active session %d for user %d", sessionID, userID),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to revoke session",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: fmt.Sprintf("Session %d revoked", sessionID),
+	})
+}
+
+// DeactivateUser blocks a user from logging in and revokes their tokens
+func (h *AdminHandler) DeactivateUser(c *gin.Context) {
+	h.setUserActive(c, false)
+}
+
+// ActivateUser re-enables a deactivated user
+func (h *AdminHandler) ActivateUser(c *gin.Context) {
+	h.setUserActive(c, true)
+}
+
+func (h *AdminHandler) setUserActive(c *gin.Context, active bool) {
+	userID, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	adminID, _ := middleware.GetUserID(c)
+	if !active && userID == adminID {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request",
+			Message: "You cannot deactivate your own account",
+		})
+		return
+	}
+
+	err := h.admin.SetUserActive(userID, active, adminID)
+	if errors.Is(err, utils.ErrUserNotFound) {
+		userNotFound(c, userID)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to update user",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	state := "deactivated"
+	if active {
+		state = "activated"
+	}
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: fmt.Sprintf("User %d %s", userID, state),
+	})
+}
+
+// parseUserID reads the :id path parameter, writing a 400 if it is invalid
+func parseUserID(c *gin.Context) (int, bool) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid user ID",
+			Message: "User ID must be a valid integer",
+		})
+		return 0, false
+	}
+	return userID, true
+}
+
+func userNotFound(c *gin.Context, userID int) {
+	c.JSON(http.StatusNotFound, models.ErrorResponse{
+		Error:   "User not found",
+		Message: fmt.Sprintf("User with ID %d does not exist", userID),
+	})
+}
@@ -0,0 +1,95 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/testserver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// waitForNextSecond sleeps past the current second. Per-user token revocation
+// has the one-second resolution of the iat claim, so tokens must be issued in
+// an earlier second than the admin action for it to reject them.
+func waitForNextSecond() {
+	time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(time.Second)))
+}
+
+func TestAdmin_AccountActions(t *testing.T) {
+	srv := testserver.New(t)
+	admin := srv.CreateUser("admin@example.com", "secret123", models.RoleAdmin)
+	leaver := srv.CreateUser("leaver@example.com", "secret123", models.RoleClinician)
+	promoted := srv.CreateUser("promoted@example.com", "secret123", models.RoleClinician)
+	reset := srv.CreateUser("reset@example.com", "secret123", models.RoleClinician)
+
+	adminToken := srv.Login("admin@example.com", "secret123")
+	leaverSession := loginResponse(t, srv, "leaver@example.com", "secret123")
+	promotedSession := loginResponse(t, srv, "promoted@example.com", "secret123")
+	resetSession := loginResponse(t, srv, "reset@example.com", "secret123")
+	waitForNextSecond()
+
+	t.Run("deactivated user's token is rejected", func(t *testing.T) {
+		w := srv.Do(http.MethodPost, fmt.Sprintf("/api/v1/admin/users/%d/deactivate", leaver.ID), nil, adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = srv.Do(http.MethodGet, "/api/v1/auth/profile", nil, leaverSession.Token)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		code, _ := refresh(srv, leaverSession.RefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, code)
+
+		w = srv.Do(http.MethodPost, "/api/v1/auth/login", models.LoginRequest{Email: "leaver@example.com", Password: "secret123"}, "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = srv.Do(http.MethodPost, fmt.Sprintf("/api/v1/admin/users/%d/activate", leaver.ID), nil, adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		srv.Login("leaver@example.com", "secret123")
+	})
+
+	t.Run("role change revokes sessions", func(t *testing.T) {
+		w := srv.Do(http.MethodPut, fmt.Sprintf("/api/v1/admin/users/%d/role", promoted.ID),
+			models.ChangeRoleRequest{Role: models.RoleAdmin}, adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var summary models.UserSummary
+		srv.Decode(w, &summary)
+		assert.Equal(t, models.RoleAdmin, summary.Role)
+
+		w = srv.Do(http.MethodGet, "/api/v1/auth/profile", nil, promotedSession.Token)
+		assert.Equal(t, http.StatusUnauthorized, w.Code, "token carrying the old role")
+		code, _ := refresh(srv, promotedSession.RefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, code)
+
+		w = srv.Do(http.MethodGet, "/api/v1/admin/users", nil, srv.Login("promoted@example.com", "secret123"))
+		assert.Equal(t, http.StatusOK, w.Code, "new login has the new role")
+	})
+
+	t.Run("forced reset blocks password login", func(t *testing.T) {
+		w := srv.Do(http.MethodPost, fmt.Sprintf("/api/v1/admin/users/%d/force-password-reset", reset.ID), nil, adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = srv.Do(http.MethodGet, "/api/v1/auth/profile", nil, resetSession.Token)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = srv.Do(http.MethodPost, "/api/v1/auth/login", models.LoginRequest{Email: "reset@example.com", Password: "secret123"}, "")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = srv.Do(http.MethodPost, "/api/v1/auth/reset-password", models.ConfirmResetPasswordRequest{
+			Token: lastMailedToken(t, srv, "reset@example.com"), NewPassword: "newsecret456",
+		}, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		srv.Login("reset@example.com", "newsecret456")
+	})
+
+	t.Run("admins cannot change their own role", func(t *testing.T) {
+		w := srv.Do(http.MethodPut, fmt.Sprintf("/api/v1/admin/users/%d/role", admin.ID),
+			models.ChangeRoleRequest{Role: models.RoleClinician}, adminToken)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = srv.Do(http.MethodPost, fmt.Sprintf("/api/v1/admin/users/%d/deactivate", admin.ID), nil, adminToken)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
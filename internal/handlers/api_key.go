@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/middleware"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/service"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHandler handles API key administration requests
+type APIKeyHandler struct {
+	apiKeys *service.APIKeyService
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(apiKeys *service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{apiKeys: apiKeys}
+}
+
+// ListAPIKeys returns every API key without its secret
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	keys, err := h.apiKeys.ListAPIKeys()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to query API keys",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"api_keys":    keys,
+		"total_count": len(keys),
+	})
+}
+
+// CreateAPIKey issues a new API key. The key is returned only in this response.
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req models.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	for _, p := range req.Permissions {
+		if !middleware.APIKeyGrantable(middleware.Permission(p)) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid permission",
+				Message: fmt.Sprintf("Permission %q does not exist or cannot be granted to an API key", p),
+			})
+			return
+		}
+	}
+
+	adminID, _ := middleware.GetUserID(c)
+
+	response, err := h.apiKeys.CreateAPIKey(req, adminID)
+	switch {
+	case err == nil:
+		c.JSON(http.StatusCreated, response)
+	case errors.Is(err, utils.ErrBadRequest):
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid date format",
+			Message: "expires_at must be in ISO-8601 format (e.g., 2025-01-15T00:00:00Z)",
+		})
+	case errors.Is(err, utils.ErrInvalidDateRange):
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid expiry",
+			Message: "expires_at must be in the future",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to create API key",
+			Message: err.Error(),
+		})
+	}
+}
+
+// RevokeAPIKey disables an API key immediately
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	keyID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid API key ID",
+			Message: "API key ID must be a valid integer",
+		})
+		return
+	}
+
+	adminID, _ := middleware.GetUserID(c)
+
+	err = h.apiKeys.RevokeAPIKey(keyID, adminID)
+	if errors.Is(err, utils.ErrNotFound) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "API key not found",
+			Message: fmt.Sprintf("No active API key with ID %d", keyID),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to revoke API key",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: fmt.Sprintf("API key %d revoked", keyID),
+	})
+}
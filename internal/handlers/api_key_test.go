@@ -0,0 +1,71 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/middleware"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeys_CreateUseRevoke(t *testing.T) {
+	f := newClinicalFixture(t)
+	patient := f.createPatient(t, "Key Patient", "MRN-KEY")
+
+	withKey := func(method, path, key string) int {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set(middleware.APIKeyHeader, key)
+		w := httptest.NewRecorder()
+		f.Router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("only admins manage keys", func(t *testing.T) {
+		w := f.Do(http.MethodPost, "/api/v1/admin/api-keys", models.CreateAPIKeyRequest{
+			Name: "lab-feed", Permissions: []string{string(middleware.PermPatientsRead)},
+		}, f.clinicianToken)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("human-only permissions are refused", func(t *testing.T) {
+		for _, p := range []middleware.Permission{middleware.PermUsersManage, middleware.PermPatientsDelete, "no:such"} {
+			w := f.Do(http.MethodPost, "/api/v1/admin/api-keys", models.CreateAPIKeyRequest{
+				Name: "bad-grant", Permissions: []string{string(p)},
+			}, f.adminToken)
+			assert.Equal(t, http.StatusBadRequest, w.Code, p)
+		}
+	})
+
+	w := f.Do(http.MethodPost, "/api/v1/admin/api-keys", models.CreateAPIKeyRequest{
+		Name:        "lab-feed",
+		Permissions: []string{string(middleware.PermPatientsList), string(middleware.PermPatientsRead)},
+	}, f.adminToken)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created models.CreateAPIKeyResponse
+	f.Decode(w, &created)
+	require.NotEmpty(t, created.Key)
+
+	patientPath := fmt.Sprintf("/api/v1/patients/%d", patient.PatientID)
+	assert.Equal(t, http.StatusOK, withKey(http.MethodGet, patientPath, created.Key))
+	assert.Equal(t, http.StatusOK, withKey(http.MethodGet, "/api/v1/patients", created.Key))
+	assert.Equal(t, http.StatusForbidden, withKey(http.MethodGet, "/api/v1/assessments", created.Key), "permission not granted")
+	assert.Equal(t, http.StatusForbidden, withKey(http.MethodDelete, patientPath, created.Key))
+	assert.Equal(t, http.StatusUnauthorized, withKey(http.MethodGet, patientPath, created.Key+"x"))
+
+	w = f.Do(http.MethodGet, "/api/v1/admin/api-keys", nil, f.adminToken)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var list struct {
+		TotalCount int `json:"total_count"`
+	}
+	f.Decode(w, &list)
+	assert.Equal(t, 1, list.TotalCount)
+
+	w = f.Do(http.MethodDelete, fmt.Sprintf("/api/v1/admin/api-keys/%d", created.APIKey.ID), nil, f.adminToken)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, http.StatusUnauthorized, withKey(http.MethodGet, patientPath, created.Key), "revoked keys stop working")
+}
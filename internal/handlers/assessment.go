@@ -1,27 +1,29 @@
 package handlers
 
 import (
-	"database/sql"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
 	"strconv"
-	"time"
 
-	"github.com/vellalasantosh/wound_iq_api_claude/internal/db"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/middleware"
 	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/service"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
 // AssessmentHandler handles assessment-related requests
 type AssessmentHandler struct {
-	db *db.DB
+	assessments *service.AssessmentService
+	access      *service.AccessService
 }
 
 // NewAssessmentHandler creates a new assessment handler
-func NewAssessmentHandler(database *db.DB) *AssessmentHandler {
-	return &AssessmentHandler{db: database}
+func NewAssessmentHandler(assessments *service.AssessmentService, access *service.AccessService) *AssessmentHandler {
+	return &AssessmentHandler{assessments: assessments, access: access}
 }
 
 // GetAllAssessments retrieves all assessments with filters and pagination
@@ -35,77 +37,20 @@ func (h *AssessmentHandler) GetAllAssessments(c *gin.Context) {
 		return
 	}
 
-	// Build query with filters
-	query := `
-		SELECT a.assessment_id, a.date, p.patient_id, p.full_name, 
-		       c.clinician_id, c.full_name, a.location
-		FROM assessment a
-		JOIN patient p ON p.patient_id = a.patient_id
-		JOIN clinician c ON c.clinician_id = a.clinician_id
-		WHERE 1=1
-	`
-	countQuery := "SELECT COUNT(*) FROM assessment a WHERE 1=1"
-	args := []interface{}{}
-	argPos := 1
-
-	// Apply filters
-	if filter.PatientID != nil {
-		query += fmt.Sprintf(" AND a.patient_id = $%d", argPos)
-		countQuery += fmt.Sprintf(" AND patient_id = $%d", argPos)
-		args = append(args, *filter.PatientID)
-		argPos++
-	}
-	if filter.ClinicianID != nil {
-		query += fmt.Sprintf(" AND a.clinician_id = $%d", argPos)
-		countQuery += fmt.Sprintf(" AND clinician_id = $%d", argPos)
-		args = append(args, *filter.ClinicianID)
-		argPos++
-	}
-	if filter.StartDate != "" {
-		startDate, err := time.Parse(time.RFC3339, filter.StartDate)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, models.ErrorResponse{
-				Error:   "Invalid start_date format",
-				Message: "Date must be in ISO-8601 format",
-			})
-			return
-		}
-		query += fmt.Sprintf(" AND a.date >= $%d", argPos)
-		countQuery += fmt.Sprintf(" AND date >= $%d", argPos)
-		args = append(args, startDate)
-		argPos++
-	}
-	if filter.EndDate != "" {
-		endDate, err := time.Parse(time.RFC3339, filter.EndDate)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, models.ErrorResponse{
-				Error:   "Invalid end_date format",
-				Message: "Date must be in ISO-8601 format",
-			})
-			return
-		}
-		query += fmt.Sprintf(" AND a.date <= $%d", argPos)
-		countQuery += fmt.Sprintf(" AND date <= $%d", argPos)
-		args = append(args, endDate)
-		argPos++
+	// Clinicians only see assessments for patients on their care team
+	careTeamUserID := 0
+	if caller := currentCaller(c); h.access.ScopeToCareTeam(caller) {
+		careTeamUserID = caller.UserID
 	}
 
-	// Get total count
-	var totalCount int
-	err := h.db.QueryRow(countQuery, args...).Scan(&totalCount)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "Failed to count assessments",
-			Message: err.Error(),
+	assessments, totalCount, err := h.assessments.ListAssessments(&filter, careTeamUserID)
+	if errors.Is(err, utils.ErrBadRequest) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid date format",
+			Message: "start_date and end_date must be in ISO-8601 format",
 		})
 		return
 	}
-
-	// Add ordering and pagination
-	query += fmt.Sprintf(" ORDER BY a.date DESC LIMIT $%d OFFSET $%d", argPos, argPos+1)
-	args = append(args, filter.GetLimit(), filter.GetOffset())
-
-	rows, err := h.db.Query(query, args...)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "Failed to query assessments",
@@ -113,29 +58,13 @@ func (h *AssessmentHandler) GetAllAssessments(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-
-	type AssessmentListItem struct {
-		AssessmentID  int       `json:"assessment_id"`
-		Date          time.Time `json:"date"`
-		PatientID     int       `json:"patient_id"`
-		PatientName   string    `json:"patient_name"`
-		ClinicianID   int       `json:"clinician_id"`
-		ClinicianName string    `json:"clinician_name"`
-		Location      string    `json:"location"`
-	}
-
-	var assessments []AssessmentListItem
-	for rows.Next() {
-		var a AssessmentListItem
-		if err := rows.Scan(&a.AssessmentID, &a.Date, &a.PatientID, &a.PatientName, &a.ClinicianID, &a.ClinicianName, &a.Location); err != nil {
-			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-				Error:   "Failed to scan assessment",
-				Message: err.Error(),
-			})
-			return
-		}
-		assessments = append(assessments, a)
+
+	patientIDs := make([]int, len(assessments))
+	for i, a := range assessments {
+		patientIDs[i] = a.PatientID
+	}
+	if !auditListAccess(c, h.access, patientIDs) {
+		return
 	}
 
 	totalPages := int(math.Ceil(float64(totalCount) / float64(filter.GetLimit())))
@@ -160,24 +89,11 @@ func (h *AssessmentHandler) GetAssessmentByID(c *gin.Context) {
 		return
 	}
 
-	var assessment models.Assessment
-	err = h.db.QueryRow(`
-		SELECT assessment_id, clinician_id, patient_id, date, location, etiology, 
-		       depth_of_injury, stage, chronicity, healing_status, return_to_clinic
-		FROM assessment
-		WHERE assessment_id = $1
-	`, id).Scan(&assessment.AssessmentID, &assessment.ClinicianID, &assessment.PatientID,
-		&assessment.Date, &assessment.Location, &assessment.Etiology, &assessment.DepthOfInjury,
-		&assessment.Stage, &assessment.Chronicity, &assessment.HealingStatus, &assessment.ReturnToClinic)
-
-	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "Assessment not found",
-			Message: fmt.Sprintf("Assessment with ID %d does not exist", id),
-		})
+	assessment, err := h.assessments.GetAssessment(id)
+	if errors.Is(err, utils.ErrNotFound) {
+		assessmentNotFound(c, id)
 		return
 	}
-
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "Failed to query assessment",
@@ -186,6 +102,10 @@ func (h *AssessmentHandler) GetAssessmentByID(c *gin.Context) {
 		return
 	}
 
+	if !authorizePatientAccess(c, h.access, assessment.PatientID) {
+		return
+	}
+
 	c.JSON(http.StatusOK, assessment)
 }
 
@@ -200,38 +120,14 @@ func (h *AssessmentHandler) CreateAssessment(c *gin.Context) {
 		return
 	}
 
-	// Verify patient exists
-	var patientExists bool
-	err := h.db.QueryRow("SELECT EXISTS(SELECT 1 FROM patient WHERE patient_id = $1)", req.PatientID).Scan(&patientExists)
-	if err != nil || !patientExists {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "Invalid patient",
-			Message: fmt.Sprintf("Patient with ID %d does not exist", req.PatientID),
-		})
+	if !h.chartAsCaller(c, &req) || !authorizePatientAccess(c, h.access, req.PatientID) {
 		return
 	}
 
-	// Verify clinician exists
-	var clinicianExists bool
-	err = h.db.QueryRow("SELECT EXISTS(SELECT 1 FROM clinician WHERE clinician_id = $1)", req.ClinicianID).Scan(&clinicianExists)
-	if err != nil || !clinicianExists {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "Invalid clinician",
-			Message: fmt.Sprintf("Clinician with ID %d does not exist", req.ClinicianID),
-		})
+	assessment, err := h.assessments.CreateAssessment(req)
+	if respondInvalidReference(c, err, req) {
 		return
 	}
-
-	// Insert assessment
-	var newID int
-	err = h.db.QueryRow(`
-		INSERT INTO assessment (clinician_id, patient_id, date, location, etiology, 
-		                       depth_of_injury, stage, chronicity, healing_status, return_to_clinic)
-		VALUES ($1, $2, NOW(), $3, $4, $5, $6, $7, $8, $9)
-		RETURNING assessment_id
-	`, req.ClinicianID, req.PatientID, req.Location, req.Etiology, req.DepthOfInjury,
-		req.Stage, req.Chronicity, req.HealingStatus, req.ReturnToClinic).Scan(&newID)
-
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "Failed to create assessment",
@@ -240,25 +136,6 @@ func (h *AssessmentHandler) CreateAssessment(c *gin.Context) {
 		return
 	}
 
-	// Retrieve the created assessment
-	var assessment models.Assessment
-	err = h.db.QueryRow(`
-		SELECT assessment_id, clinician_id, patient_id, date, location, etiology, 
-		       depth_of_injury, stage, chronicity, healing_status, return_to_clinic
-		FROM assessment
-		WHERE assessment_id = $1
-	`, newID).Scan(&assessment.AssessmentID, &assessment.ClinicianID, &assessment.PatientID,
-		&assessment.Date, &assessment.Location, &assessment.Etiology, &assessment.DepthOfInjury,
-		&assessment.Stage, &assessment.Chronicity, &assessment.HealingStatus, &assessment.ReturnToClinic)
-
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "Failed to retrieve created assessment",
-			Message: err.Error(),
-		})
-		return
-	}
-
 	c.JSON(http.StatusCreated, assessment)
 }
 
@@ -273,46 +150,14 @@ func (h *AssessmentHandler) CreateFullAssessment(c *gin.Context) {
 		return
 	}
 
-	// Call add_full_assessment PostgreSQL function
-	var newID int
-	err := h.db.QueryRow(`
-		SELECT add_full_assessment(
-			$1, $2, $3, $4, $5, $6, $7, $8, $9,
-			$10, $11, $12, $13, $14, $15,
-			$16, $17, $18, $19, $20, $21,
-			$22, $23, $24, $25, $26,
-			$27, $28, $29, $30, $31, $32, $33, $34, $35,
-			$36, $37, $38,
-			$39, $40, $41, $42, $43, $44
-		)
-	`,
-		// Assessment fields
-		req.ClinicianID, req.PatientID, req.Location, req.Etiology, req.DepthOfInjury,
-		req.Stage, req.Chronicity, req.HealingStatus, req.ReturnToClinic,
-		// Infection and pain
-		req.InfectionPain.LocalizedSymptoms, req.InfectionPain.SystemicSymptoms,
-		req.InfectionPain.PainPresent, req.InfectionPain.PainScore,
-		req.InfectionPain.CultureResults, req.InfectionPain.Antibiotic,
-		// Tissue status
-		req.TissueStatus.GranulationPercent, req.TissueStatus.EpithelialPercent,
-		req.TissueStatus.SloughPercent, req.TissueStatus.EscharPercent,
-		req.TissueStatus.NecroticPercent, req.TissueStatus.Debridement,
-		// Vitals
-		req.Vitals.BloodPressure, req.Vitals.Temperature, req.Vitals.Pulse,
-		req.Vitals.RespirationRate, req.Vitals.OxygenSaturation,
-		// Wound condition
-		req.WoundCondition.Length, req.WoundCondition.Width, req.WoundCondition.Depth,
-		req.WoundCondition.Tunneling, req.WoundCondition.Undermining,
-		req.WoundCondition.Edges, req.WoundCondition.SkinCondition,
-		req.WoundCondition.Edema, req.WoundCondition.Blister,
-		// Exudate
-		req.Exudate.ExudateType, req.Exudate.ExudateAmount, req.Exudate.Odor,
-		// Treatment
-		req.Treatment.PrimaryDressing, req.Treatment.SecondaryDressing,
-		req.Treatment.TertiaryDressing, req.Treatment.Frequency,
-		req.Treatment.Supplies, req.Treatment.Orders,
-	).Scan(&newID)
+	if !h.chartAsCaller(c, &req.CreateAssessmentRequest) || !authorizePatientAccess(c, h.access, req.PatientID) {
+		return
+	}
 
+	newID, err := h.assessments.CreateFullAssessment(req)
+	if respondInvalidReference(c, err, req.CreateAssessmentRequest) {
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "Failed to create full assessment",
@@ -349,96 +194,111 @@ func (h *AssessmentHandler) UpdateAssessment(c *gin.Context) {
 		return
 	}
 
-	// Check if assessment exists
-	var exists bool
-	err = h.db.QueryRow("SELECT EXISTS(SELECT 1 FROM assessment WHERE assessment_id = $1)", id).Scan(&exists)
-	if err != nil || !exists {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "Assessment not found",
-			Message: fmt.Sprintf("Assessment with ID %d does not exist", id),
+	existing, err := h.assessments.GetAssessment(id)
+	if errors.Is(err, utils.ErrNotFound) {
+		assessmentNotFound(c, id)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to query assessment",
+			Message: err.Error(),
 		})
 		return
 	}
 
-	// Build dynamic update query
-	query := "UPDATE assessment SET "
-	args := []interface{}{}
-	argPos := 1
-
-	if req.Location != "" {
-		query += fmt.Sprintf("location = $%d, ", argPos)
-		args = append(args, req.Location)
-		argPos++
-	}
-	if req.Etiology != "" {
-		query += fmt.Sprintf("etiology = $%d, ", argPos)
-		args = append(args, req.Etiology)
-		argPos++
-	}
-	if req.DepthOfInjury != "" {
-		query += fmt.Sprintf("depth_of_injury = $%d, ", argPos)
-		args = append(args, req.DepthOfInjury)
-		argPos++
-	}
-	if req.Stage != "" {
-		query += fmt.Sprintf("stage = $%d, ", argPos)
-		args = append(args, req.Stage)
-		argPos++
+	if !authorizePatientAccess(c, h.access, existing.PatientID) {
+		return
 	}
-	if req.Chronicity != "" {
-		query += fmt.Sprintf("chronicity = $%d, ", argPos)
-		args = append(args, req.Chronicity)
-		argPos++
+
+	assessment, err := h.assessments.UpdateAssessment(id, req)
+	if errors.Is(err, utils.ErrNotFound) {
+		assessmentNotFound(c, id)
+		return
 	}
-	if req.HealingStatus != "" {
-		query += fmt.Sprintf("healing_status = $%d, ", argPos)
-		args = append(args, req.HealingStatus)
-		argPos++
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to update assessment",
+			Message: err.Error(),
+		})
+		return
 	}
-	if req.ReturnToClinic != nil {
-		query += fmt.Sprintf("return_to_clinic = $%d, ", argPos)
-		args = append(args, *req.ReturnToClinic)
-		argPos++
+
+	c.JSON(http.StatusOK, assessment)
+}
+
+// DeleteAssessment soft-deletes an assessment; its sections are kept with it
+// and admins can list and restore it. The mode parameter has no effect.
+func (h *AssessmentHandler) DeleteAssessment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid assessment ID",
+			Message: "Assessment ID must be a valid integer",
+		})
+		return
 	}
 
-	// Remove trailing comma and space
-	query = query[:len(query)-2]
-	query += fmt.Sprintf(" WHERE assessment_id = $%d", argPos)
-	args = append(args, id)
+	var query models.DeleteQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Message: err.Error(),
+		})
+		return
+	}
 
-	_, err = h.db.Exec(query, args...)
+	userID, _ := middleware.GetUserID(c)
+	result, err := h.assessments.DeleteAssessment(id, userID, query.Reason)
+	if errors.Is(err, utils.ErrNotFound) {
+		assessmentNotFound(c, id)
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "Failed to update assessment",
+			Error:   "Failed to delete assessment",
 			Message: err.Error(),
 		})
 		return
 	}
 
-	// Retrieve updated assessment
-	var assessment models.Assessment
-	err = h.db.QueryRow(`
-		SELECT assessment_id, clinician_id, patient_id, date, location, etiology, 
-		       depth_of_injury, stage, chronicity, healing_status, return_to_clinic
-		FROM assessment
-		WHERE assessment_id = $1
-	`, id).Scan(&assessment.AssessmentID, &assessment.ClinicianID, &assessment.PatientID,
-		&assessment.Date, &assessment.Location, &assessment.Etiology, &assessment.DepthOfInjury,
-		&assessment.Stage, &assessment.Chronicity, &assessment.HealingStatus, &assessment.ReturnToClinic)
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: fmt.Sprintf("Assessment with ID %d deleted successfully", id),
+		Data:    result,
+	})
+}
 
+// ListDeletedAssessments lists soft-deleted assessments, most recently deleted first
+func (h *AssessmentHandler) ListDeletedAssessments(c *gin.Context) {
+	var params models.PaginationParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid pagination parameters",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	items, totalCount, err := h.assessments.ListDeletedAssessments(&params)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "Failed to retrieve updated assessment",
+			Error:   "Failed to query deleted assessments",
 			Message: err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, assessment)
+	c.JSON(http.StatusOK, models.PaginatedResponse{
+		Data:       items,
+		Page:       params.Page,
+		PageSize:   params.GetLimit(),
+		TotalCount: totalCount,
+		TotalPages: int(math.Ceil(float64(totalCount) / float64(params.GetLimit()))),
+	})
 }
 
-// DeleteAssessment deletes an assessment and all related data
-func (h *AssessmentHandler) DeleteAssessment(c *gin.Context) {
+// RestoreAssessment undeletes an assessment whose patient is not deleted
+func (h *AssessmentHandler) RestoreAssessment(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -448,36 +308,90 @@ func (h *AssessmentHandler) DeleteAssessment(c *gin.Context) {
 		return
 	}
 
-	// Check if assessment exists
-	var exists bool
-	err = h.db.QueryRow("SELECT EXISTS(SELECT 1 FROM assessment WHERE assessment_id = $1)", id).Scan(&exists)
-	if err != nil || !exists {
+	result, err := h.assessments.RestoreAssessment(id)
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, models.SuccessResponse{
+			Message: fmt.Sprintf("Assessment with ID %d restored successfully", id),
+			Data:    result,
+		})
+	case errors.Is(err, utils.ErrNotFound):
 		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "Assessment not found",
-			Message: fmt.Sprintf("Assessment with ID %d does not exist", id),
+			Error:   "Deleted assessment not found",
+			Message: fmt.Sprintf("Assessment with ID %d is not deleted", id),
+		})
+	case errors.Is(err, utils.ErrPatientDeleted):
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "Patient is deleted",
+			Message: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to restore assessment",
+			Message: err.Error(),
 		})
-		return
 	}
+}
 
-	// Delete related data (cascade should handle this, but being explicit)
-	_, _ = h.db.Exec("DELETE FROM treatment WHERE assessment_id = $1", id)
-	_, _ = h.db.Exec("DELETE FROM exudate WHERE assessment_id = $1", id)
-	_, _ = h.db.Exec("DELETE FROM wound_condition WHERE assessment_id = $1", id)
-	_, _ = h.db.Exec("DELETE FROM vitals WHERE assessment_id = $1", id)
-	_, _ = h.db.Exec("DELETE FROM tissue_status WHERE assessment_id = $1", id)
-	_, _ = h.db.Exec("DELETE FROM infection_and_pain WHERE assessment_id = $1", id)
+// chartAsCaller sets the charting clinician. Clinicians always chart as
+// themselves, whatever the body says; other callers (API keys) must name the
+// clinician. Writes the error response and returns false if that fails.
+func (h *AssessmentHandler) chartAsCaller(c *gin.Context, req *models.CreateAssessmentRequest) bool {
+	caller := currentCaller(c)
+	if caller.Role != models.RoleClinician {
+		if req.ClinicianID == 0 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid request body",
+				Message: "clinician_id is required",
+			})
+			return false
+		}
+		return true
+	}
 
-	// Delete assessment
-	_, err = h.db.Exec("DELETE FROM assessment WHERE assessment_id = $1", id)
+	clinicianID, err := h.access.GetOwnClinicianID(caller.UserID)
+	if errors.Is(err, utils.ErrNotClinician) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "Forbidden",
+			Message: "Your account has no clinician profile",
+		})
+		return false
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "Failed to delete assessment",
+			Error:   "Failed to resolve clinician",
 			Message: err.Error(),
 		})
-		return
+		return false
 	}
 
-	c.JSON(http.StatusOK, models.SuccessResponse{
-		Message: fmt.Sprintf("Assessment with ID %d deleted successfully", id),
+	req.ClinicianID = clinicianID
+	return true
+}
+
+func assessmentNotFound(c *gin.Context, id int) {
+	c.JSON(http.StatusNotFound, models.ErrorResponse{
+		Error:   "Assessment not found",
+		Message: fmt.Sprintf("Assessment with ID %d does not exist", id),
 	})
 }
+
+// respondInvalidReference writes a 400 response and returns true when err
+// reports that the assessment names a patient or clinician that does not exist
+func respondInvalidReference(c *gin.Context, err error, req models.CreateAssessmentRequest) bool {
+	switch {
+	case errors.Is(err, utils.ErrInvalidPatient):
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid patient",
+			Message: fmt.Sprintf("Patient with ID %d does not exist", req.PatientID),
+		})
+		return true
+	case errors.Is(err, utils.ErrInvalidClinician):
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid clinician",
+			Message: fmt.Sprintf("Clinician with ID %d does not exist", req.ClinicianID),
+		})
+		return true
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// sectionBinder reads one assessment section from a request body
+type sectionBinder interface {
+	// bind validates the body and returns it as the section to save. With
+	// patch set, fields missing from the body keep their recorded values.
+	bind(c *gin.Context, recorded models.AssessmentSections, patch bool) (models.AssessmentSections, error)
+}
+
+// assessmentSection is the sectionBinder for one section. Req is the
+// section's *Request model, so edits are validated exactly as on create.
+type assessmentSection[Req any] struct {
+	current func(models.AssessmentSections) any
+	save    func(Req) models.AssessmentSections
+}
+
+func (s assessmentSection[Req]) bind(c *gin.Context, recorded models.AssessmentSections, patch bool) (models.AssessmentSections, error) {
+	var req Req
+	if patch {
+		// Start from the recorded section; one never recorded marshals to
+		// null and leaves req empty, so the body must then be complete
+		b, err := json.Marshal(s.current(recorded))
+		if err != nil {
+			return models.AssessmentSections{}, err
+		}
+		if err := json.Unmarshal(b, &req); err != nil {
+			return models.AssessmentSections{}, err
+		}
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return models.AssessmentSections{}, err
+	}
+	return s.save(req), nil
+}
+
+// assessmentSections maps the path segment under /assessments/:id to its section
+var assessmentSections = map[string]sectionBinder{
+	"infection-pain": assessmentSection[models.InfectionPainRequest]{
+		current: func(s models.AssessmentSections) any { return s.InfectionPain },
+		save: func(req models.InfectionPainRequest) models.AssessmentSections {
+			v := models.InfectionPain(req)
+			return models.AssessmentSections{InfectionPain: &v}
+		},
+	},
+	"tissue-status": assessmentSection[models.TissueStatusRequest]{
+		current: func(s models.AssessmentSections) any { return s.TissueStatus },
+		save: func(req models.TissueStatusRequest) models.AssessmentSections {
+			v := models.TissueStatus(req)
+			return models.AssessmentSections{TissueStatus: &v}
+		},
+	},
+	"vitals": assessmentSection[models.VitalsRequest]{
+		current: func(s models.AssessmentSections) any { return s.Vitals },
+		save: func(req models.VitalsRequest) models.AssessmentSections {
+			v := models.Vitals(req)
+			return models.AssessmentSections{Vitals: &v}
+		},
+	},
+	"wound-condition": assessmentSection[models.WoundConditionRequest]{
+		current: func(s models.AssessmentSections) any { return s.WoundCondition },
+		save: func(req models.WoundConditionRequest) models.AssessmentSections {
+			v := models.WoundCondition(req)
+			return models.AssessmentSections{WoundCondition: &v}
+		},
+	},
+	"exudate": assessmentSection[models.ExudateRequest]{
+		current: func(s models.AssessmentSections) any { return s.Exudate },
+		save: func(req models.ExudateRequest) models.AssessmentSections {
+			v := models.Exudate(req)
+			return models.AssessmentSections{Exudate: &v}
+		},
+	},
+	"treatment": assessmentSection[models.TreatmentRequest]{
+		current: func(s models.AssessmentSections) any { return s.Treatment },
+		save: func(req models.TreatmentRequest) models.AssessmentSections {
+			v := models.Treatment(req)
+			return models.AssessmentSections{Treatment: &v}
+		},
+	},
+}
+
+// ReplaceSection returns the PUT handler for the named section. The body must
+// be the complete section; it replaces the recorded one or records it.
+func (h *AssessmentHandler) ReplaceSection(section string) gin.HandlerFunc {
+	return h.saveSection(section, false)
+}
+
+// PatchSection returns the PATCH handler for the named section, which changes
+// only the fields present in the body
+func (h *AssessmentHandler) PatchSection(section string) gin.HandlerFunc {
+	return h.saveSection(section, true)
+}
+
+func (h *AssessmentHandler) saveSection(section string, patch bool) gin.HandlerFunc {
+	binder, ok := assessmentSections[section]
+	if !ok {
+		panic("handlers: unknown assessment section " + section)
+	}
+
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid assessment ID",
+				Message: "Assessment ID must be a valid integer",
+			})
+			return
+		}
+
+		existing, err := h.assessments.GetFullAssessment(id)
+		if errors.Is(err, utils.ErrNotFound) {
+			assessmentNotFound(c, id)
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error:   "Failed to query assessment",
+				Message: err.Error(),
+			})
+			return
+		}
+
+		if !authorizePatientAccess(c, h.access, existing.PatientID) {
+			return
+		}
+
+		sections, err := binder.bind(c, existing.AssessmentSections, patch)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid request body",
+				Message: err.Error(),
+			})
+			return
+		}
+
+		full, err := h.assessments.SaveSections(id, sections)
+		if errors.Is(err, utils.ErrNotFound) {
+			assessmentNotFound(c, id)
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error:   "Failed to save " + section,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, full)
+	}
+}
+
+// ReplaceFullAssessment overwrites an assessment's header and every section
+// in one transaction
+func (h *AssessmentHandler) ReplaceFullAssessment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid assessment ID",
+			Message: "Assessment ID must be a valid integer",
+		})
+		return
+	}
+
+	var req models.FullAssessmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	existing, err := h.assessments.GetAssessment(id)
+	if errors.Is(err, utils.ErrNotFound) {
+		assessmentNotFound(c, id)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to query assessment",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if !h.chartAsCaller(c, &req.CreateAssessmentRequest) || !authorizePatientAccess(c, h.access, existing.PatientID) {
+		return
+	}
+
+	full, err := h.assessments.ReplaceFullAssessment(id, req)
+	if respondInvalidReference(c, err, req.CreateAssessmentRequest) {
+		return
+	}
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, full)
+	case errors.Is(err, utils.ErrNotFound):
+		assessmentNotFound(c, id)
+	case errors.Is(err, utils.ErrPatientMismatch):
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid patient",
+			Message: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to replace assessment",
+			Message: err.Error(),
+		})
+	}
+}
@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/vellalasantosh/wound_iq_api_claude/internal/middleware"
 	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
 	"github.com/vellalasantosh/wound_iq_api_claude/internal/service"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
 
 	"github.com/gin-gonic/gin"
 )
@@ -35,9 +38,9 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.Register(req)
+	response, err := h.authService.Register(req, clientInfo(c))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, passwordErrorBody(err))
 		return
 	}
 
@@ -46,13 +49,16 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 // Login godoc
 // @Summary User login
-// @Description Authenticate user and return tokens
+// @Description Authenticate user and return tokens. Users with MFA enabled receive an MFA challenge to complete at /auth/login/mfa.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body models.LoginRequest true "Login credentials"
 // @Success 200 {object} models.LoginResponse
+// @Success 202 {object} models.MFAChallengeResponse
 // @Failure 401 {object} map[string]string
+// @Failure 423 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req models.LoginRequest
@@ -61,15 +67,161 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.Login(req)
+	response, challenge, err := h.authService.Login(req, clientInfo(c))
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		c.JSON(loginErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if challenge != nil {
+		c.JSON(http.StatusAccepted, challenge)
 		return
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// LoginMFA godoc
+// @Summary Complete MFA login
+// @Description Exchange an MFA challenge token and a TOTP or recovery code for tokens
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.MFALoginRequest true "Challenge token and code"
+// @Success 200 {object} models.LoginResponse
+// @Failure 401 {object} map[string]string
+// @Failure 423 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /auth/login/mfa [post]
+func (h *AuthHandler) LoginMFA(c *gin.Context) {
+	var req models.MFALoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.authService.LoginMFA(req, clientInfo(c))
+	if err != nil {
+		c.JSON(loginErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ssoStateCookie carries the OIDC login state between /sso/login and /sso/callback
+const (
+	ssoStateCookie     = "woundiq_sso_state"
+	ssoStateCookiePath = "/api/v1/auth/sso"
+)
+
+// SSOLogin godoc
+// @Summary Start single sign-on
+// @Description Redirect to the hospital identity provider (OIDC authorization-code flow)
+// @Tags auth
+// @Success 302
+// @Failure 404 {object} map[string]string
+// @Router /auth/sso/login [get]
+func (h *AuthHandler) SSOLogin(c *gin.Context) {
+	authURL, stateToken, err := h.authService.StartSSO(c.Request.Context())
+	if errors.Is(err, utils.ErrSSONotConfigured) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Identity provider unavailable"})
+		return
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(ssoStateCookie, stateToken, int(utils.SSOStateExpiry.Seconds()), ssoStateCookiePath, "", isSecureRequest(c), true)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// SSOCallback godoc
+// @Summary Complete single sign-on
+// @Description Identity provider redirect target. Exchanges the authorization code and returns the normal login response, or an MFA challenge (202) for users with MFA enabled.
+// @Tags auth
+// @Produce json
+// @Param code query string true "Authorization code"
+// @Param state query string true "State"
+// @Success 200 {object} models.LoginResponse
+// @Success 202 {object} models.MFAChallengeResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 423 {object} map[string]string
+// @Router /auth/sso/callback [get]
+func (h *AuthHandler) SSOCallback(c *gin.Context) {
+	// The state cookie is single-use
+	stateToken, _ := c.Cookie(ssoStateCookie)
+	c.SetCookie(ssoStateCookie, "", -1, ssoStateCookiePath, "", isSecureRequest(c), true)
+
+	if idpErr := c.Query("error"); idpErr != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": utils.ErrSSOFailed.Error(), "detail": idpErr})
+		return
+	}
+
+	code, state := c.Query("code"), c.Query("state")
+	if code == "" || state == "" || stateToken == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing code, state or SSO session cookie"})
+		return
+	}
+
+	response, challenge, err := h.authService.CompleteSSO(c.Request.Context(), code, state, stateToken, clientInfo(c))
+	switch {
+	case err == nil && challenge != nil:
+		c.JSON(http.StatusAccepted, challenge)
+	case err == nil:
+		c.JSON(http.StatusOK, response)
+	case errors.Is(err, utils.ErrAccountLocked):
+		c.JSON(http.StatusLocked, gin.H{"error": err.Error()})
+	case errors.Is(err, utils.ErrSSONotConfigured):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, utils.ErrSSOAccountNotFound):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, utils.ErrInvalidToken), errors.Is(err, utils.ErrSSOFailed), errors.Is(err, utils.ErrUserInactive):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to complete single sign-on"})
+	}
+}
+
+// isSecureRequest reports whether the client connected over HTTPS, directly
+// or through a proxy that sets X-Forwarded-Proto
+func isSecureRequest(c *gin.Context) bool {
+	return c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+}
+
+// passwordErrorBody builds an error response, listing each policy rule the
+// password breaks when err is a *utils.PasswordPolicyError
+func passwordErrorBody(err error) gin.H {
+	var policyErr *utils.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		return gin.H{"error": utils.ErrPasswordPolicy.Error(), "violations": policyErr.Violations}
+	}
+	return gin.H{"error": err.Error()}
+}
+
+// clientInfo captures the client details recorded with a new session
+func clientInfo(c *gin.Context) models.ClientInfo {
+	return models.ClientInfo{IP: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+}
+
+// loginErrorStatus maps a login failure to its HTTP status
+func loginErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, utils.ErrAccountLocked):
+		return http.StatusLocked
+	case errors.Is(err, utils.ErrTooManyAttempts):
+		return http.StatusTooManyRequests
+	case errors.Is(err, utils.ErrEmailNotVerified), errors.Is(err, utils.ErrPasswordResetRequired),
+		errors.Is(err, utils.ErrPasswordExpired):
+		return http.StatusForbidden
+	default:
+		return http.StatusUnauthorized
+	}
+}
+
 // RefreshToken godoc
 // @Summary Refresh access token
 // @Description Get new access token using refresh token
@@ -87,7 +239,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.RefreshToken(req.RefreshToken)
+	response, err := h.authService.RefreshToken(req.RefreshToken, clientInfo(c))
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
@@ -98,7 +250,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 // Logout godoc
 // @Summary User logout
-// @Description Revoke all user tokens
+// @Description Revoke all refresh tokens and the current access token
 // @Tags auth
 // @Produce json
 // @Security BearerAuth
@@ -112,7 +264,9 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 		return
 	}
 
-	if err := h.authService.Logout(userID); err != nil {
+	tokenID, tokenExpiresAt, _ := middleware.GetTokenID(c)
+
+	if err := h.authService.Logout(userID, tokenID, tokenExpiresAt); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to logout"})
 		return
 	}
@@ -120,6 +274,67 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
 }
 
+// ListSessions godoc
+// @Summary List active sessions
+// @Description List the current user's active refresh-token sessions with the client they were created from
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]string
+// @Router /auth/sessions [get]
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	sessions, err := h.authService.ListSessions(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list sessions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions})
+}
+
+// RevokeSession godoc
+// @Summary Revoke a session
+// @Description Revoke one refresh-token session without logging out everywhere
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Session ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	sessionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Session ID must be a valid integer"})
+		return
+	}
+
+	err = h.authService.RevokeSession(userID, sessionID)
+	if errors.Is(err, utils.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke session"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked"})
+}
+
 // GetProfile godoc
 // @Summary Get user profile
 // @Description Get current user profile information
@@ -145,9 +360,47 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 	c.JSON(http.StatusOK, profile)
 }
 
+// UpdateProfile godoc
+// @Summary Update user profile
+// @Description Update name and/or email. Changing the email requires it to be verified again.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.UpdateProfileRequest true "Profile fields to change"
+// @Success 200 {object} models.UserWithProfile
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /auth/profile [put]
+func (h *AuthHandler) UpdateProfile(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req models.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile, err := h.authService.UpdateProfile(userID, req)
+	if errors.Is(err, utils.ErrEmailExists) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
 // ChangePassword godoc
 // @Summary Change password
-// @Description Change user password
+// @Description Change user password. Every session, including the current one, is signed out.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -170,9 +423,221 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 	}
 
 	if err := h.authService.ChangePassword(userID, req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, passwordErrorBody(err))
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Password changed successfully"})
 }
+
+// VerifyEmail godoc
+// @Summary Verify email address
+// @Description Confirm an email address with the token sent by email. Accepts the token as a query parameter (email link) or JSON body.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token query string false "Verification token"
+// @Param request body models.VerifyEmailRequest false "Verification token"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Router /auth/verify-email [get]
+// @Router /auth/verify-email [post]
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req models.VerifyEmailRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authService.VerifyEmail(req.Token); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email verified successfully"})
+}
+
+// ResendVerification godoc
+// @Summary Resend verification email
+// @Description Send a new email verification link. Always succeeds for unknown and already verified addresses.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.ResendVerificationRequest true "Account email"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /auth/resend-verification [post]
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	var req models.ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authService.ResendVerification(req.Email); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send verification email"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "If the account exists, a verification email has been sent"})
+}
+
+// ForgotPassword godoc
+// @Summary Request password reset
+// @Description Email a single-use password reset link. Always succeeds for unknown addresses.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.ResetPasswordRequest true "Account email"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Router /auth/forgot-password [post]
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req models.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authService.ForgotPassword(req); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send password reset email"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "If the account exists, a password reset email has been sent"})
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Set a new password using the emailed reset token. All sessions are revoked.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.ConfirmResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/reset-password [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req models.ConfirmResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.authService.ResetPassword(req)
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
+	case errors.Is(err, utils.ErrInvalidToken), errors.Is(err, utils.ErrPasswordPolicy):
+		c.JSON(http.StatusBadRequest, passwordErrorBody(err))
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset password"})
+	}
+}
+
+// EnrollMFA godoc
+// @Summary Start MFA enrollment
+// @Description Generate a TOTP secret and otpauth URI for an authenticator app. MFA is enforced once confirmed.
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.MFAEnrollResponse
+// @Failure 409 {object} map[string]string
+// @Router /auth/mfa/enroll [post]
+func (h *AuthHandler) EnrollMFA(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	response, err := h.authService.EnrollMFA(userID)
+	if errors.Is(err, utils.ErrMFAAlreadyEnabled) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start MFA enrollment"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ConfirmMFA godoc
+// @Summary Confirm MFA enrollment
+// @Description Enable MFA with a code from the authenticator app. Returns one-time recovery codes and a new access token.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.MFAConfirmRequest true "TOTP code"
+// @Success 200 {object} models.MFAConfirmResponse
+// @Failure 400 {object} map[string]string
+// @Router /auth/mfa/confirm [post]
+func (h *AuthHandler) ConfirmMFA(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req models.MFAConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.authService.ConfirmMFA(userID, req)
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, response)
+	case errors.Is(err, utils.ErrMFAAlreadyEnabled):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, utils.ErrInvalidMFACode), errors.Is(err, utils.ErrMFANotEnrolled):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to confirm MFA enrollment"})
+	}
+}
+
+// DisableMFA godoc
+// @Summary Disable MFA
+// @Description Remove MFA after re-checking the password and a TOTP or recovery code. Not allowed when the role requires MFA.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.MFADisableRequest true "Password and code"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /auth/mfa/disable [post]
+func (h *AuthHandler) DisableMFA(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req models.MFADisableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.authService.DisableMFA(userID, req)
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, gin.H{"message": "Multi-factor authentication disabled"})
+	case errors.Is(err, utils.ErrMFARequiredByPolicy):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, utils.ErrInvalidCredentials), errors.Is(err, utils.ErrInvalidMFACode),
+		errors.Is(err, utils.ErrMFANotEnrolled):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to disable MFA"})
+	}
+}
@@ -0,0 +1,491 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/service"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/testserver"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuth_RegisterLoginProfile(t *testing.T) {
+	srv := testserver.New(t)
+
+	w := srv.Do(http.MethodPost, "/api/v1/auth/register", models.RegisterRequest{
+		Email:     "nurse@example.com",
+		Password:  "secret123",
+		FirstName: "Nora",
+		LastName:  "Nurse",
+	}, "")
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var registered models.LoginResponse
+	srv.Decode(w, &registered)
+	assert.NotEmpty(t, registered.Token)
+	assert.Equal(t, "Nora", registered.User.FirstName)
+	assert.Len(t, srv.Mail.Messages(), 1, "verification email")
+
+	t.Run("duplicate email", func(t *testing.T) {
+		w := srv.Do(http.MethodPost, "/api/v1/auth/register", models.RegisterRequest{
+			Email: "nurse@example.com", Password: "secret123",
+			FirstName: "N", LastName: "N",
+		}, "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("clinicians cannot self-register", func(t *testing.T) {
+		w := srv.Do(http.MethodPost, "/api/v1/auth/register", models.RegisterRequest{
+			Email: "doc@example.com", Password: "secret123",
+			FirstName: "D", LastName: "D", Role: models.RoleClinician,
+		}, "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		w := srv.Do(http.MethodPost, "/api/v1/auth/login",
+			models.LoginRequest{Email: "nurse@example.com", Password: "wrong-password"}, "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	token := srv.Login("nurse@example.com", "secret123")
+
+	t.Run("profile", func(t *testing.T) {
+		w := srv.Do(http.MethodGet, "/api/v1/auth/profile", nil, token)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var profile models.UserWithProfile
+		srv.Decode(w, &profile)
+		assert.Equal(t, "nurse@example.com", profile.Email)
+		assert.Equal(t, models.RolePatient, profile.Role)
+		assert.Equal(t, "Nurse", profile.LastName)
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		w := srv.Do(http.MethodGet, "/api/v1/auth/profile", nil, "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("logout revokes the token", func(t *testing.T) {
+		w := srv.Do(http.MethodPost, "/api/v1/auth/logout", nil, token)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = srv.Do(http.MethodGet, "/api/v1/auth/profile", nil, token)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestAuth_AdminRoutesRequireAdmin(t *testing.T) {
+	srv := testserver.New(t)
+	srv.CreateUser("admin@example.com", "secret123", models.RoleAdmin)
+	srv.CreateUser("doc@example.com", "secret123", models.RoleClinician)
+
+	adminToken := srv.Login("admin@example.com", "secret123")
+	clinicianToken := srv.Login("doc@example.com", "secret123")
+
+	w := srv.Do(http.MethodGet, "/api/v1/admin/users", nil, clinicianToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = srv.Do(http.MethodGet, "/api/v1/admin/users", nil, adminToken)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var page struct {
+		Data       []models.UserSummary `json:"data"`
+		TotalCount int                  `json:"total_count"`
+	}
+	srv.Decode(w, &page)
+	assert.Equal(t, 2, page.TotalCount)
+}
+
+func TestAuth_AdminCreatesClinician(t *testing.T) {
+	srv := testserver.New(t)
+	srv.CreateUser("admin@example.com", "secret123", models.RoleAdmin)
+	adminToken := srv.Login("admin@example.com", "secret123")
+
+	req := models.CreateUserRequest{
+		Email: "doc@example.com", Password: "secret123",
+		FirstName: "Dana", LastName: "Doc", Role: models.RoleClinician,
+	}
+	w := srv.Do(http.MethodPost, "/api/v1/admin/users", req, adminToken)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var user models.UserSummary
+	srv.Decode(w, &user)
+	assert.Equal(t, models.RoleClinician, user.Role)
+
+	w = srv.Do(http.MethodPost, "/api/v1/admin/users", req, adminToken)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "duplicate email")
+
+	w = srv.Do(http.MethodGet, "/api/v1/clinicians", nil, srv.Login("doc@example.com", "secret123"))
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
+
+// loginResponse signs in and returns the full token pair
+func loginResponse(t *testing.T, srv *testserver.Server, email, password string) models.LoginResponse {
+	t.Helper()
+
+	w := srv.Do(http.MethodPost, "/api/v1/auth/login", models.LoginRequest{Email: email, Password: password}, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp models.LoginResponse
+	srv.Decode(w, &resp)
+	require.NotEmpty(t, resp.RefreshToken)
+	return resp
+}
+
+// refresh exchanges a refresh token, returning the status and new pair
+func refresh(srv *testserver.Server, refreshToken string) (int, models.LoginResponse) {
+	w := srv.Do(http.MethodPost, "/api/v1/auth/refresh", models.RefreshTokenRequest{RefreshToken: refreshToken}, "")
+
+	var resp models.LoginResponse
+	if w.Code == http.StatusOK {
+		srv.Decode(w, &resp)
+	}
+	return w.Code, resp
+}
+
+func TestAuth_RefreshRotationAndReuse(t *testing.T) {
+	srv := testserver.New(t)
+	srv.CreateUser("nurse@example.com", "secret123", models.RolePatient)
+
+	t.Run("rotation issues a new pair", func(t *testing.T) {
+		first := loginResponse(t, srv, "nurse@example.com", "secret123")
+
+		code, rotated := refresh(srv, first.RefreshToken)
+		require.Equal(t, http.StatusOK, code)
+		assert.NotEmpty(t, rotated.Token)
+		assert.NotEqual(t, first.RefreshToken, rotated.RefreshToken)
+
+		code, _ = refresh(srv, rotated.RefreshToken)
+		assert.Equal(t, http.StatusOK, code, "the replacement is usable once")
+	})
+
+	t.Run("replaying a rotated token revokes the family", func(t *testing.T) {
+		before := len(srv.Store.SecurityEvents())
+		first := loginResponse(t, srv, "nurse@example.com", "secret123")
+
+		code, rotated := refresh(srv, first.RefreshToken)
+		require.Equal(t, http.StatusOK, code)
+
+		code, _ = refresh(srv, first.RefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, code)
+
+		code, _ = refresh(srv, rotated.RefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, code, "replacement revoked with its family")
+
+		events := srv.Store.SecurityEvents()[before:]
+		require.Len(t, events, 1)
+		assert.Equal(t, models.SecurityEventRefreshTokenReuse, events[0].EventType)
+	})
+
+	t.Run("a refresh token is not a bearer token", func(t *testing.T) {
+		session := loginResponse(t, srv, "nurse@example.com", "secret123")
+
+		w := srv.Do(http.MethodGet, "/api/v1/auth/profile", nil, session.RefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		w = srv.Do(http.MethodGet, "/api/v1/me", nil, session.RefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("a token revoked by logout is not reuse", func(t *testing.T) {
+		before := len(srv.Store.SecurityEvents())
+		session := loginResponse(t, srv, "nurse@example.com", "secret123")
+
+		w := srv.Do(http.MethodPost, "/api/v1/auth/logout", nil, session.Token)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		code, _ := refresh(srv, session.RefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, code)
+		assert.Len(t, srv.Store.SecurityEvents(), before, "no reuse event")
+	})
+}
+
+func TestAuth_ResendVerification(t *testing.T) {
+	srv := testserver.New(t)
+	srv.CreateUser("new@example.com", "secret123", models.RolePatient)
+	verified := srv.CreateUser("verified@example.com", "secret123", models.RolePatient)
+	require.NoError(t, srv.Store.Repositories().Auth.MarkEmailVerified(verified.ID))
+
+	resend := func(email string) (int, string) {
+		w := srv.Do(http.MethodPost, "/api/v1/auth/resend-verification", models.ResendVerificationRequest{Email: email}, "")
+		return w.Code, w.Body.String()
+	}
+
+	code, unknownBody := resend("nobody@example.com")
+	require.Equal(t, http.StatusOK, code)
+
+	code, body := resend("verified@example.com")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, unknownBody, body, "verified accounts are indistinguishable from unknown ones")
+	assert.Empty(t, srv.Mail.Messages())
+
+	code, body = resend("new@example.com")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, unknownBody, body)
+	require.Len(t, srv.Mail.Messages(), 1)
+	assert.Equal(t, "new@example.com", srv.Mail.Messages()[0].To)
+
+	t.Run("rate limited per client", func(t *testing.T) {
+		var codes []int
+		for i := 0; i < 3; i++ {
+			code, _ := resend("nobody@example.com")
+			codes = append(codes, code)
+		}
+		assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+	})
+}
+
+// mailedTokenPattern extracts the token from a link in an outgoing email
+var mailedTokenPattern = regexp.MustCompile(`token=([A-Za-z0-9_-]+)`)
+
+// lastMailedToken returns the token from the most recent email to address
+func lastMailedToken(t *testing.T, srv *testserver.Server, address string) string {
+	t.Helper()
+
+	messages := srv.Mail.Messages()
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].To != address {
+			continue
+		}
+		match := mailedTokenPattern.FindStringSubmatch(messages[i].Body)
+		require.NotNil(t, match, "no token in %q", messages[i].Body)
+		return match[1]
+	}
+	t.Fatalf("no email sent to %s", address)
+	return ""
+}
+
+func TestAuth_VerifyEmail(t *testing.T) {
+	srv := testserver.NewWithConfig(t, service.AuthConfig{
+		AppBaseURL:               "http://localhost",
+		RequireEmailVerification: true,
+		PasswordPolicy:           utils.PasswordPolicy{MinLength: 6, MaxLength: 100},
+	})
+
+	w := srv.Do(http.MethodPost, "/api/v1/auth/register", models.RegisterRequest{
+		Email: "new@example.com", Password: "secret123", FirstName: "N", LastName: "N",
+	}, "")
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	token := lastMailedToken(t, srv, "new@example.com")
+
+	w = srv.Do(http.MethodPost, "/api/v1/auth/login", models.LoginRequest{Email: "new@example.com", Password: "secret123"}, "")
+	assert.Equal(t, http.StatusForbidden, w.Code, "login blocked until verified")
+
+	w = srv.Do(http.MethodGet, "/api/v1/auth/verify-email?token=not-a-token", nil, "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = srv.Do(http.MethodGet, "/api/v1/auth/verify-email?token="+token, nil, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = srv.Do(http.MethodPost, "/api/v1/auth/verify-email", models.VerifyEmailRequest{Token: token}, "")
+	assert.Equal(t, http.StatusBadRequest, w.Code, "tokens are single-use")
+
+	srv.Login("new@example.com", "secret123")
+}
+
+func TestAuth_ForgotAndResetPassword(t *testing.T) {
+	srv := testserver.New(t)
+	srv.CreateUser("nurse@example.com", "secret123", models.RolePatient)
+	session := loginResponse(t, srv, "nurse@example.com", "secret123")
+
+	w := srv.Do(http.MethodPost, "/api/v1/auth/forgot-password", models.ResetPasswordRequest{Email: "nobody@example.com"}, "")
+	require.Equal(t, http.StatusOK, w.Code, "unknown addresses get the same answer")
+	assert.Empty(t, srv.Mail.Messages())
+
+	w = srv.Do(http.MethodPost, "/api/v1/auth/forgot-password", models.ResetPasswordRequest{Email: "nurse@example.com"}, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	token := lastMailedToken(t, srv, "nurse@example.com")
+
+	t.Run("weak password", func(t *testing.T) {
+		w := srv.Do(http.MethodPost, "/api/v1/auth/reset-password",
+			models.ConfirmResetPasswordRequest{Token: token, NewPassword: "abc"}, "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		w := srv.Do(http.MethodPost, "/api/v1/auth/reset-password",
+			models.ConfirmResetPasswordRequest{Token: "not-a-token", NewPassword: "newsecret456"}, "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	w = srv.Do(http.MethodPost, "/api/v1/auth/reset-password",
+		models.ConfirmResetPasswordRequest{Token: token, NewPassword: "newsecret456"}, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	code, _ := refresh(srv, session.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, code, "existing sessions are revoked")
+
+	w = srv.Do(http.MethodPost, "/api/v1/auth/login", models.LoginRequest{Email: "nurse@example.com", Password: "secret123"}, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	srv.Login("nurse@example.com", "newsecret456")
+
+	w = srv.Do(http.MethodPost, "/api/v1/auth/reset-password",
+		models.ConfirmResetPasswordRequest{Token: token, NewPassword: "another789"}, "")
+	assert.Equal(t, http.StatusBadRequest, w.Code, "tokens are single-use")
+}
+
+func TestAuth_MFALogin(t *testing.T) {
+	srv := testserver.New(t)
+	srv.CreateUser("doc@example.com", "secret123", models.RoleClinician)
+	token := srv.Login("doc@example.com", "secret123")
+
+	w := srv.Do(http.MethodPost, "/api/v1/auth/mfa/enroll", nil, token)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var enroll models.MFAEnrollResponse
+	srv.Decode(w, &enroll)
+	require.NotEmpty(t, enroll.Secret)
+
+	w = srv.Do(http.MethodPost, "/api/v1/auth/mfa/confirm", models.MFAConfirmRequest{Code: "000000"}, token)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "wrong code")
+
+	code, err := utils.GenerateTOTPCode(enroll.Secret, time.Now())
+	require.NoError(t, err)
+	w = srv.Do(http.MethodPost, "/api/v1/auth/mfa/confirm", models.MFAConfirmRequest{Code: code}, token)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var confirmed models.MFAConfirmResponse
+	srv.Decode(w, &confirmed)
+	require.Len(t, confirmed.RecoveryCodes, 10)
+
+	challenge := func() string {
+		w := srv.Do(http.MethodPost, "/api/v1/auth/login", models.LoginRequest{Email: "doc@example.com", Password: "secret123"}, "")
+		require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+		var resp models.MFAChallengeResponse
+		srv.Decode(w, &resp)
+		require.True(t, resp.MFARequired)
+		return resp.MFAToken
+	}
+
+	mfaToken := challenge()
+	w = srv.Do(http.MethodGet, "/api/v1/auth/profile", nil, mfaToken)
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "the challenge token is not an access token")
+
+	w = srv.Do(http.MethodPost, "/api/v1/auth/login/mfa", models.MFALoginRequest{MFAToken: mfaToken, Code: "000000"}, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	recovery := confirmed.RecoveryCodes[0]
+	w = srv.Do(http.MethodPost, "/api/v1/auth/login/mfa", models.MFALoginRequest{MFAToken: mfaToken, Code: recovery}, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var login models.LoginResponse
+	srv.Decode(w, &login)
+	assert.NotEmpty(t, login.Token)
+
+	w = srv.Do(http.MethodPost, "/api/v1/auth/login/mfa", models.MFALoginRequest{MFAToken: challenge(), Code: recovery}, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "recovery codes are single-use")
+
+	t.Run("disable", func(t *testing.T) {
+		w := srv.Do(http.MethodPost, "/api/v1/auth/mfa/disable",
+			models.MFADisableRequest{Password: "wrong-password", Code: confirmed.RecoveryCodes[1]}, login.Token)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = srv.Do(http.MethodPost, "/api/v1/auth/mfa/disable",
+			models.MFADisableRequest{Password: "secret123", Code: confirmed.RecoveryCodes[1]}, login.Token)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		srv.Login("doc@example.com", "secret123")
+	})
+}
+
+func TestAuth_Sessions(t *testing.T) {
+	srv := testserver.New(t)
+	srv.CreateUser("admin@example.com", "secret123", models.RoleAdmin)
+	user := srv.CreateUser("nurse@example.com", "secret123", models.RolePatient)
+	adminToken := srv.Login("admin@example.com", "secret123")
+
+	laptop := loginResponse(t, srv, "nurse@example.com", "secret123")
+	phone := loginResponse(t, srv, "nurse@example.com", "secret123")
+
+	listSessions := func() []models.Session {
+		w := srv.Do(http.MethodGet, "/api/v1/auth/sessions", nil, laptop.Token)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp struct {
+			Sessions []models.Session `json:"sessions"`
+		}
+		srv.Decode(w, &resp)
+		return resp.Sessions
+	}
+
+	sessions := listSessions()
+	require.Len(t, sessions, 2)
+
+	t.Run("another user's session is not found", func(t *testing.T) {
+		w := srv.Do(http.MethodDelete, fmt.Sprintf("/api/v1/auth/sessions/%d", sessions[0].ID), nil, adminToken)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	// The phone signed in last, so its session has the higher ID
+	phoneSession := sessions[0].ID
+	if sessions[1].ID > phoneSession {
+		phoneSession = sessions[1].ID
+	}
+	w := srv.Do(http.MethodDelete, fmt.Sprintf("/api/v1/auth/sessions/%d", phoneSession), nil, laptop.Token)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	code, _ := refresh(srv, phone.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, rotated := refresh(srv, laptop.RefreshToken)
+	require.Equal(t, http.StatusOK, code, "the other session survives")
+	assert.Len(t, listSessions(), 1)
+
+	t.Run("admin revokes every session", func(t *testing.T) {
+		path := fmt.Sprintf("/api/v1/admin/users/%d/sessions", user.ID)
+		w := srv.Do(http.MethodGet, path, nil, adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp struct {
+			Sessions []models.Session `json:"sessions"`
+		}
+		srv.Decode(w, &resp)
+		assert.Len(t, resp.Sessions, 1)
+
+		w = srv.Do(http.MethodDelete, path, nil, adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		code, _ := refresh(srv, rotated.RefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, code)
+	})
+}
+
+func TestAuth_Lockout(t *testing.T) {
+	srv := testserver.NewWithConfig(t, service.AuthConfig{
+		LockoutThreshold: 3,
+		LockoutDuration:  15 * time.Minute,
+		PasswordPolicy:   utils.PasswordPolicy{MinLength: 6, MaxLength: 100},
+	})
+	srv.CreateUser("admin@example.com", "secret123", models.RoleAdmin)
+	user := srv.CreateUser("nurse@example.com", "secret123", models.RolePatient)
+	adminToken := srv.Login("admin@example.com", "secret123")
+
+	login := func(password string) int {
+		return srv.Do(http.MethodPost, "/api/v1/auth/login",
+			models.LoginRequest{Email: "nurse@example.com", Password: password}, "").Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, login("wrong-password"))
+	assert.Equal(t, http.StatusUnauthorized, login("wrong-password"))
+	assert.Equal(t, http.StatusLocked, login("wrong-password"), "the third failure locks the account")
+	assert.Equal(t, http.StatusLocked, login("secret123"), "even the right password is refused")
+
+	w := srv.Do(http.MethodGet, "/api/v1/admin/users/locked", nil, adminToken)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var locked struct {
+		Users []struct {
+			UserID int  `json:"user_id"`
+			Locked bool `json:"locked"`
+		} `json:"users"`
+	}
+	srv.Decode(w, &locked)
+	require.Len(t, locked.Users, 1)
+	assert.Equal(t, user.ID, locked.Users[0].UserID)
+	assert.True(t, locked.Users[0].Locked)
+
+	w = srv.Do(http.MethodDelete, fmt.Sprintf("/api/v1/admin/users/%d/lock", user.ID), nil, adminToken)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	assert.Equal(t, http.StatusOK, login("secret123"))
+}
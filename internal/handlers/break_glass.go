@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/middleware"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/service"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BreakGlassHandler handles emergency-access requests and their review
+type BreakGlassHandler struct {
+	breakGlass *service.BreakGlassService
+}
+
+// NewBreakGlassHandler creates a new break-glass handler
+func NewBreakGlassHandler(breakGlass *service.BreakGlassService) *BreakGlassHandler {
+	return &BreakGlassHandler{breakGlass: breakGlass}
+}
+
+// StartBreakGlass grants the caller a time-boxed elevated token after they state a reason
+func (h *BreakGlassHandler) StartBreakGlass(c *gin.Context) {
+	var req models.BreakGlassRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if _, active := middleware.GetBreakGlassID(c); active {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Break-glass already active",
+			Message: "Request emergency access with your regular token",
+		})
+		return
+	}
+
+	userID, _ := middleware.GetUserID(c)
+	email, _ := middleware.GetUserEmail(c)
+	role, _ := middleware.GetUserRole(c)
+
+	response, err := h.breakGlass.Start(userID, email, role, req.Reason, c.ClientIP())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to grant emergency access",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// ListBreakGlassEvents returns the break-glass review report
+func (h *BreakGlassHandler) ListBreakGlassEvents(c *gin.Context) {
+	var filter models.BreakGlassEventFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	events, totalCount, err := h.breakGlass.ListEvents(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to query break-glass events",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	totalPages := int(math.Ceil(float64(totalCount) / float64(filter.GetLimit())))
+
+	c.JSON(http.StatusOK, models.PaginatedResponse{
+		Data:       events,
+		Page:       filter.Page,
+		PageSize:   filter.GetLimit(),
+		TotalCount: totalCount,
+		TotalPages: totalPages,
+	})
+}
+
+// GetBreakGlassEvent returns one event with every record accessed under it
+func (h *BreakGlassHandler) GetBreakGlassEvent(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid event ID",
+			Message: "Event ID must be a valid integer",
+		})
+		return
+	}
+
+	event, err := h.breakGlass.GetEvent(id)
+	if errors.Is(err, utils.ErrNotFound) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Event not found",
+			Message: fmt.Sprintf("Break-glass event with ID %d does not exist", id),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to query break-glass event",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
+
+// ReviewBreakGlassEvent records the reviewer's findings for an event
+func (h *BreakGlassHandler) ReviewBreakGlassEvent(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid event ID",
+			Message: "Event ID must be a valid integer",
+		})
+		return
+	}
+
+	var req models.ReviewBreakGlassRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	reviewerID, _ := middleware.GetUserID(c)
+
+	event, err := h.breakGlass.Review(id, reviewerID, req.Notes)
+	if errors.Is(err, utils.ErrNotFound) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Event not found",
+			Message: fmt.Sprintf("Break-glass event with ID %d does not exist", id),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to review break-glass event",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
@@ -0,0 +1,111 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakGlass_ElevatedReadsAreAuditedAndReviewed(t *testing.T) {
+	f := newClinicalFixture(t)
+	own := f.createPatient(t, "Own Patient", "MRN-OWN")
+
+	// A patient created by an admin has no care team
+	w := f.Do(http.MethodPost, "/api/v1/patients", models.CreatePatientRequest{
+		FullName:            "Other Patient",
+		DateOfBirth:         "1980-05-01T00:00:00Z",
+		Gender:              "Male",
+		MedicalRecordNumber: "MRN-OTHER",
+	}, f.adminToken)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var other models.Patient
+	f.Decode(w, &other)
+
+	otherPath := fmt.Sprintf("/api/v1/patients/%d", other.PatientID)
+	w = f.Do(http.MethodGet, otherPath, nil, f.clinicianToken)
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	t.Run("reason is required", func(t *testing.T) {
+		w := f.Do(http.MethodPost, "/api/v1/break-glass", models.BreakGlassRequest{Reason: "urgent"}, f.clinicianToken)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("admins cannot start break-glass", func(t *testing.T) {
+		w := f.Do(http.MethodPost, "/api/v1/break-glass",
+			models.BreakGlassRequest{Reason: "Checking the emergency flow"}, f.adminToken)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	w = f.Do(http.MethodPost, "/api/v1/break-glass",
+		models.BreakGlassRequest{Reason: "Unconscious patient in the emergency department"}, f.clinicianToken)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var grant models.BreakGlassResponse
+	f.Decode(w, &grant)
+	require.NotEmpty(t, grant.Token)
+
+	w = f.Do(http.MethodGet, otherPath, nil, grant.Token)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = f.Do(http.MethodGet, "/api/v1/patients", nil, grant.Token)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var page models.PaginatedResponse
+	f.Decode(w, &page)
+	assert.Equal(t, 2, page.TotalCount, "break-glass lifts care-team scoping")
+
+	w = f.Do(http.MethodPost, "/api/v1/break-glass",
+		models.BreakGlassRequest{Reason: "Unconscious patient in the emergency department"}, grant.Token)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "cannot chain break-glass tokens")
+
+	eventPath := fmt.Sprintf("/api/v1/break-glass/events/%d", grant.EventID)
+
+	t.Run("clinicians cannot review", func(t *testing.T) {
+		w := f.Do(http.MethodGet, eventPath, nil, f.clinicianToken)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("every patient read is recorded", func(t *testing.T) {
+		w := f.Do(http.MethodGet, eventPath, nil, f.adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var event struct {
+			Accesses []models.BreakGlassAccess `json:"accesses"`
+		}
+		f.Decode(w, &event)
+
+		perPatient := map[int][]string{}
+		for _, a := range event.Accesses {
+			require.NotNil(t, a.PatientID, "list reads are recorded per patient: %s", a.Resource)
+			perPatient[*a.PatientID] = append(perPatient[*a.PatientID], a.Resource)
+		}
+		assert.Equal(t, []string{"GET " + otherPath, "GET /api/v1/patients"}, perPatient[other.PatientID])
+		assert.Equal(t, []string{"GET /api/v1/patients"}, perPatient[own.PatientID])
+	})
+
+	t.Run("review", func(t *testing.T) {
+		w := f.Do(http.MethodGet, "/api/v1/break-glass/events?unreviewed=true", nil, f.adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var pending models.PaginatedResponse
+		f.Decode(w, &pending)
+		assert.Equal(t, 1, pending.TotalCount)
+
+		w = f.Do(http.MethodPost, eventPath+"/review",
+			models.ReviewBreakGlassRequest{Notes: "Justified: patient arrived unresponsive"}, f.adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var event struct {
+			ReviewedBy  *int   `json:"reviewed_by"`
+			ReviewNotes string `json:"review_notes"`
+		}
+		f.Decode(w, &event)
+		assert.NotNil(t, event.ReviewedBy)
+		assert.Equal(t, "Justified: patient arrived unresponsive", event.ReviewNotes)
+
+		w = f.Do(http.MethodGet, "/api/v1/break-glass/events?unreviewed=true", nil, f.adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		f.Decode(w, &pending)
+		assert.Equal(t, 0, pending.TotalCount)
+	})
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/middleware"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/service"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CareTeamHandler handles care-team assignment requests
+type CareTeamHandler struct {
+	careTeam *service.CareTeamService
+	access   *service.AccessService
+}
+
+// NewCareTeamHandler creates a new care team handler
+func NewCareTeamHandler(careTeam *service.CareTeamService, access *service.AccessService) *CareTeamHandler {
+	return &CareTeamHandler{careTeam: careTeam, access: access}
+}
+
+// GetCareTeam lists the clinicians assigned to a patient
+func (h *CareTeamHandler) GetCareTeam(c *gin.Context) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid patient ID",
+			Message: "Patient ID must be a valid integer",
+		})
+		return
+	}
+
+	if !authorizePatientAccess(c, h.access, patientID) {
+		return
+	}
+
+	assignments, err := h.careTeam.ListCareTeam(patientID)
+	if errors.Is(err, utils.ErrNotFound) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Patient not found",
+			Message: fmt.Sprintf("Patient with ID %d does not exist", patientID),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to query care team",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"patient_id": patientID,
+		"care_team":  assignments,
+	})
+}
+
+// AssignClinician adds a clinician to a patient's care team
+func (h *CareTeamHandler) AssignClinician(c *gin.Context) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid patient ID",
+			Message: "Patient ID must be a valid integer",
+		})
+		return
+	}
+
+	var req models.AssignCareTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	userID, _ := middleware.GetUserID(c)
+
+	assignment, err := h.careTeam.Assign(patientID, req, userID)
+	switch {
+	case err == nil:
+		c.JSON(http.StatusCreated, assignment)
+	case errors.Is(err, utils.ErrBadRequest):
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid date format",
+			Message: "Dates must be in ISO-8601 format (e.g., 2024-01-15T00:00:00Z)",
+		})
+	case errors.Is(err, utils.ErrInvalidDateRange), errors.Is(err, utils.ErrNotClinician):
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid assignment",
+			Message: err.Error(),
+		})
+	case errors.Is(err, utils.ErrNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Patient not found",
+			Message: fmt.Sprintf("Patient with ID %d does not exist", patientID),
+		})
+	case errors.Is(err, utils.ErrAlreadyAssigned):
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "Already assigned",
+			Message: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to assign clinician",
+			Message: err.Error(),
+		})
+	}
+}
+
+// UnassignClinician ends a care-team assignment
+func (h *CareTeamHandler) UnassignClinician(c *gin.Context) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid patient ID",
+			Message: "Patient ID must be a valid integer",
+		})
+		return
+	}
+
+	assignmentID, err := strconv.Atoi(c.Param("assignment_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid assignment ID",
+			Message: "Assignment ID must be a valid integer",
+		})
+		return
+	}
+
+	err = h.careTeam.Unassign(patientID, assignmentID)
+	if errors.Is(err, utils.ErrNotFound) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Assignment not found",
+			Message: fmt.Sprintf("No open assignment %d for patient %d", assignmentID, patientID),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to unassign clinician",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: fmt.Sprintf("Assignment %d ended", assignmentID),
+	})
+}
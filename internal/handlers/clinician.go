@@ -1,26 +1,27 @@
 package handlers
 
 import (
-	"database/sql"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
 	"strconv"
 
-	"github.com/vellalasantosh/wound_iq_api_claude/internal/db"
 	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/service"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
 // ClinicianHandler handles clinician-related requests
 type ClinicianHandler struct {
-	db *db.DB
+	clinicians *service.ClinicianService
 }
 
 // NewClinicianHandler creates a new clinician handler
-func NewClinicianHandler(database *db.DB) *ClinicianHandler {
-	return &ClinicianHandler{db: database}
+func NewClinicianHandler(clinicians *service.ClinicianService) *ClinicianHandler {
+	return &ClinicianHandler{clinicians: clinicians}
 }
 
 // GetAllClinicians retrieves all clinicians with pagination
@@ -34,24 +35,7 @@ func (h *ClinicianHandler) GetAllClinicians(c *gin.Context) {
 		return
 	}
 
-	// Get total count
-	var totalCount int
-	err := h.db.QueryRow("SELECT COUNT(*) FROM clinician").Scan(&totalCount)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "Failed to count clinicians",
-			Message: err.Error(),
-		})
-		return
-	}
-
-	// Query clinicians with pagination
-	rows, err := h.db.Query(`
-		SELECT clinician_id, full_name, role, department, contact_info, license_number
-		FROM clinician
-		ORDER BY full_name
-		LIMIT $1 OFFSET $2
-	`, params.GetLimit(), params.GetOffset())
+	clinicians, totalCount, err := h.clinicians.ListClinicians(&params)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "Failed to query clinicians",
@@ -59,20 +43,6 @@ func (h *ClinicianHandler) GetAllClinicians(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-
-	var clinicians []models.Clinician
-	for rows.Next() {
-		var cl models.Clinician
-		if err := rows.Scan(&cl.ClinicianID, &cl.FullName, &cl.Role, &cl.Department, &cl.ContactInfo, &cl.LicenseNumber); err != nil {
-			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-				Error:   "Failed to scan clinician",
-				Message: err.Error(),
-			})
-			return
-		}
-		clinicians = append(clinicians, cl)
-	}
 
 	totalPages := int(math.Ceil(float64(totalCount) / float64(params.GetLimit())))
 
@@ -96,21 +66,11 @@ func (h *ClinicianHandler) GetClinicianByID(c *gin.Context) {
 		return
 	}
 
-	var clinician models.Clinician
-	err = h.db.QueryRow(`
-		SELECT clinician_id, full_name, role, department, contact_info, license_number
-		FROM clinician
-		WHERE clinician_id = $1
-	`, id).Scan(&clinician.ClinicianID, &clinician.FullName, &clinician.Role, &clinician.Department, &clinician.ContactInfo, &clinician.LicenseNumber)
-
-	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "Clinician not found",
-			Message: fmt.Sprintf("Clinician with ID %d does not exist", id),
-		})
+	clinician, err := h.clinicians.GetClinician(id)
+	if errors.Is(err, utils.ErrNotFound) {
+		clinicianNotFound(c, id)
 		return
 	}
-
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "Failed to query clinician",
@@ -133,14 +93,7 @@ func (h *ClinicianHandler) CreateClinician(c *gin.Context) {
 		return
 	}
 
-	// Insert clinician
-	var newID int
-	err := h.db.QueryRow(`
-		INSERT INTO clinician (full_name, role, department, contact_info, license_number)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING clinician_id
-	`, req.FullName, req.Role, req.Department, req.ContactInfo, req.LicenseNumber).Scan(&newID)
-
+	clinician, err := h.clinicians.CreateClinician(req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "Failed to create clinician",
@@ -149,22 +102,6 @@ func (h *ClinicianHandler) CreateClinician(c *gin.Context) {
 		return
 	}
 
-	// Retrieve the created clinician
-	var clinician models.Clinician
-	err = h.db.QueryRow(`
-		SELECT clinician_id, full_name, role, department, contact_info, license_number
-		FROM clinician
-		WHERE clinician_id = $1
-	`, newID).Scan(&clinician.ClinicianID, &clinician.FullName, &clinician.Role, &clinician.Department, &clinician.ContactInfo, &clinician.LicenseNumber)
-
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "Failed to retrieve created clinician",
-			Message: err.Error(),
-		})
-		return
-	}
-
 	c.JSON(http.StatusCreated, clinician)
 }
 
@@ -188,54 +125,11 @@ func (h *ClinicianHandler) UpdateClinician(c *gin.Context) {
 		return
 	}
 
-	// Check if clinician exists
-	var exists bool
-	err = h.db.QueryRow("SELECT EXISTS(SELECT 1 FROM clinician WHERE clinician_id = $1)", id).Scan(&exists)
-	if err != nil || !exists {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "Clinician not found",
-			Message: fmt.Sprintf("Clinician with ID %d does not exist", id),
-		})
+	clinician, err := h.clinicians.UpdateClinician(id, req)
+	if errors.Is(err, utils.ErrNotFound) {
+		clinicianNotFound(c, id)
 		return
 	}
-
-	// Build dynamic update query
-	query := "UPDATE clinician SET "
-	args := []interface{}{}
-	argPos := 1
-
-	if req.FullName != "" {
-		query += fmt.Sprintf("full_name = $%d, ", argPos)
-		args = append(args, req.FullName)
-		argPos++
-	}
-	if req.Role != "" {
-		query += fmt.Sprintf("role = $%d, ", argPos)
-		args = append(args, req.Role)
-		argPos++
-	}
-	if req.Department != "" {
-		query += fmt.Sprintf("department = $%d, ", argPos)
-		args = append(args, req.Department)
-		argPos++
-	}
-	if req.ContactInfo != "" {
-		query += fmt.Sprintf("contact_info = $%d, ", argPos)
-		args = append(args, req.ContactInfo)
-		argPos++
-	}
-	if req.LicenseNumber != "" {
-		query += fmt.Sprintf("license_number = $%d, ", argPos)
-		args = append(args, req.LicenseNumber)
-		argPos++
-	}
-
-	// Remove trailing comma and space
-	query = query[:len(query)-2]
-	query += fmt.Sprintf(" WHERE clinician_id = $%d", argPos)
-	args = append(args, id)
-
-	_, err = h.db.Exec(query, args...)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "Failed to update clinician",
@@ -244,26 +138,12 @@ func (h *ClinicianHandler) UpdateClinician(c *gin.Context) {
 		return
 	}
 
-	// Retrieve updated clinician
-	var clinician models.Clinician
-	err = h.db.QueryRow(`
-		SELECT clinician_id, full_name, role, department, contact_info, license_number
-		FROM clinician
-		WHERE clinician_id = $1
-	`, id).Scan(&clinician.ClinicianID, &clinician.FullName, &clinician.Role, &clinician.Department, &clinician.ContactInfo, &clinician.LicenseNumber)
-
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "Failed to retrieve updated clinician",
-			Message: err.Error(),
-		})
-		return
-	}
-
 	c.JSON(http.StatusOK, clinician)
 }
 
-// DeleteClinician deletes a clinician
+// DeleteClinician deletes a clinician and their care-team assignments. A
+// clinician who charted assessments is refused in either mode, since
+// assessments are medical records and are never removed.
 func (h *ClinicianHandler) DeleteClinician(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
@@ -274,19 +154,27 @@ func (h *ClinicianHandler) DeleteClinician(c *gin.Context) {
 		return
 	}
 
-	// Check if clinician exists
-	var exists bool
-	err = h.db.QueryRow("SELECT EXISTS(SELECT 1 FROM clinician WHERE clinician_id = $1)", id).Scan(&exists)
-	if err != nil || !exists {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "Clinician not found",
-			Message: fmt.Sprintf("Clinician with ID %d does not exist", id),
+	var query models.DeleteQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Message: err.Error(),
 		})
 		return
 	}
 
-	// Delete clinician
-	_, err = h.db.Exec("DELETE FROM clinician WHERE clinician_id = $1", id)
+	result, err := h.clinicians.DeleteClinician(id, query.GetMode())
+	if errors.Is(err, utils.ErrNotFound) {
+		clinicianNotFound(c, id)
+		return
+	}
+	if errors.Is(err, utils.ErrHasDependents) {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "Clinician has dependent records",
+			Message: err.Error() + "; assessments are kept as medical records, so the clinician cannot be deleted",
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "Failed to delete clinician",
@@ -297,5 +185,13 @@ func (h *ClinicianHandler) DeleteClinician(c *gin.Context) {
 
 	c.JSON(http.StatusOK, models.SuccessResponse{
 		Message: fmt.Sprintf("Clinician with ID %d deleted successfully", id),
+		Data:    result,
+	})
+}
+
+func clinicianNotFound(c *gin.Context, id int) {
+	c.JSON(http.StatusNotFound, models.ErrorResponse{
+		Error:   "Clinician not found",
+		Message: fmt.Sprintf("Clinician with ID %d does not exist", id),
 	})
 }
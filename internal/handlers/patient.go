@@ -1,27 +1,29 @@
 package handlers
 
 import (
-	"database/sql"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
 	"strconv"
-	"time"
 
-	"github.com/vellalasantosh/wound_iq_api_claude/internal/db"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/middleware"
 	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/service"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
 // PatientHandler handles patient-related requests
 type PatientHandler struct {
-	db *db.DB
+	patients *service.PatientService
+	access   *service.AccessService
 }
 
 // NewPatientHandler creates a new patient handler
-func NewPatientHandler(database *db.DB) *PatientHandler {
-	return &PatientHandler{db: database}
+func NewPatientHandler(patients *service.PatientService, access *service.AccessService) *PatientHandler {
+	return &PatientHandler{patients: patients, access: access}
 }
 
 // GetAllPatients retrieves all patients with pagination
@@ -42,24 +44,13 @@ func (h *PatientHandler) GetAllPatients(c *gin.Context) {
 		return
 	}
 
-	// Get total count
-	var totalCount int
-	err := h.db.QueryRow("SELECT COUNT(*) FROM patient").Scan(&totalCount)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "Failed to count patients",
-			Message: err.Error(),
-		})
-		return
+	// Clinicians only see patients on their care team
+	careTeamUserID := 0
+	if caller := currentCaller(c); h.access.ScopeToCareTeam(caller) {
+		careTeamUserID = caller.UserID
 	}
 
-	// Query patients with pagination
-	rows, err := h.db.Query(`
-		SELECT patient_id, full_name, date_of_birth, gender, medical_record_number
-		FROM patient
-		ORDER BY full_name
-		LIMIT $1 OFFSET $2
-	`, params.GetLimit(), params.GetOffset())
+	patients, totalCount, err := h.patients.ListPatients(&params, careTeamUserID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "Failed to query patients",
@@ -67,19 +58,13 @@ func (h *PatientHandler) GetAllPatients(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
 
-	var patients []models.Patient
-	for rows.Next() {
-		var p models.Patient
-		if err := rows.Scan(&p.PatientID, &p.FullName, &p.DateOfBirth, &p.Gender, &p.MedicalRecordNumber); err != nil {
-			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-				Error:   "Failed to scan patient",
-				Message: err.Error(),
-			})
-			return
-		}
-		patients = append(patients, p)
+	patientIDs := make([]int, len(patients))
+	for i, p := range patients {
+		patientIDs[i] = p.PatientID
+	}
+	if !auditListAccess(c, h.access, patientIDs) {
+		return
 	}
 
 	totalPages := int(math.Ceil(float64(totalCount) / float64(params.GetLimit())))
@@ -110,21 +95,15 @@ func (h *PatientHandler) GetPatientByID(c *gin.Context) {
 		return
 	}
 
-	var patient models.Patient
-	err = h.db.QueryRow(`
-		SELECT patient_id, full_name, date_of_birth, gender, medical_record_number
-		FROM patient
-		WHERE patient_id = $1
-	`, id).Scan(&patient.PatientID, &patient.FullName, &patient.DateOfBirth, &patient.Gender, &patient.MedicalRecordNumber)
-
-	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "Patient not found",
-			Message: fmt.Sprintf("Patient with ID %d does not exist", id),
-		})
+	if !authorizePatientAccess(c, h.access, id) {
 		return
 	}
 
+	patient, err := h.patients.GetPatient(id)
+	if errors.Is(err, utils.ErrNotFound) {
+		patientNotFound(c, id)
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "Failed to query patient",
@@ -154,22 +133,36 @@ func (h *PatientHandler) CreatePatient(c *gin.Context) {
 		return
 	}
 
-	// Parse date of birth
-	dob, err := time.Parse(time.RFC3339, req.DateOfBirth)
-	if err != nil {
+	// A clinician who registers a patient joins their care team
+	caller := currentCaller(c)
+	careTeamClinicianID := 0
+	if caller.Role == models.RoleClinician {
+		clinicianID, err := h.access.GetOwnClinicianID(caller.UserID)
+		if errors.Is(err, utils.ErrNotClinician) {
+			c.JSON(http.StatusForbidden, models.ErrorResponse{
+				Error:   "Forbidden",
+				Message: "Your account has no clinician profile",
+			})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error:   "Failed to resolve clinician",
+				Message: err.Error(),
+			})
+			return
+		}
+		careTeamClinicianID = clinicianID
+	}
+
+	patient, err := h.patients.CreatePatient(req, careTeamClinicianID, caller.UserID)
+	if errors.Is(err, utils.ErrBadRequest) {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Error:   "Invalid date format",
 			Message: "Date of birth must be in ISO-8601 format (e.g., 2000-01-15T00:00:00Z)",
 		})
 		return
 	}
-
-	// Call the add_patient PostgreSQL function
-	var newID int
-	err = h.db.QueryRow(`
-		SELECT add_patient($1, $2, $3, $4)
-	`, req.FullName, dob, req.Gender, req.MedicalRecordNumber).Scan(&newID)
-
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "Failed to create patient",
@@ -178,22 +171,6 @@ func (h *PatientHandler) CreatePatient(c *gin.Context) {
 		return
 	}
 
-	// Retrieve the created patient
-	var patient models.Patient
-	err = h.db.QueryRow(`
-		SELECT patient_id, full_name, date_of_birth, gender, medical_record_number
-		FROM patient
-		WHERE patient_id = $1
-	`, newID).Scan(&patient.PatientID, &patient.FullName, &patient.DateOfBirth, &patient.Gender, &patient.MedicalRecordNumber)
-
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "Failed to retrieve created patient",
-			Message: err.Error(),
-		})
-		return
-	}
-
 	c.JSON(http.StatusCreated, patient)
 }
 
@@ -216,6 +193,10 @@ func (h *PatientHandler) UpdatePatient(c *gin.Context) {
 		return
 	}
 
+	if !authorizePatientAccess(c, h.access, id) {
+		return
+	}
+
 	var req models.UpdatePatientRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -225,92 +206,128 @@ func (h *PatientHandler) UpdatePatient(c *gin.Context) {
 		return
 	}
 
-	// Check if patient exists
-	var exists bool
-	err = h.db.QueryRow("SELECT EXISTS(SELECT 1 FROM patient WHERE patient_id = $1)", id).Scan(&exists)
-	if err != nil || !exists {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "Patient not found",
-			Message: fmt.Sprintf("Patient with ID %d does not exist", id),
+	patient, err := h.patients.UpdatePatient(id, req)
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, patient)
+	case errors.Is(err, utils.ErrNotFound):
+		patientNotFound(c, id)
+	case errors.Is(err, utils.ErrBadRequest):
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid date format",
+			Message: "Date of birth must be in ISO-8601 format",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to update patient",
+			Message: err.Error(),
 		})
-		return
 	}
+}
 
-	// Build dynamic update query
-	query := "UPDATE patient SET "
-	args := []interface{}{}
-	argPos := 1
-
-	if req.FullName != "" {
-		query += fmt.Sprintf("full_name = $%d, ", argPos)
-		args = append(args, req.FullName)
-		argPos++
+// DeletePatient soft-deletes a patient; admins can list and restore them.
+// With mode=restrict (the default) a patient with assessments is refused;
+// mode=cascade deletes the assessments too.
+// @Summary Delete a patient
+// @Tags patients
+// @Produce json
+// @Param id path int true "Patient ID"
+// @Param mode query string false "restrict or cascade"
+// @Param reason query string false "Why the record is deleted"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Router /v1/patients/{id} [delete]
+func (h *PatientHandler) DeletePatient(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid patient ID",
+			Message: "Patient ID must be a valid integer",
+		})
+		return
 	}
-	if req.DateOfBirth != "" {
-		dob, err := time.Parse(time.RFC3339, req.DateOfBirth)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, models.ErrorResponse{
-				Error:   "Invalid date format",
-				Message: "Date of birth must be in ISO-8601 format",
-			})
-			return
-		}
-		query += fmt.Sprintf("date_of_birth = $%d, ", argPos)
-		args = append(args, dob)
-		argPos++
+
+	var query models.DeleteQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid query parameters",
+			Message: err.Error(),
+		})
+		return
 	}
-	if req.Gender != "" {
-		query += fmt.Sprintf("gender = $%d, ", argPos)
-		args = append(args, req.Gender)
-		argPos++
+
+	userID, _ := middleware.GetUserID(c)
+	result, err := h.patients.DeletePatient(id, query, userID)
+	if errors.Is(err, utils.ErrNotFound) {
+		patientNotFound(c, id)
+		return
 	}
-	if req.MedicalRecordNumber != "" {
-		query += fmt.Sprintf("medical_record_number = $%d, ", argPos)
-		args = append(args, req.MedicalRecordNumber)
-		argPos++
+	if errors.Is(err, utils.ErrHasDependents) {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "Patient has dependent records",
+			Message: err.Error() + "; use mode=cascade to delete them with the patient",
+		})
+		return
 	}
-
-	// Remove trailing comma and space
-	query = query[:len(query)-2]
-	query += fmt.Sprintf(" WHERE patient_id = $%d", argPos)
-	args = append(args, id)
-
-	_, err = h.db.Exec(query, args...)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "Failed to update patient",
+			Error:   "Failed to delete patient",
 			Message: err.Error(),
 		})
 		return
 	}
 
-	// Retrieve updated patient
-	var patient models.Patient
-	err = h.db.QueryRow(`
-		SELECT patient_id, full_name, date_of_birth, gender, medical_record_number
-		FROM patient
-		WHERE patient_id = $1
-	`, id).Scan(&patient.PatientID, &patient.FullName, &patient.DateOfBirth, &patient.Gender, &patient.MedicalRecordNumber)
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Message: fmt.Sprintf("Patient with ID %d deleted successfully", id),
+		Data:    result,
+	})
+}
+
+// ListDeletedPatients lists soft-deleted patients, most recently deleted first
+// @Summary List deleted patients
+// @Tags admin
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} models.PaginatedResponse
+// @Router /v1/admin/deleted/patients [get]
+func (h *PatientHandler) ListDeletedPatients(c *gin.Context) {
+	var params models.PaginationParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid pagination parameters",
+			Message: err.Error(),
+		})
+		return
+	}
 
+	patients, totalCount, err := h.patients.ListDeletedPatients(&params)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "Failed to retrieve updated patient",
+			Error:   "Failed to query deleted patients",
 			Message: err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, patient)
+	c.JSON(http.StatusOK, models.PaginatedResponse{
+		Data:       patients,
+		Page:       params.Page,
+		PageSize:   params.GetLimit(),
+		TotalCount: totalCount,
+		TotalPages: int(math.Ceil(float64(totalCount) / float64(params.GetLimit()))),
+	})
 }
 
-// DeletePatient deletes a patient
-// @Summary Delete a patient
-// @Tags patients
+// RestorePatient undeletes a patient along with the assessments deleted with them
+// @Summary Restore a deleted patient
+// @Tags admin
 // @Produce json
 // @Param id path int true "Patient ID"
 // @Success 200 {object} models.SuccessResponse
-// @Router /v1/patients/{id} [delete]
-func (h *PatientHandler) DeletePatient(c *gin.Context) {
+// @Failure 404 {object} models.ErrorResponse
+// @Router /v1/admin/deleted/patients/{id}/restore [post]
+func (h *PatientHandler) RestorePatient(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -320,28 +337,31 @@ func (h *PatientHandler) DeletePatient(c *gin.Context) {
 		return
 	}
 
-	// Check if patient exists
-	var exists bool
-	err = h.db.QueryRow("SELECT EXISTS(SELECT 1 FROM patient WHERE patient_id = $1)", id).Scan(&exists)
-	if err != nil || !exists {
+	result, err := h.patients.RestorePatient(id)
+	if errors.Is(err, utils.ErrNotFound) {
 		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "Patient not found",
-			Message: fmt.Sprintf("Patient with ID %d does not exist", id),
+			Error:   "Deleted patient not found",
+			Message: fmt.Sprintf("Patient with ID %d is not deleted", id),
 		})
 		return
 	}
-
-	// Delete patient (will cascade to assessments due to FK constraints)
-	_, err = h.db.Exec("DELETE FROM patient WHERE patient_id = $1", id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "Failed to delete patient",
+			Error:   "Failed to restore patient",
 			Message: err.Error(),
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, models.SuccessResponse{
-		Message: fmt.Sprintf("Patient with ID %d deleted successfully", id),
+		Message: fmt.Sprintf("Patient with ID %d restored successfully", id),
+		Data:    result,
+	})
+}
+
+func patientNotFound(c *gin.Context, id int) {
+	c.JSON(http.StatusNotFound, models.ErrorResponse{
+		Error:   "Patient not found",
+		Message: fmt.Sprintf("Patient with ID %d does not exist", id),
 	})
 }
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/middleware"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/service"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PortalHandler serves the patient self-service endpoints under /me.
+// Every request is scoped to the patient record linked to the caller's user account.
+type PortalHandler struct {
+	patients    *service.PatientService
+	assessments *service.AssessmentService
+	access      *service.AccessService
+}
+
+// NewPortalHandler creates a new patient portal handler
+func NewPortalHandler(patients *service.PatientService, assessments *service.AssessmentService, access *service.AccessService) *PortalHandler {
+	return &PortalHandler{patients: patients, assessments: assessments, access: access}
+}
+
+// GetMyPatient returns the caller's own demographics
+func (h *PortalHandler) GetMyPatient(c *gin.Context) {
+	patientID, ok := h.resolvePatientID(c)
+	if !ok {
+		return
+	}
+
+	patient, err := h.patients.GetPatient(patientID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to query patient",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, patient)
+}
+
+// GetMyWoundHistory returns the caller's wound history
+func (h *PortalHandler) GetMyWoundHistory(c *gin.Context) {
+	patientID, ok := h.resolvePatientID(c)
+	if !ok {
+		return
+	}
+
+	history, err := h.patients.GetWoundHistory(patientID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to retrieve wound history",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"patient_id": patientID,
+		"history":    history,
+	})
+}
+
+// GetMyFullAssessment returns one of the caller's assessments in full.
+// Assessments belonging to other patients are reported as not found.
+func (h *PortalHandler) GetMyFullAssessment(c *gin.Context) {
+	patientID, ok := h.resolvePatientID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid assessment ID",
+			Message: "Assessment ID must be a valid integer",
+		})
+		return
+	}
+
+	// Another patient's assessment is reported as missing so the portal does
+	// not reveal which assessment IDs exist
+	result, err := h.assessments.GetFullAssessment(id)
+	if errors.Is(err, utils.ErrNotFound) || (err == nil && result.PatientID != patientID) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Assessment not found",
+			Message: fmt.Sprintf("Assessment with ID %d does not exist", id),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to retrieve assessment",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// resolvePatientID looks up the patient record linked to the authenticated user.
+// It writes an error response and returns false if there is none.
+func (h *PortalHandler) resolvePatientID(c *gin.Context) (int, bool) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{Error: "User not authenticated"})
+		return 0, false
+	}
+
+	patientID, err := h.access.GetOwnPatientID(userID)
+	if errors.Is(err, utils.ErrNotFound) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Patient profile not found",
+			Message: "Your account is not linked to a patient record",
+		})
+		return 0, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to resolve patient profile",
+			Message: err.Error(),
+		})
+		return 0, false
+	}
+
+	return patientID, true
+}
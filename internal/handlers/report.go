@@ -1,24 +1,27 @@
 package handlers
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
 	"strconv"
 
-	"github.com/vellalasantosh/wound_iq_api_claude/internal/db"
 	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/service"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
 // ReportHandler handles report-related requests
 type ReportHandler struct {
-	db *db.DB
+	patients    *service.PatientService
+	assessments *service.AssessmentService
+	access      *service.AccessService
 }
 
 // NewReportHandler creates a new report handler
-func NewReportHandler(database *db.DB) *ReportHandler {
-	return &ReportHandler{db: database}
+func NewReportHandler(patients *service.PatientService, assessments *service.AssessmentService, access *service.AccessService) *ReportHandler {
+	return &ReportHandler{patients: patients, assessments: assessments, access: access}
 }
 
 // GetPatientWoundHistory retrieves wound history for a patient using get_patient_wound_history function
@@ -32,19 +35,15 @@ func (h *ReportHandler) GetPatientWoundHistory(c *gin.Context) {
 		return
 	}
 
-	// Verify patient exists
-	var exists bool
-	err = h.db.QueryRow("SELECT EXISTS(SELECT 1 FROM patient WHERE patient_id = $1)", id).Scan(&exists)
-	if err != nil || !exists {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "Patient not found",
-			Message: fmt.Sprintf("Patient with ID %d does not exist", id),
-		})
+	if !authorizePatientAccess(c, h.access, id) {
 		return
 	}
 
-	// Call get_patient_wound_history function
-	rows, err := h.db.Query("SELECT * FROM get_patient_wound_history($1)", id)
+	history, err := h.patients.GetWoundHistory(id)
+	if errors.Is(err, utils.ErrNotFound) {
+		patientNotFound(c, id)
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "Failed to retrieve wound history",
@@ -52,20 +51,6 @@ func (h *ReportHandler) GetPatientWoundHistory(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-
-	var history []models.WoundHistory
-	for rows.Next() {
-		var h models.WoundHistory
-		if err := rows.Scan(&h.AssessmentID, &h.AssessmentDate, &h.Location, &h.Stage, &h.HealingStatus); err != nil {
-			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-				Error:   "Failed to scan wound history",
-				Message: err.Error(),
-			})
-			return
-		}
-		history = append(history, h)
-	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"patient_id": id,
@@ -73,7 +58,7 @@ func (h *ReportHandler) GetPatientWoundHistory(c *gin.Context) {
 	})
 }
 
-// GetFullAssessment retrieves full assessment details using get_assessment_full function
+// GetFullAssessment returns an assessment with every recorded section
 func (h *ReportHandler) GetFullAssessment(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
@@ -84,32 +69,22 @@ func (h *ReportHandler) GetFullAssessment(c *gin.Context) {
 		return
 	}
 
-	// Call get_assessment_full function
-	var result models.FullAssessmentResponse
-	err = h.db.QueryRow("SELECT * FROM get_assessment_full($1)", id).Scan(
-		&result.AssessmentID,
-		&result.AssessmentDate,
-		&result.PatientID,
-		&result.PatientName,
-		&result.ClinicianID,
-		&result.ClinicianName,
-		&result.Location,
-		&result.Etiology,
-		&result.Stage,
-		&result.HealingStatus,
-		&result.PainScore,
-		&result.GranulationPercent,
-		&result.Length,
-		&result.Width,
-	)
-
+	result, err := h.assessments.GetFullAssessment(id)
+	if errors.Is(err, utils.ErrNotFound) {
+		assessmentNotFound(c, id)
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "Assessment not found",
-			Message: fmt.Sprintf("Assessment with ID %d does not exist or could not be retrieved", id),
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to retrieve assessment",
+			Message: err.Error(),
 		})
 		return
 	}
 
+	if !authorizePatientAccess(c, h.access, result.PatientID) {
+		return
+	}
+
 	c.JSON(http.StatusOK, result)
 }
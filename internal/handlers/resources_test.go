@@ -0,0 +1,709 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/testserver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clinicalFixture is a server with an admin and a clinician signed in
+type clinicalFixture struct {
+	*testserver.Server
+	adminToken     string
+	clinicianToken string
+	clinicianID    int
+}
+
+func newClinicalFixture(t *testing.T) *clinicalFixture {
+	srv := testserver.New(t)
+	srv.CreateUser("admin@example.com", "secret123", models.RoleAdmin)
+	doc := srv.CreateUser("doc@example.com", "secret123", models.RoleClinician)
+
+	clinicianID, err := srv.Store.Repositories().Access.GetClinicianIDByUserID(doc.ID)
+	require.NoError(t, err)
+
+	f := &clinicalFixture{
+		Server:         srv,
+		adminToken:     srv.Login("admin@example.com", "secret123"),
+		clinicianToken: srv.Login("doc@example.com", "secret123"),
+		clinicianID:    clinicianID,
+	}
+	return f
+}
+
+// createPatient registers a patient as the signed-in clinician, which also
+// puts the clinician on the patient's care team
+func (f *clinicalFixture) createPatient(t *testing.T, name, mrn string) models.Patient {
+	w := f.Do(http.MethodPost, "/api/v1/patients", models.CreatePatientRequest{
+		FullName:            name,
+		DateOfBirth:         "1990-01-15T00:00:00Z",
+		Gender:              "Female",
+		MedicalRecordNumber: mrn,
+	}, f.clinicianToken)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var p models.Patient
+	f.Decode(w, &p)
+	return p
+}
+
+func (f *clinicalFixture) createFullAssessment(t *testing.T, patientID int) int {
+	w := f.Do(http.MethodPost, "/api/v1/assessments/full", fullAssessment(f.clinicianID, patientID), f.clinicianToken)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var resp struct {
+		Data struct {
+			AssessmentID int `json:"assessment_id"`
+		} `json:"data"`
+	}
+	f.Decode(w, &resp)
+	return resp.Data.AssessmentID
+}
+
+func fullAssessment(clinicianID, patientID int) models.FullAssessmentRequest {
+	return models.FullAssessmentRequest{
+		CreateAssessmentRequest: models.CreateAssessmentRequest{
+			ClinicianID:    clinicianID,
+			PatientID:      patientID,
+			Location:       "Left Foot",
+			Etiology:       "Diabetic Ulcer",
+			DepthOfInjury:  "Partial Thickness",
+			Stage:          "Stage II",
+			Chronicity:     "Chronic",
+			HealingStatus:  "Improving",
+			ReturnToClinic: true,
+		},
+		InfectionPain: models.InfectionPainRequest{
+			LocalizedSymptoms: "Redness",
+			SystemicSymptoms:  "None",
+			PainPresent:       "Yes",
+			PainScore:         "4",
+			CultureResults:    "Negative",
+			Antibiotic:        "None",
+		},
+		TissueStatus: models.TissueStatusRequest{
+			GranulationPercent: 50,
+			EpithelialPercent:  20,
+			SloughPercent:      20,
+			EscharPercent:      5,
+			NecroticPercent:    5,
+			Debridement:        "Sharp",
+		},
+		Vitals: models.VitalsRequest{
+			BloodPressure:    "120/80",
+			Temperature:      37.0,
+			Pulse:            72,
+			RespirationRate:  16,
+			OxygenSaturation: 98,
+		},
+		WoundCondition: models.WoundConditionRequest{
+			Length:        2.5,
+			Width:         2.0,
+			Depth:         0.5,
+			Edges:         "Attached",
+			SkinCondition: "Dry",
+			Edema:         "Mild",
+			Blister:       "No",
+		},
+		Exudate: models.ExudateRequest{
+			ExudateType:   "Serous",
+			ExudateAmount: "Low",
+			Odor:          "None",
+		},
+		Treatment: models.TreatmentRequest{
+			PrimaryDressing:   "Foam",
+			SecondaryDressing: "Gauze",
+			TertiaryDressing:  "Bandage",
+			Frequency:         "Daily",
+			Supplies:          "Standard",
+			Orders:            "Monitor",
+		},
+	}
+}
+
+func TestPatients_CRUD(t *testing.T) {
+	f := newClinicalFixture(t)
+	p := f.createPatient(t, "Jane Doe", "MRN12345")
+	path := fmt.Sprintf("/api/v1/patients/%d", p.PatientID)
+
+	t.Run("missing required field", func(t *testing.T) {
+		w := f.Do(http.MethodPost, "/api/v1/patients", map[string]interface{}{"full_name": "Jane Doe"}, f.clinicianToken)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid date format", func(t *testing.T) {
+		w := f.Do(http.MethodPost, "/api/v1/patients", models.CreatePatientRequest{
+			FullName:            "John Doe",
+			DateOfBirth:         "01/15/1990",
+			Gender:              "Male",
+			MedicalRecordNumber: "MRN54321",
+		}, f.clinicianToken)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		w := f.Do(http.MethodGet, path, nil, f.clinicianToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var got models.Patient
+		f.Decode(w, &got)
+		assert.Equal(t, "MRN12345", got.MedicalRecordNumber)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		w := f.Do(http.MethodGet, "/api/v1/patients?page=1&page_size=10", nil, f.adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var page models.PaginatedResponse
+		f.Decode(w, &page)
+		assert.Equal(t, 1, page.TotalCount)
+		assert.Equal(t, 1, page.Page)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		w := f.Do(http.MethodPut, path, models.UpdatePatientRequest{FullName: "Jane Smith"}, f.clinicianToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var got models.Patient
+		f.Decode(w, &got)
+		assert.Equal(t, "Jane Smith", got.FullName)
+		assert.Equal(t, "MRN12345", got.MedicalRecordNumber)
+	})
+
+	t.Run("clinicians cannot delete", func(t *testing.T) {
+		w := f.Do(http.MethodDelete, path, nil, f.clinicianToken)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		w := f.Do(http.MethodDelete, path, nil, f.adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = f.Do(http.MethodGet, path, nil, f.adminToken)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestPatients_CareTeamScoping(t *testing.T) {
+	f := newClinicalFixture(t)
+	own := f.createPatient(t, "Own Patient", "MRN-OWN")
+
+	w := f.Do(http.MethodGet, fmt.Sprintf("/api/v1/patients/%d/care-team", own.PatientID), nil, f.adminToken)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var team struct {
+		CareTeam []struct {
+			ClinicianID int  `json:"clinician_id"`
+			Active      bool `json:"active"`
+		} `json:"care_team"`
+	}
+	f.Decode(w, &team)
+	require.Len(t, team.CareTeam, 1, "the creating clinician is assigned with the patient")
+	assert.Equal(t, f.clinicianID, team.CareTeam[0].ClinicianID)
+	assert.True(t, team.CareTeam[0].Active)
+
+	// A patient created by an admin has no care team
+	w = f.Do(http.MethodPost, "/api/v1/patients", models.CreatePatientRequest{
+		FullName:            "Other Patient",
+		DateOfBirth:         "1980-05-01T00:00:00Z",
+		Gender:              "Male",
+		MedicalRecordNumber: "MRN-OTHER",
+	}, f.adminToken)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var other models.Patient
+	f.Decode(w, &other)
+
+	w = f.Do(http.MethodGet, "/api/v1/patients", nil, f.clinicianToken)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var page models.PaginatedResponse
+	f.Decode(w, &page)
+	assert.Equal(t, 1, page.TotalCount, "clinician sees only their care team")
+
+	w = f.Do(http.MethodGet, fmt.Sprintf("/api/v1/patients/%d", other.PatientID), nil, f.clinicianToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// An assignment that starts in the future does not grant access yet
+	careTeamPath := fmt.Sprintf("/api/v1/patients/%d/care-team", other.PatientID)
+	nextWeek := time.Now().AddDate(0, 0, 7).Format(time.RFC3339)
+	w = f.Do(http.MethodPost, careTeamPath,
+		models.AssignCareTeamRequest{ClinicianID: f.clinicianID, StartDate: nextWeek}, f.adminToken)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = f.Do(http.MethodGet, fmt.Sprintf("/api/v1/patients/%d", other.PatientID), nil, f.clinicianToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// Assignments for the same clinician may not overlap
+	w = f.Do(http.MethodPost, careTeamPath,
+		models.AssignCareTeamRequest{ClinicianID: f.clinicianID}, f.adminToken)
+	assert.Equal(t, http.StatusConflict, w.Code, "open-ended from today overlaps next week")
+
+	w = f.Do(http.MethodPost, careTeamPath,
+		models.AssignCareTeamRequest{ClinicianID: f.clinicianID, EndDate: nextWeek}, f.adminToken)
+	require.Equal(t, http.StatusCreated, w.Code, "ends as the future assignment starts: %s", w.Body.String())
+
+	w = f.Do(http.MethodGet, fmt.Sprintf("/api/v1/patients/%d", other.PatientID), nil, f.clinicianToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestClinicians_CRUD(t *testing.T) {
+	f := newClinicalFixture(t)
+
+	req := models.CreateClinicianRequest{
+		FullName:      "Dr. Grey",
+		Role:          "Surgeon",
+		Department:    "Surgery",
+		ContactInfo:   "grey@example.com",
+		LicenseNumber: "LIC-900",
+	}
+
+	w := f.Do(http.MethodPost, "/api/v1/clinicians", req, f.clinicianToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = f.Do(http.MethodPost, "/api/v1/clinicians", req, f.adminToken)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created models.Clinician
+	f.Decode(w, &created)
+	path := fmt.Sprintf("/api/v1/clinicians/%d", created.ClinicianID)
+
+	w = f.Do(http.MethodPut, path, models.UpdateClinicianRequest{Department: "Wound Care"}, f.adminToken)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var updated models.Clinician
+	f.Decode(w, &updated)
+	assert.Equal(t, "Wound Care", updated.Department)
+	assert.Equal(t, "Surgeon", updated.Role)
+
+	w = f.Do(http.MethodDelete, path, nil, f.adminToken)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = f.Do(http.MethodGet, path, nil, f.adminToken)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAssessments_CRUDAndReports(t *testing.T) {
+	f := newClinicalFixture(t)
+	p := f.createPatient(t, "Jane Doe", "MRN12345")
+	id := f.createFullAssessment(t, p.PatientID)
+	path := fmt.Sprintf("/api/v1/assessments/%d", id)
+
+	t.Run("list", func(t *testing.T) {
+		w := f.Do(http.MethodGet, fmt.Sprintf("/api/v1/assessments?patient_id=%d", p.PatientID), nil, f.clinicianToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var page struct {
+			Data       []models.AssessmentListItem `json:"data"`
+			TotalCount int                         `json:"total_count"`
+		}
+		f.Decode(w, &page)
+		require.Equal(t, 1, page.TotalCount)
+		assert.Equal(t, "Jane Doe", page.Data[0].PatientName)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		w := f.Do(http.MethodPut, path, models.UpdateAssessmentRequest{HealingStatus: "Healed"}, f.clinicianToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var got models.Assessment
+		f.Decode(w, &got)
+		assert.Equal(t, "Healed", got.HealingStatus)
+		assert.Equal(t, "Left Foot", got.Location)
+	})
+
+	t.Run("full report", func(t *testing.T) {
+		w := f.Do(http.MethodGet, path+"/full", nil, f.clinicianToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var full models.FullAssessmentResponse
+		f.Decode(w, &full)
+		assert.Equal(t, "Jane Doe", full.PatientName)
+		assert.Equal(t, "Partial Thickness", full.DepthOfInjury)
+		assert.True(t, full.ReturnToClinic)
+
+		// Every section round-trips exactly as it was saved
+		saved := fullAssessment(f.clinicianID, p.PatientID)
+		require.NotNil(t, full.InfectionPain)
+		assert.Equal(t, models.InfectionPain(saved.InfectionPain), *full.InfectionPain)
+		require.NotNil(t, full.TissueStatus)
+		assert.Equal(t, models.TissueStatus(saved.TissueStatus), *full.TissueStatus)
+		require.NotNil(t, full.Vitals)
+		assert.Equal(t, models.Vitals(saved.Vitals), *full.Vitals)
+		require.NotNil(t, full.WoundCondition)
+		assert.Equal(t, models.WoundCondition(saved.WoundCondition), *full.WoundCondition)
+		require.NotNil(t, full.Exudate)
+		assert.Equal(t, models.Exudate(saved.Exudate), *full.Exudate)
+		require.NotNil(t, full.Treatment)
+		assert.Equal(t, models.Treatment(saved.Treatment), *full.Treatment)
+	})
+
+	t.Run("wound history", func(t *testing.T) {
+		w := f.Do(http.MethodGet, fmt.Sprintf("/api/v1/patients/%d/history", p.PatientID), nil, f.clinicianToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp struct {
+			History []models.WoundHistory `json:"history"`
+		}
+		f.Decode(w, &resp)
+		require.Len(t, resp.History, 1)
+		assert.Equal(t, id, resp.History[0].AssessmentID)
+	})
+
+	t.Run("full report of header-only assessment", func(t *testing.T) {
+		w := f.Do(http.MethodPost, "/api/v1/assessments", fullAssessment(f.clinicianID, p.PatientID).CreateAssessmentRequest, f.clinicianToken)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var created models.Assessment
+		f.Decode(w, &created)
+
+		w = f.Do(http.MethodGet, fmt.Sprintf("/api/v1/assessments/%d/full", created.AssessmentID), nil, f.clinicianToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var full map[string]any
+		f.Decode(w, &full)
+		for _, section := range []string{"infection_pain", "tissue_status", "vitals", "wound_condition", "exudate", "treatment"} {
+			assert.Contains(t, full, section)
+			assert.Nil(t, full[section], section)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		w := f.Do(http.MethodDelete, path, nil, f.adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = f.Do(http.MethodGet, path+"/full", nil, f.adminToken)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestAssessments_ChartedAsCaller(t *testing.T) {
+	f := newClinicalFixture(t)
+	p := f.createPatient(t, "Jane Doe", "MRN-A")
+
+	w := f.Do(http.MethodPost, "/api/v1/clinicians", models.CreateClinicianRequest{
+		FullName: "Other Doc", Role: "Nurse", Department: "Wound Care",
+		ContactInfo: "555-0100", LicenseNumber: "LIC-2",
+	}, f.adminToken)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var other models.Clinician
+	f.Decode(w, &other)
+
+	// Naming another clinician in the body does not chart under their name
+	w = f.Do(http.MethodPost, "/api/v1/assessments/full", fullAssessment(other.ClinicianID, p.PatientID), f.clinicianToken)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		Data struct {
+			AssessmentID int `json:"assessment_id"`
+		} `json:"data"`
+	}
+	f.Decode(w, &created)
+
+	w = f.Do(http.MethodGet, fmt.Sprintf("/api/v1/assessments/%d", created.Data.AssessmentID), nil, f.adminToken)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var a models.Assessment
+	f.Decode(w, &a)
+	assert.Equal(t, f.clinicianID, a.ClinicianID)
+}
+
+func TestAssessments_EditSections(t *testing.T) {
+	f := newClinicalFixture(t)
+	p := f.createPatient(t, "Jane Doe", "MRN12345")
+	id := f.createFullAssessment(t, p.PatientID)
+	path := fmt.Sprintf("/api/v1/assessments/%d", id)
+
+	t.Run("patch keeps omitted fields", func(t *testing.T) {
+		w := f.Do(http.MethodPatch, path+"/vitals", map[string]any{"pulse": 90}, f.clinicianToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var full models.FullAssessmentResponse
+		f.Decode(w, &full)
+		require.NotNil(t, full.Vitals)
+		assert.Equal(t, 90, full.Vitals.Pulse)
+		assert.Equal(t, "120/80", full.Vitals.BloodPressure)
+		assert.Equal(t, "Foam", full.Treatment.PrimaryDressing)
+	})
+
+	t.Run("patch is validated like create", func(t *testing.T) {
+		w := f.Do(http.MethodPatch, path+"/vitals", map[string]any{"pulse": 500}, f.clinicianToken)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = f.Do(http.MethodPatch, path+"/tissue-status", map[string]any{"slough_percent": 101}, f.clinicianToken)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("put requires the whole section", func(t *testing.T) {
+		w := f.Do(http.MethodPut, path+"/exudate", map[string]any{"odor": "Foul"}, f.clinicianToken)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = f.Do(http.MethodPut, path+"/wound-condition", models.WoundConditionRequest{
+			Length: 1.5, Width: 1.0, Depth: 0.2, Tunneling: true,
+			Edges: "Rolled", SkinCondition: "Moist", Edema: "None", Blister: "No",
+		}, f.clinicianToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var full models.FullAssessmentResponse
+		f.Decode(w, &full)
+		assert.Equal(t, 1.5, full.WoundCondition.Length)
+		assert.True(t, full.WoundCondition.Tunneling)
+		assert.False(t, full.WoundCondition.Undermining)
+	})
+
+	t.Run("section recorded after create", func(t *testing.T) {
+		w := f.Do(http.MethodPost, "/api/v1/assessments", fullAssessment(f.clinicianID, p.PatientID).CreateAssessmentRequest, f.clinicianToken)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var created models.Assessment
+		f.Decode(w, &created)
+		headerPath := fmt.Sprintf("/api/v1/assessments/%d", created.AssessmentID)
+
+		// Nothing to merge with, so a partial patch fails validation
+		w = f.Do(http.MethodPatch, headerPath+"/infection-pain", map[string]any{"pain_score": "2"}, f.clinicianToken)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = f.Do(http.MethodPut, headerPath+"/infection-pain", fullAssessment(f.clinicianID, p.PatientID).InfectionPain, f.clinicianToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var full models.FullAssessmentResponse
+		f.Decode(w, &full)
+		require.NotNil(t, full.InfectionPain)
+		assert.Equal(t, "4", full.InfectionPain.PainScore)
+		assert.Nil(t, full.Vitals)
+	})
+
+	t.Run("replace full", func(t *testing.T) {
+		req := fullAssessment(f.clinicianID, p.PatientID)
+		req.HealingStatus = "Healed"
+		req.Treatment.Orders = "Discharge"
+		req.Vitals.Pulse = 64
+
+		w := f.Do(http.MethodPut, path+"/full", req, f.clinicianToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = f.Do(http.MethodGet, path+"/full", nil, f.clinicianToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var full models.FullAssessmentResponse
+		f.Decode(w, &full)
+		assert.Equal(t, "Healed", full.HealingStatus)
+		assert.Equal(t, "Discharge", full.Treatment.Orders)
+		assert.Equal(t, 64, full.Vitals.Pulse)
+		assert.Equal(t, models.WoundCondition(req.WoundCondition), *full.WoundCondition)
+	})
+
+	t.Run("replace full cannot move patient", func(t *testing.T) {
+		other := f.createPatient(t, "John Roe", "MRN67890")
+		w := f.Do(http.MethodPut, path+"/full", fullAssessment(f.clinicianID, other.PatientID), f.clinicianToken)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("outside care team", func(t *testing.T) {
+		f.CreateUser("other@example.com", "secret123", models.RoleClinician)
+		otherToken := f.Login("other@example.com", "secret123")
+
+		w := f.Do(http.MethodPatch, path+"/vitals", map[string]any{"pulse": 80}, otherToken)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("unknown assessment", func(t *testing.T) {
+		w := f.Do(http.MethodPatch, "/api/v1/assessments/9999/exudate", map[string]any{"odor": "None"}, f.clinicianToken)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+// deleteResponse is the body of a successful DELETE
+type deleteResponse struct {
+	Data models.DeleteResult `json:"data"`
+}
+
+func TestDeletes_RestrictAndCascade(t *testing.T) {
+	f := newClinicalFixture(t)
+
+	t.Run("assessment keeps its sections", func(t *testing.T) {
+		p := f.createPatient(t, "Jane Doe", "MRN-A")
+		id := f.createFullAssessment(t, p.PatientID)
+
+		w := f.Do(http.MethodDelete, fmt.Sprintf("/api/v1/assessments/%d", id), nil, f.adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp deleteResponse
+		f.Decode(w, &resp)
+		assert.Equal(t, []int{id}, resp.Data.AssessmentIDs)
+		assert.Equal(t, map[string]int{"assessment": 1}, resp.Data.Deleted)
+	})
+
+	t.Run("patient", func(t *testing.T) {
+		p := f.createPatient(t, "John Roe", "MRN-B")
+		id := f.createFullAssessment(t, p.PatientID)
+		path := fmt.Sprintf("/api/v1/patients/%d", p.PatientID)
+
+		w := f.Do(http.MethodDelete, path+"?mode=purge", nil, f.adminToken)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		// Restrict is the default and leaves everything in place
+		w = f.Do(http.MethodDelete, path, nil, f.adminToken)
+		require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "1 assessment")
+		w = f.Do(http.MethodGet, fmt.Sprintf("/api/v1/assessments/%d", id), nil, f.adminToken)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = f.Do(http.MethodDelete, path+"?mode=cascade", nil, f.adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp deleteResponse
+		f.Decode(w, &resp)
+		assert.Equal(t, models.DeleteCascade, resp.Data.Mode)
+		assert.Equal(t, []int{id}, resp.Data.AssessmentIDs)
+		assert.Equal(t, map[string]int{"patient": 1, "assessment": 1}, resp.Data.Deleted)
+
+		w = f.Do(http.MethodGet, fmt.Sprintf("/api/v1/assessments/%d", id), nil, f.adminToken)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("clinician with assessments", func(t *testing.T) {
+		p := f.createPatient(t, "Ann Poe", "MRN-C")
+		id := f.createFullAssessment(t, p.PatientID)
+		path := fmt.Sprintf("/api/v1/clinicians/%d", f.clinicianID)
+
+		// Assessments are never removed, so neither mode can delete their author
+		for _, mode := range []string{"restrict", "cascade"} {
+			w := f.Do(http.MethodDelete, path+"?mode="+mode, nil, f.adminToken)
+			require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), "3 assessment")
+		}
+
+		w := f.Do(http.MethodGet, fmt.Sprintf("/api/v1/assessments/%d", id), nil, f.adminToken)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestSoftDeletes_ListAndRestore(t *testing.T) {
+	f := newClinicalFixture(t)
+	p := f.createPatient(t, "Jane Doe", "MRN-A")
+	kept := f.createFullAssessment(t, p.PatientID)
+	removed := f.createFullAssessment(t, p.PatientID)
+	patientPath := fmt.Sprintf("/api/v1/patients/%d", p.PatientID)
+
+	t.Run("deleted assessment is hidden", func(t *testing.T) {
+		w := f.Do(http.MethodDelete, fmt.Sprintf("/api/v1/assessments/%d?reason=charted+in+error", removed), nil, f.adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = f.Do(http.MethodGet, fmt.Sprintf("/api/v1/assessments/%d/full", removed), nil, f.adminToken)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		var resp struct {
+			History []models.WoundHistory `json:"history"`
+		}
+		w = f.Do(http.MethodGet, patientPath+"/history", nil, f.adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		f.Decode(w, &resp)
+		require.Len(t, resp.History, 1)
+		assert.Equal(t, kept, resp.History[0].AssessmentID)
+	})
+
+	t.Run("deleted patient is hidden", func(t *testing.T) {
+		w := f.Do(http.MethodDelete, patientPath+"?mode=cascade&reason=duplicate", nil, f.adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp deleteResponse
+		f.Decode(w, &resp)
+		assert.Equal(t, []int{kept}, resp.Data.AssessmentIDs)
+
+		w = f.Do(http.MethodGet, patientPath, nil, f.adminToken)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w = f.Do(http.MethodGet, "/api/v1/patients", nil, f.adminToken)
+		assert.NotContains(t, w.Body.String(), "MRN-A")
+		w = f.Do(http.MethodGet, "/api/v1/assessments", nil, f.adminToken)
+		assert.Contains(t, w.Body.String(), `"total_count":0`)
+	})
+
+	t.Run("admin lists deleted records", func(t *testing.T) {
+		w := f.Do(http.MethodGet, "/api/v1/admin/deleted/patients", nil, f.clinicianToken)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		var patients struct {
+			Data []models.DeletedPatient `json:"data"`
+		}
+		w = f.Do(http.MethodGet, "/api/v1/admin/deleted/patients", nil, f.adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		f.Decode(w, &patients)
+		require.Len(t, patients.Data, 1)
+		assert.Equal(t, "duplicate", patients.Data[0].DeleteReason)
+		assert.NotNil(t, patients.Data[0].DeletedBy)
+
+		var assessments struct {
+			Data []models.DeletedAssessment `json:"data"`
+		}
+		w = f.Do(http.MethodGet, "/api/v1/admin/deleted/assessments", nil, f.adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		f.Decode(w, &assessments)
+		require.Len(t, assessments.Data, 2)
+		reasons := map[int]string{}
+		for _, a := range assessments.Data {
+			reasons[a.AssessmentID] = a.DeleteReason
+		}
+		assert.Equal(t, map[int]string{kept: "duplicate", removed: "charted in error"}, reasons)
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		restore := func(kind string, id int) *httptest.ResponseRecorder {
+			return f.Do(http.MethodPost, fmt.Sprintf("/api/v1/admin/deleted/%s/%d/restore", kind, id), nil, f.adminToken)
+		}
+
+		w := restore("assessments", removed)
+		assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+
+		// Only the assessment deleted with the patient comes back with it
+		w = restore("patients", p.PatientID)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp struct {
+			Data models.RestoreResult `json:"data"`
+		}
+		f.Decode(w, &resp)
+		assert.Equal(t, []int{kept}, resp.Data.AssessmentIDs)
+		assert.Equal(t, map[string]int{"patient": 1, "assessment": 1}, resp.Data.Restored)
+
+		w = f.Do(http.MethodGet, fmt.Sprintf("/api/v1/assessments/%d", kept), nil, f.adminToken)
+		assert.Equal(t, http.StatusOK, w.Code)
+		w = f.Do(http.MethodGet, fmt.Sprintf("/api/v1/assessments/%d", removed), nil, f.adminToken)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = restore("assessments", removed)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		w = f.Do(http.MethodGet, fmt.Sprintf("/api/v1/assessments/%d/full", removed), nil, f.adminToken)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// Restoring a live record is a 404
+		w = restore("patients", p.PatientID)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestPortal_PatientSeesOwnRecords(t *testing.T) {
+	f := newClinicalFixture(t)
+	f.CreateUser("pat@example.com", "secret123", models.RolePatient)
+	patientToken := f.Login("pat@example.com", "secret123")
+
+	w := f.Do(http.MethodGet, "/api/v1/me", nil, patientToken)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var me models.Patient
+	f.Decode(w, &me)
+
+	w = f.Do(http.MethodPost, fmt.Sprintf("/api/v1/patients/%d/care-team", me.PatientID),
+		models.AssignCareTeamRequest{ClinicianID: f.clinicianID}, f.adminToken)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	id := f.createFullAssessment(t, me.PatientID)
+
+	w = f.Do(http.MethodGet, fmt.Sprintf("/api/v1/me/assessments/%d", id), nil, patientToken)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	other := f.createPatient(t, "Someone Else", "MRN-ELSE")
+	w = f.Do(http.MethodGet, fmt.Sprintf("/api/v1/patients/%d", other.PatientID), nil, patientToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	otherAssessment := f.createFullAssessment(t, other.PatientID)
+	w = f.Do(http.MethodGet, fmt.Sprintf("/api/v1/me/assessments/%d", otherAssessment), nil, patientToken)
+	assert.Equal(t, http.StatusNotFound, w.Code, "another patient's assessment looks missing")
+	w = f.Do(http.MethodGet, "/api/v1/me/assessments/999999", nil, patientToken)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,156 @@
+package handlers_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/oidc"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/service"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/testserver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubIdP is a minimal OIDC provider; every code redeems an ID token with
+// the claims of the next sign-in
+type stubIdP struct {
+	*httptest.Server
+	claims jwt.MapClaims
+}
+
+func newStubIdP(t *testing.T) *stubIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp := &stubIdP{}
+	mux := http.NewServeMux()
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Close)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.URL,
+			"authorization_endpoint": idp.URL + "/authorize",
+			"token_endpoint":         idp.URL + "/token",
+			"jwks_uri":               idp.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "stub",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, idp.claims)
+		token.Header["kid"] = "stub"
+		signed, _ := token.SignedString(key)
+		json.NewEncoder(w).Encode(map[string]string{"id_token": signed, "token_type": "Bearer"})
+	})
+
+	return idp
+}
+
+func (idp *stubIdP) provider() *oidc.Provider {
+	return oidc.NewProvider(oidc.Config{
+		IssuerURL:    idp.URL,
+		ClientID:     "woundiq",
+		ClientSecret: "s3cret",
+		RedirectURL:  "http://localhost:8080/api/v1/auth/sso/callback",
+	}, idp.Client())
+}
+
+// signIn runs the browser side of an SSO login for the IdP user subject
+func (idp *stubIdP) signIn(t *testing.T, srv *testserver.Server, subject, email string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	srv.Router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/auth/sso/login", nil))
+	require.Equal(t, http.StatusFound, w.Code, w.Body.String())
+	authURL, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	cookies := w.Result().Cookies()
+	require.NotEmpty(t, cookies)
+
+	idp.claims = jwt.MapClaims{
+		"iss":            idp.URL,
+		"aud":            "woundiq",
+		"sub":            subject,
+		"exp":            time.Now().Add(time.Minute).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          authURL.Query().Get("nonce"),
+		"email":          email,
+		"email_verified": true,
+		"name":           "Nora Nurse",
+	}
+
+	callback := "/api/v1/auth/sso/callback?" + url.Values{
+		"code":  {"code"},
+		"state": {authURL.Query().Get("state")},
+	}.Encode()
+	req := httptest.NewRequest(http.MethodGet, callback, nil)
+	req.AddCookie(cookies[0])
+	w = httptest.NewRecorder()
+	srv.Router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSSO_LinksOnlyVerifiedAccounts(t *testing.T) {
+	idp := newStubIdP(t)
+	srv := testserver.NewWithConfig(t, service.AuthConfig{
+		SSO:                    idp.provider(),
+		SSOProvisionClinicians: true,
+	})
+
+	t.Run("unverified local account is not claimed", func(t *testing.T) {
+		w := srv.Do(http.MethodPost, "/api/v1/auth/register", models.RegisterRequest{
+			Email: "nurse@hospital.org", Password: "Squatter#2024", FirstName: "Not", LastName: "Staff",
+		}, "")
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		w = idp.signIn(t, srv, "idp-nurse", "nurse@hospital.org")
+		assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+
+		_, err := srv.Store.Repositories().Auth.GetUserByIdentity(idp.URL, "idp-nurse")
+		assert.Error(t, err, "identity must stay unlinked")
+	})
+
+	t.Run("verified local account is linked", func(t *testing.T) {
+		user := srv.CreateUser("doc@hospital.org", "secret123", models.RoleClinician)
+		require.NoError(t, srv.Store.Repositories().Auth.MarkEmailVerified(user.ID))
+
+		w := idp.signIn(t, srv, "idp-doc", "doc@hospital.org")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp models.LoginResponse
+		srv.Decode(w, &resp)
+		assert.Equal(t, user.ID, resp.User.ID)
+
+		linked, err := srv.Store.Repositories().Auth.GetUserByIdentity(idp.URL, "idp-doc")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, linked.ID)
+	})
+
+	t.Run("unknown address is provisioned", func(t *testing.T) {
+		w := idp.signIn(t, srv, "idp-new", "new@hospital.org")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp models.LoginResponse
+		srv.Decode(w, &resp)
+		assert.Equal(t, models.RoleClinician, resp.User.Role)
+	})
+}
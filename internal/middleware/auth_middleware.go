@@ -1,17 +1,51 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
 	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
-// AuthMiddleware validates JWT token and adds user info to context
+// tokenDenylist is consulted for every validated token; nil disables the check
+var tokenDenylist utils.TokenDenylist
+
+// SetTokenDenylist sets the store used to reject revoked access tokens
+func SetTokenDenylist(denylist utils.TokenDenylist) {
+	tokenDenylist = denylist
+}
+
+// APIKeyHeader carries a machine API key instead of a bearer token
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyVerifier resolves a presented API key. Unknown, expired and revoked
+// keys return utils.ErrInvalidAPIKey.
+type APIKeyVerifier interface {
+	VerifyAPIKey(rawKey string) (*models.APIKey, error)
+}
+
+// apiKeyVerifier checks X-API-Key headers; nil rejects every API key
+var apiKeyVerifier APIKeyVerifier
+
+// SetAPIKeyVerifier sets the store used to authenticate API keys
+func SetAPIKeyVerifier(verifier APIKeyVerifier) {
+	apiKeyVerifier = verifier
+}
+
+// AuthMiddleware validates JWT token and adds user info to context.
+// Requests with an X-API-Key header are authenticated as that key instead.
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if rawKey := c.GetHeader(APIKeyHeader); rawKey != "" {
+			authenticateAPIKey(c, rawKey)
+			return
+		}
+
 		// Get token from Authorization header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -38,15 +72,69 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		// Reject tokens revoked by logout, password change or deactivation
+		if tokenDenylist != nil {
+			revoked, err := tokenDenylist.IsRevoked(claims)
+			if err != nil {
+				c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Unable to verify token"})
+				c.Abort()
+				return
+			}
+			if revoked {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+				c.Abort()
+				return
+			}
+		}
+
 		// Add user info to context
 		c.Set("user_id", claims.UserID)
 		c.Set("user_email", claims.Email)
 		c.Set("user_role", claims.Role)
+		if claims.BreakGlassID != 0 {
+			c.Set("break_glass_id", claims.BreakGlassID)
+		}
+		if claims.MFAPending {
+			c.Set("mfa_pending", true)
+		}
+		c.Set("token_id", claims.ID)
+		if claims.ExpiresAt != nil {
+			c.Set("token_expires_at", claims.ExpiresAt.Time)
+		}
 
 		c.Next()
 	}
 }
 
+// authenticateAPIKey adds the key's identity to context. The role is always
+// models.RoleService; RequirePermission checks the key's own permissions.
+func authenticateAPIKey(c *gin.Context, rawKey string) {
+	if apiKeyVerifier == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "API keys are not accepted"})
+		c.Abort()
+		return
+	}
+
+	key, err := apiKeyVerifier.VerifyAPIKey(rawKey)
+	if errors.Is(err, utils.ErrInvalidAPIKey) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid, expired or revoked API key"})
+		c.Abort()
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Unable to verify API key"})
+		c.Abort()
+		return
+	}
+
+	c.Set("api_key_id", key.ID)
+	c.Set("api_key_name", key.Name)
+	c.Set("api_key_permissions", key.Permissions)
+	c.Set("user_role", models.RoleService)
+
+	c.Next()
+}
+
 // RoleMiddleware checks if user has required role
 func RoleMiddleware(allowedRoles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -93,3 +181,60 @@ func GetUserRole(c *gin.Context) (string, bool) {
 	}
 	return role.(string), true
 }
+
+// GetBreakGlassID extracts the break-glass event ID from context.
+// It is only present when the request uses an emergency-access token.
+func GetBreakGlassID(c *gin.Context) (int, bool) {
+	eventID, exists := c.Get("break_glass_id")
+	if !exists {
+		return 0, false
+	}
+	return eventID.(int), true
+}
+
+// GetUserEmail extracts user email from context
+func GetUserEmail(c *gin.Context) (string, bool) {
+	email, exists := c.Get("user_email")
+	if !exists {
+		return "", false
+	}
+	return email.(string), true
+}
+
+// GetTokenID extracts the access token's jti and expiry from context
+func GetTokenID(c *gin.Context) (string, time.Time, bool) {
+	jti := c.GetString("token_id")
+	if jti == "" {
+		return "", time.Time{}, false
+	}
+	return jti, c.GetTime("token_expires_at"), true
+}
+
+// GetAPIKeyID extracts the API key ID from context.
+// It is only present when the request was authenticated with an API key;
+// GetUserID is then unset.
+func GetAPIKeyID(c *gin.Context) (int, bool) {
+	keyID, exists := c.Get("api_key_id")
+	if !exists {
+		return 0, false
+	}
+	return keyID.(int), true
+}
+
+// GetAPIKeyName extracts the API key's name from context
+func GetAPIKeyName(c *gin.Context) (string, bool) {
+	name, exists := c.Get("api_key_name")
+	if !exists {
+		return "", false
+	}
+	return name.(string), true
+}
+
+// GetAPIKeyPermissions extracts the permissions granted to the API key
+func GetAPIKeyPermissions(c *gin.Context) ([]string, bool) {
+	permissions, exists := c.Get("api_key_permissions")
+	if !exists {
+		return nil, false
+	}
+	return permissions.([]string), true
+}
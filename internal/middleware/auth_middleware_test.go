@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubAPIKeyVerifier map[string]*models.APIKey
+
+func (s stubAPIKeyVerifier) VerifyAPIKey(rawKey string) (*models.APIKey, error) {
+	if key, ok := s[rawKey]; ok {
+		return key, nil
+	}
+	return nil, utils.ErrInvalidAPIKey
+}
+
+func setupAPIKeyRouter(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	SetAPIKeyVerifier(stubAPIKeyVerifier{
+		"wiq_reader": {ID: 7, Name: "interface-engine", Permissions: []string{string(PermPatientsRead)}},
+		"wiq_admin":  {ID: 8, Name: "bad-grant", Permissions: []string{string(PermUsersManage)}},
+		"wiq_purger": {ID: 9, Name: "old-grant", Permissions: []string{string(PermPatientsDelete)}},
+	})
+	t.Cleanup(func() { SetAPIKeyVerifier(nil) })
+
+	r := gin.New()
+	r.Use(AuthMiddleware())
+	identity := func(c *gin.Context) {
+		keyID, _ := GetAPIKeyID(c)
+		_, hasUser := GetUserID(c)
+		role, _ := GetUserRole(c)
+		c.JSON(http.StatusOK, gin.H{"key_id": keyID, "has_user": hasUser, "role": role})
+	}
+	r.GET("/patients/1", RequirePermission(PermPatientsRead), identity)
+	r.DELETE("/patients/1", RequirePermission(PermPatientsDelete), identity)
+	r.GET("/admin", RequirePermission(PermUsersManage), identity)
+	r.GET("/admin-role", RoleMiddleware(models.RoleAdmin), identity)
+	return r
+}
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	r := setupAPIKeyRouter(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		key    string
+		status int
+	}{
+		{"granted permission", http.MethodGet, "/patients/1", "wiq_reader", http.StatusOK},
+		{"permission not granted", http.MethodDelete, "/patients/1", "wiq_reader", http.StatusForbidden},
+		{"human-only permission", http.MethodGet, "/admin", "wiq_admin", http.StatusForbidden},
+		{"deletes are human-only", http.MethodDelete, "/patients/1", "wiq_purger", http.StatusForbidden},
+		{"role check", http.MethodGet, "/admin-role", "wiq_reader", http.StatusForbidden},
+		{"unknown key", http.MethodGet, "/patients/1", "wiq_unknown", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set(APIKeyHeader, tt.key)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func TestAuthMiddleware_APIKeyIdentity(t *testing.T) {
+	r := setupAPIKeyRouter(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/patients/1", nil)
+	req.Header.Set(APIKeyHeader, "wiq_reader")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"key_id":7,"has_user":false,"role":"service"}`, w.Body.String())
+}
+
+func TestAuthMiddleware_APIKeysDisabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/", AuthMiddleware(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(APIKeyHeader, "wiq_reader")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Permission names an action on a protected resource
+type Permission string
+
+const (
+	PermPatientsList   Permission = "patients:list"
+	PermPatientsRead   Permission = "patients:read"
+	PermPatientsWrite  Permission = "patients:write"
+	PermPatientsDelete Permission = "patients:delete"
+
+	PermCliniciansRead   Permission = "clinicians:read"
+	PermCliniciansManage Permission = "clinicians:manage"
+	PermCareTeamManage   Permission = "care-team:manage"
+
+	PermAssessmentsList   Permission = "assessments:list"
+	PermAssessmentsRead   Permission = "assessments:read"
+	PermAssessmentsWrite  Permission = "assessments:write"
+	PermAssessmentsDelete Permission = "assessments:delete"
+
+	PermPortalRead Permission = "portal:read"
+
+	PermBreakGlassStart  Permission = "break-glass:start"
+	PermBreakGlassReview Permission = "break-glass:review"
+
+	PermUsersManage Permission = "users:manage"
+
+	// PermRecordsRestore lists and restores soft-deleted patients and assessments
+	PermRecordsRestore Permission = "records:restore"
+)
+
+// RolePermissions is the policy table mapping each permission to the roles
+// allowed to exercise it. Record-level scoping is applied on top of this by
+// service.AccessService: patients see only their own records and clinicians
+// only the patients on their care team.
+var RolePermissions = map[Permission][]string{
+	PermPatientsList:   {models.RoleAdmin, models.RoleClinician},
+	PermPatientsRead:   {models.RoleAdmin, models.RoleClinician, models.RolePatient},
+	PermPatientsWrite:  {models.RoleAdmin, models.RoleClinician},
+	PermPatientsDelete: {models.RoleAdmin},
+
+	PermCliniciansRead:   {models.RoleAdmin, models.RoleClinician},
+	PermCliniciansManage: {models.RoleAdmin},
+	PermCareTeamManage:   {models.RoleAdmin},
+
+	PermAssessmentsList:   {models.RoleAdmin, models.RoleClinician},
+	PermAssessmentsRead:   {models.RoleAdmin, models.RoleClinician, models.RolePatient},
+	PermAssessmentsWrite:  {models.RoleClinician},
+	PermAssessmentsDelete: {models.RoleAdmin},
+
+	PermPortalRead: {models.RolePatient},
+
+	PermBreakGlassStart:  {models.RoleClinician},
+	PermBreakGlassReview: {models.RoleAdmin},
+
+	PermUsersManage: {models.RoleAdmin},
+
+	PermRecordsRestore: {models.RoleAdmin},
+}
+
+// humanOnlyPermissions cannot be granted to API keys: they act on behalf of
+// the signed-in person (their own records, an emergency-access reason, a
+// review signature), record who deleted or restored a record, or would let a
+// key manage accounts and other keys
+var humanOnlyPermissions = map[Permission]bool{
+	PermPatientsDelete:    true,
+	PermAssessmentsDelete: true,
+	PermPortalRead:        true,
+	PermBreakGlassStart:   true,
+	PermBreakGlassReview:  true,
+	PermUsersManage:       true,
+	PermRecordsRestore:    true,
+}
+
+// APIKeyGrantable reports whether an API key may be scoped to the permission
+func APIKeyGrantable(permission Permission) bool {
+	_, known := RolePermissions[permission]
+	return known && !humanOnlyPermissions[permission]
+}
+
+// RequirePermission allows the request through only if the caller's role is
+// granted the permission in RolePermissions. Unknown permissions deny everyone.
+// Tokens issued before a required MFA enrollment is completed are denied.
+// API key requests are checked against the key's own permissions instead.
+func RequirePermission(permission Permission) gin.HandlerFunc {
+	checkRole := RoleMiddleware(RolePermissions[permission]...)
+
+	return func(c *gin.Context) {
+		if granted, isAPIKey := GetAPIKeyPermissions(c); isAPIKey {
+			if !APIKeyGrantable(permission) || !slices.Contains(granted, string(permission)) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "API key lacks permission " + string(permission)})
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if c.GetBool("mfa_pending") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Multi-factor authentication enrollment required"})
+			c.Abort()
+			return
+		}
+
+		checkRole(c)
+	}
+}
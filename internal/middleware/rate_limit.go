@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit allows each client IP at most limit requests per window on the
+// routes it guards and answers 429 beyond that. Counts are kept in memory,
+// so every API instance limits independently.
+func RateLimit(limit int, window time.Duration) gin.HandlerFunc {
+	type bucket struct {
+		start time.Time
+		count int
+	}
+
+	var mu sync.Mutex
+	buckets := map[string]*bucket{}
+
+	return func(c *gin.Context) {
+		now := time.Now()
+		ip := c.ClientIP()
+
+		mu.Lock()
+		// Drop finished windows so idle clients do not accumulate
+		for key, b := range buckets {
+			if now.Sub(b.start) >= window {
+				delete(buckets, key)
+			}
+		}
+
+		b, ok := buckets[ip]
+		if !ok {
+			b = &bucket{start: now}
+			buckets[ip] = b
+		}
+		b.count++
+		retryAfter := b.start.Add(window).Sub(now)
+		allowed := b.count <= limit
+		mu.Unlock()
+
+		if !allowed {
+			c.Header("Retry-After", retryAfterSeconds(retryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests, try again later"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// retryAfterSeconds formats a wait as whole seconds, rounding up
+func retryAfterSeconds(d time.Duration) string {
+	seconds := int((d + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return strconv.Itoa(seconds)
+}
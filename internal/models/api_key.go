@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// RoleService is the role carried by requests authenticated with an API key.
+// What such a request may do is decided by the key's permissions, not the role.
+const RoleService = "service"
+
+// APIKeyPrefix starts every API key so leaked keys are easy to recognise
+const APIKeyPrefix = "wiq_"
+
+// APIKey is a machine credential; the secret itself is never stored or returned
+type APIKey struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Prefix      string    `json:"prefix"`
+	Permissions []string  `json:"permissions"`
+	CreatedBy   *int      `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   NullTime  `json:"expires_at"`
+	LastUsedAt  NullTime  `json:"last_used_at"`
+	RevokedAt   NullTime  `json:"revoked_at"`
+}
+
+// IsUsable reports whether the key is neither revoked nor expired at t
+func (k *APIKey) IsUsable(t time.Time) bool {
+	if k.RevokedAt.Valid {
+		return false
+	}
+	return !k.ExpiresAt.Valid || t.Before(k.ExpiresAt.Time)
+}
+
+// CreateAPIKeyRequest represents an API key creation request
+type CreateAPIKeyRequest struct {
+	Name        string   `json:"name" binding:"required,max=100"`
+	Permissions []string `json:"permissions" binding:"required,min=1"`
+	ExpiresAt   string   `json:"expires_at,omitempty"` // RFC 3339; omitted means no expiry
+}
+
+// CreateAPIKeyResponse returns the new key; Key is shown only this once
+type CreateAPIKeyResponse struct {
+	Key    string `json:"key"`
+	APIKey APIKey `json:"api_key"`
+}
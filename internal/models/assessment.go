@@ -26,9 +26,39 @@ type AssessmentFilter struct {
 	PaginationParams
 }
 
-// CreateAssessmentRequest represents request for creating an assessment
+// AssessmentQuery is a parsed AssessmentFilter passed to the repository.
+// A non-zero CareTeamUserID limits results to that clinician user's patients.
+type AssessmentQuery struct {
+	PatientID      *int
+	ClinicianID    *int
+	StartDate      *time.Time
+	EndDate        *time.Time
+	CareTeamUserID int
+	Limit          int
+	Offset         int
+}
+
+// AssessmentListItem is the summary row returned when listing assessments
+type AssessmentListItem struct {
+	AssessmentID  int       `json:"assessment_id"`
+	Date          time.Time `json:"date"`
+	PatientID     int       `json:"patient_id"`
+	PatientName   string    `json:"patient_name"`
+	ClinicianID   int       `json:"clinician_id"`
+	ClinicianName string    `json:"clinician_name"`
+	Location      string    `json:"location"`
+}
+
+// DeletedAssessment is a soft-deleted assessment awaiting restore
+type DeletedAssessment struct {
+	AssessmentListItem
+	DeletionInfo
+}
+
+// CreateAssessmentRequest represents request for creating an assessment.
+// ClinicianID is ignored for clinicians, who always chart as themselves.
 type CreateAssessmentRequest struct {
-	ClinicianID    int    `json:"clinician_id" binding:"required"`
+	ClinicianID    int    `json:"clinician_id"`
 	PatientID      int    `json:"patient_id" binding:"required"`
 	Location       string `json:"location" binding:"required,max=50"`
 	Etiology       string `json:"etiology" binding:"required,max=50"`
@@ -120,20 +150,110 @@ type UpdateAssessmentRequest struct {
 	ReturnToClinic *bool  `json:"return_to_clinic"`
 }
 
-// FullAssessmentResponse includes all related data
+// FullAssessmentResponse is an assessment with every section recorded by
+// CreateFullAssessment. It mirrors FullAssessmentRequest so clients can
+// round-trip what they saved; a section is null if it was never recorded.
 type FullAssessmentResponse struct {
-	AssessmentID       int       `json:"assessment_id"`
-	AssessmentDate     time.Time `json:"assessment_date"`
-	PatientID          int       `json:"patient_id"`
-	PatientName        string    `json:"patient_name"`
-	ClinicianID        int       `json:"clinician_id"`
-	ClinicianName      string    `json:"clinician_name"`
-	Location           string    `json:"location"`
-	Etiology           string    `json:"etiology"`
-	Stage              string    `json:"stage"`
-	HealingStatus      string    `json:"healing_status"`
-	PainScore          string    `json:"pain_score"`
-	GranulationPercent int       `json:"granulation_percent"`
-	Length             float64   `json:"length"`
-	Width              float64   `json:"width"`
+	AssessmentID   int       `json:"assessment_id"`
+	AssessmentDate time.Time `json:"assessment_date"`
+	PatientID      int       `json:"patient_id"`
+	PatientName    string    `json:"patient_name"`
+	ClinicianID    int       `json:"clinician_id"`
+	ClinicianName  string    `json:"clinician_name"`
+	Location       string    `json:"location"`
+	Etiology       string    `json:"etiology"`
+	DepthOfInjury  string    `json:"depth_of_injury"`
+	Stage          string    `json:"stage"`
+	Chronicity     string    `json:"chronicity"`
+	HealingStatus  string    `json:"healing_status"`
+	ReturnToClinic bool      `json:"return_to_clinic"`
+	AssessmentSections
+}
+
+// AssessmentSections holds the child sections of an assessment, one per
+// table. A nil section was never recorded, or is left unchanged on save.
+type AssessmentSections struct {
+	InfectionPain  *InfectionPain  `json:"infection_pain"`
+	TissueStatus   *TissueStatus   `json:"tissue_status"`
+	Vitals         *Vitals         `json:"vitals"`
+	WoundCondition *WoundCondition `json:"wound_condition"`
+	Exudate        *Exudate        `json:"exudate"`
+	Treatment      *Treatment      `json:"treatment"`
+}
+
+// Sections returns every section of req, ready to save
+func (req FullAssessmentRequest) Sections() AssessmentSections {
+	infectionPain := InfectionPain(req.InfectionPain)
+	tissueStatus := TissueStatus(req.TissueStatus)
+	vitals := Vitals(req.Vitals)
+	woundCondition := WoundCondition(req.WoundCondition)
+	exudate := Exudate(req.Exudate)
+	treatment := Treatment(req.Treatment)
+	return AssessmentSections{
+		InfectionPain:  &infectionPain,
+		TissueStatus:   &tissueStatus,
+		Vitals:         &vitals,
+		WoundCondition: &woundCondition,
+		Exudate:        &exudate,
+		Treatment:      &treatment,
+	}
+}
+
+// InfectionPain is the infection and pain section of an assessment
+type InfectionPain struct {
+	LocalizedSymptoms string `json:"localized_symptoms"`
+	SystemicSymptoms  string `json:"systemic_symptoms"`
+	PainPresent       string `json:"pain_present"`
+	PainScore         string `json:"pain_score"`
+	CultureResults    string `json:"culture_results"`
+	Antibiotic        string `json:"antibiotic"`
+}
+
+// TissueStatus is the wound bed composition section of an assessment
+type TissueStatus struct {
+	GranulationPercent int    `json:"granulation_percent"`
+	EpithelialPercent  int    `json:"epithelial_percent"`
+	SloughPercent      int    `json:"slough_percent"`
+	EscharPercent      int    `json:"eschar_percent"`
+	NecroticPercent    int    `json:"necrotic_percent"`
+	Debridement        string `json:"debridement"`
+}
+
+// Vitals are the vital signs taken during an assessment
+type Vitals struct {
+	BloodPressure    string  `json:"blood_pressure"`
+	Temperature      float64 `json:"temperature"`
+	Pulse            int     `json:"pulse"`
+	RespirationRate  int     `json:"respiration_rate"`
+	OxygenSaturation int     `json:"oxygen_saturation"`
+}
+
+// WoundCondition holds the wound measurements and surrounding skin findings
+type WoundCondition struct {
+	Length        float64 `json:"length"`
+	Width         float64 `json:"width"`
+	Depth         float64 `json:"depth"`
+	Tunneling     bool    `json:"tunneling"`
+	Undermining   bool    `json:"undermining"`
+	Edges         string  `json:"edges"`
+	SkinCondition string  `json:"skin_condition"`
+	Edema         string  `json:"edema"`
+	Blister       string  `json:"blister"`
+}
+
+// Exudate is the drainage section of an assessment
+type Exudate struct {
+	ExudateType   string `json:"exudate_type"`
+	ExudateAmount string `json:"exudate_amount"`
+	Odor          string `json:"odor"`
+}
+
+// Treatment is the dressing and care plan section of an assessment
+type Treatment struct {
+	PrimaryDressing   string `json:"primary_dressing"`
+	SecondaryDressing string `json:"secondary_dressing"`
+	TertiaryDressing  string `json:"tertiary_dressing"`
+	Frequency         string `json:"frequency"`
+	Supplies          string `json:"supplies"`
+	Orders            string `json:"orders"`
 }
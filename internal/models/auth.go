@@ -4,6 +4,13 @@ import (
 	"time"
 )
 
+// User roles stored in Users.role
+const (
+	RoleAdmin     = "admin"
+	RoleClinician = "clinician"
+	RolePatient   = "patient"
+)
+
 // User represents the main authentication user
 type User struct {
 	ID            int       `json:"id" db:"id"`
@@ -14,6 +21,68 @@ type User struct {
 	EmailVerified bool      `json:"email_verified" db:"email_verified"`
 	CreatedAt     time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+
+	// Lockout state after repeated failed logins
+	FailedLoginAttempts int      `json:"failed_login_attempts" db:"failed_login_attempts"`
+	LockedUntil         NullTime `json:"locked_until" db:"locked_until"`
+
+	// PasswordResetRequired blocks password login until the password is reset
+	PasswordResetRequired bool      `json:"password_reset_required" db:"password_reset_required"`
+	PasswordChangedAt     time.Time `json:"password_changed_at" db:"password_changed_at"`
+}
+
+// IsLocked reports whether the account is locked at time t
+func (u *User) IsLocked(t time.Time) bool {
+	return u.LockedUntil.Valid && u.LockedUntil.Time.After(t)
+}
+
+// UserLockStatus is the admin view of an account's lockout state
+type UserLockStatus struct {
+	UserID              int      `json:"user_id"`
+	Email               string   `json:"email"`
+	Role                string   `json:"role"`
+	FailedLoginAttempts int      `json:"failed_login_attempts"`
+	LastFailedLoginAt   NullTime `json:"last_failed_login_at"`
+	Locked              bool     `json:"locked"`
+	LockedUntil         NullTime `json:"locked_until"`
+}
+
+// UserSummary is the admin view of an account
+type UserSummary struct {
+	ID                    int       `json:"id"`
+	Email                 string    `json:"email"`
+	FirstName             string    `json:"first_name"`
+	LastName              string    `json:"last_name"`
+	Role                  string    `json:"role"`
+	IsActive              bool      `json:"is_active"`
+	EmailVerified         bool      `json:"email_verified"`
+	Locked                bool      `json:"locked"`
+	PasswordResetRequired bool      `json:"password_reset_required"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+// UserFilter holds filter parameters for the admin user list.
+// Query matches email or name (case-insensitive substring).
+type UserFilter struct {
+	Query  string `form:"q"`
+	Role   string `form:"role" binding:"omitempty,oneof=admin clinician patient"`
+	Active *bool  `form:"active"`
+	PaginationParams
+}
+
+// CreateUserRequest is an administrator creating a clinician or patient account
+type CreateUserRequest struct {
+	Email     string `json:"email" binding:"required,email"`
+	Password  string `json:"password" binding:"required"`
+	FirstName string `json:"first_name" binding:"required"`
+	LastName  string `json:"last_name" binding:"required"`
+	Role      string `json:"role" binding:"required,oneof=clinician patient"`
+}
+
+// ChangeRoleRequest changes a user's role
+type ChangeRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin clinician patient"`
 }
 
 // UserWithProfile includes user data with role-specific profile
@@ -29,14 +98,51 @@ type UserWithProfile struct {
 	UpdatedAt     time.Time `json:"updated_at"`
 }
 
-// RefreshToken represents a JWT refresh token
+// RefreshToken represents a stored refresh token. Only the token's hash is
+// kept; tokens rotated from the same login share a FamilyID.
 type RefreshToken struct {
 	ID        int       `json:"id" db:"id"`
 	UserID    int       `json:"user_id" db:"user_id"`
-	Token     string    `json:"token" db:"token"`
+	TokenHash string    `json:"-" db:"token_hash"`
+	FamilyID  string    `json:"-" db:"family_id"`
 	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	Revoked   bool      `json:"revoked" db:"revoked"`
+	// Rotated is set once the token has been exchanged for a replacement;
+	// only a rotated token presented again counts as reuse
+	Rotated bool `json:"-" db:"-"`
+}
+
+// Security event types recorded in security_events
+const (
+	SecurityEventRefreshTokenReuse = "refresh_token_reuse"
+)
+
+// ClientInfo identifies the client a session was created from
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// Session is an active refresh-token family as shown to its owner. ID is the
+// family's current token; CreatedAt is when the login happened and
+// RefreshedAt when the token was last rotated.
+type Session struct {
+	ID          int       `json:"id"`
+	UserAgent   string    `json:"user_agent"`
+	ClientIP    string    `json:"client_ip"`
+	CreatedAt   time.Time `json:"created_at"`
+	RefreshedAt time.Time `json:"refreshed_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// UserMFA holds a user's TOTP enrollment
+type UserMFA struct {
+	UserID       int      `json:"user_id" db:"user_id"`
+	Secret       string   `json:"-" db:"secret"` // Never send the secret after enrollment
+	Enabled      bool     `json:"enabled" db:"enabled"`
+	ConfirmedAt  NullTime `json:"confirmed_at" db:"confirmed_at"`
+	LastUsedStep int64    `json:"-" db:"last_used_step"`
 }
 
 // LoginRequest represents the login request body
@@ -45,20 +151,58 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required,min=6"`
 }
 
-// RegisterRequest represents the registration request body
+// RegisterRequest represents the registration request body. Self-registered
+// accounts are always patients; Role is accepted for older clients.
 type RegisterRequest struct {
 	Email     string `json:"email" binding:"required,email"`
-	Password  string `json:"password" binding:"required,min=6"`
+	Password  string `json:"password" binding:"required"`
 	FirstName string `json:"first_name" binding:"required"`
 	LastName  string `json:"last_name" binding:"required"`
-	Role      string `json:"role" binding:"required,oneof=clinician patient"`
+	Role      string `json:"role" binding:"omitempty,oneof=patient"`
 }
 
-// LoginResponse represents the login response
+// LoginResponse represents the login response.
+// Tokens are omitted when registration must first be confirmed by email.
 type LoginResponse struct {
 	User         UserWithProfile `json:"user"`
-	Token        string          `json:"token"`
-	RefreshToken string          `json:"refresh_token"`
+	Token        string          `json:"token,omitempty"`
+	RefreshToken string          `json:"refresh_token,omitempty"`
+}
+
+// MFAChallengeResponse is returned by login when a second factor is required
+type MFAChallengeResponse struct {
+	MFARequired bool      `json:"mfa_required"`
+	MFAToken    string    `json:"mfa_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// MFALoginRequest completes a login with the challenge token and a TOTP or recovery code
+type MFALoginRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// MFAEnrollResponse carries a new TOTP secret for the authenticator app
+type MFAEnrollResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// MFAConfirmRequest confirms enrollment with a code from the authenticator app
+type MFAConfirmRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// MFAConfirmResponse returns the one-time recovery codes and a fresh access token
+type MFAConfirmResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+	Token         string   `json:"token"`
+}
+
+// MFADisableRequest disables MFA after re-checking the password and a code
+type MFADisableRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
 }
 
 // RefreshTokenRequest represents the refresh token request
@@ -69,7 +213,17 @@ type RefreshTokenRequest struct {
 // ChangePasswordRequest represents the change password request
 type ChangePasswordRequest struct {
 	OldPassword string `json:"old_password" binding:"required"`
-	NewPassword string `json:"new_password" binding:"required,min=6"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+// VerifyEmailRequest represents the email verification request
+type VerifyEmailRequest struct {
+	Token string `json:"token" form:"token" binding:"required"`
+}
+
+// ResendVerificationRequest represents a request for a new verification email
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
 }
 
 // ResetPasswordRequest represents the password reset request
@@ -77,9 +231,15 @@ type ResetPasswordRequest struct {
 	Email string `json:"email" binding:"required,email"`
 }
 
+// ConfirmResetPasswordRequest completes a password reset with the emailed token
+type ConfirmResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
 // UpdateProfileRequest represents the profile update request
 type UpdateProfileRequest struct {
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
+	FirstName string `json:"first_name" binding:"omitempty,max=100"`
+	LastName  string `json:"last_name" binding:"omitempty,max=100"`
 	Email     string `json:"email" binding:"omitempty,email"`
 }
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// BreakGlassRequest represents a request for emergency access
+type BreakGlassRequest struct {
+	Reason string `json:"reason" binding:"required,min=10,max=1000"`
+}
+
+// BreakGlassResponse carries the time-boxed elevated access token
+type BreakGlassResponse struct {
+	EventID   int       `json:"event_id"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// BreakGlassEvent is one emergency-access grant and the records read under it
+type BreakGlassEvent struct {
+	EventID     int                `json:"event_id"`
+	UserID      int                `json:"user_id"`
+	UserEmail   string             `json:"user_email"`
+	Reason      string             `json:"reason"`
+	ClientIP    string             `json:"client_ip"`
+	StartedAt   time.Time          `json:"started_at"`
+	ExpiresAt   time.Time          `json:"expires_at"`
+	ReviewedBy  *int               `json:"reviewed_by"`
+	ReviewedAt  NullTime           `json:"reviewed_at"`
+	ReviewNotes string             `json:"review_notes,omitempty"`
+	AccessCount int                `json:"access_count"`
+	Accesses    []BreakGlassAccess `json:"accesses,omitempty"`
+}
+
+// BreakGlassAccess is a PHI read performed with a break-glass token
+type BreakGlassAccess struct {
+	AccessID   int       `json:"access_id"`
+	PatientID  *int      `json:"patient_id"`
+	Resource   string    `json:"resource"`
+	AccessedAt time.Time `json:"accessed_at"`
+}
+
+// BreakGlassEventFilter holds filter parameters for the review report
+type BreakGlassEventFilter struct {
+	Unreviewed bool `form:"unreviewed"`
+	UserID     *int `form:"user_id"`
+	PaginationParams
+}
+
+// ReviewBreakGlassRequest records the outcome of a break-glass review
+type ReviewBreakGlassRequest struct {
+	Notes string `json:"notes" binding:"required,max=2000"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// CareTeamAssignment links a clinician to a patient for a date range
+type CareTeamAssignment struct {
+	AssignmentID  int       `json:"assignment_id"`
+	PatientID     int       `json:"patient_id"`
+	ClinicianID   int       `json:"clinician_id"`
+	ClinicianName string    `json:"clinician_name"`
+	StartDate     time.Time `json:"start_date"`
+	EndDate       NullTime  `json:"end_date"`
+	Active        bool      `json:"active"`
+}
+
+// AssignCareTeamRequest represents the request body for assigning a clinician to a patient
+type AssignCareTeamRequest struct {
+	ClinicianID int    `json:"clinician_id" binding:"required"`
+	StartDate   string `json:"start_date" binding:"omitempty"` // ISO-8601 format, defaults to today
+	EndDate     string `json:"end_date" binding:"omitempty"`   // ISO-8601 format, open-ended if empty
+}
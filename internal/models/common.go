@@ -61,3 +61,77 @@ func (nt NullTime) MarshalJSON() ([]byte, error) {
 	}
 	return []byte(`"` + nt.Time.Format(time.RFC3339) + `"`), nil
 }
+
+// DeleteMode selects how a delete treats the clinical records that depend on
+// the record being deleted
+type DeleteMode string
+
+const (
+	// DeleteRestrict refuses the delete while dependent records exist
+	DeleteRestrict DeleteMode = "restrict"
+	// DeleteCascade deletes the dependent records in the same transaction
+	DeleteCascade DeleteMode = "cascade"
+)
+
+// DeleteQuery holds the query parameters accepted by DELETE endpoints.
+// Reason is kept with soft-deleted records.
+type DeleteQuery struct {
+	Mode   DeleteMode `form:"mode" binding:"omitempty,oneof=restrict cascade"`
+	Reason string     `form:"reason" binding:"max=500"`
+}
+
+// GetMode returns the requested mode, defaulting to DeleteRestrict
+func (q DeleteQuery) GetMode() DeleteMode {
+	if q.Mode == "" {
+		return DeleteRestrict
+	}
+	return q.Mode
+}
+
+// DeleteResult reports exactly what a delete removed: rows per table and
+// the IDs of any assessments among them. Patients and assessments are soft
+// deleted, so their rows are marked rather than removed.
+type DeleteResult struct {
+	Mode          DeleteMode     `json:"mode"`
+	Deleted       map[string]int `json:"deleted"`
+	AssessmentIDs []int          `json:"assessment_ids"`
+}
+
+// NewDeleteResult returns an empty result for a delete in mode
+func NewDeleteResult(mode DeleteMode) *DeleteResult {
+	return &DeleteResult{Mode: mode, Deleted: map[string]int{}, AssessmentIDs: []int{}}
+}
+
+// Add records n rows removed from table; zero counts are not reported
+func (r *DeleteResult) Add(table string, n int) {
+	if n > 0 {
+		r.Deleted[table] += n
+	}
+}
+
+// RestoreResult reports what a restore brought back, in the same shape as
+// DeleteResult
+type RestoreResult struct {
+	Restored      map[string]int `json:"restored"`
+	AssessmentIDs []int          `json:"assessment_ids"`
+}
+
+// NewRestoreResult returns an empty result
+func NewRestoreResult() *RestoreResult {
+	return &RestoreResult{Restored: map[string]int{}, AssessmentIDs: []int{}}
+}
+
+// Add records n rows restored in table; zero counts are not reported
+func (r *RestoreResult) Add(table string, n int) {
+	if n > 0 {
+		r.Restored[table] += n
+	}
+}
+
+// DeletionInfo records who soft-deleted a record, when and why.
+// DeletedBy is nil if the deleting user has since been removed.
+type DeletionInfo struct {
+	DeletedAt    time.Time `json:"deleted_at"`
+	DeletedBy    *int      `json:"deleted_by"`
+	DeleteReason string    `json:"delete_reason"`
+}
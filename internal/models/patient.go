@@ -11,6 +11,12 @@ type Patient struct {
 	MedicalRecordNumber string    `json:"medical_record_number"`
 }
 
+// DeletedPatient is a soft-deleted patient awaiting restore
+type DeletedPatient struct {
+	Patient
+	DeletionInfo
+}
+
 // CreatePatientRequest represents the request body for creating a patient
 type CreatePatientRequest struct {
 	FullName            string `json:"full_name" binding:"required,min=2,max=100"`
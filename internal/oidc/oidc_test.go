@@ -0,0 +1,163 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubIssuer is a minimal OIDC provider that issues one ID token per code
+type stubIssuer struct {
+	*httptest.Server
+	key      *rsa.PrivateKey
+	claims   jwt.MapClaims // claims of the next ID token
+	verifier string        // code_verifier received by the token endpoint
+}
+
+func newStubIssuer(t *testing.T) *stubIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	s := &stubIssuer{key: key}
+	mux := http.NewServeMux()
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 s.URL,
+			"authorization_endpoint": s.URL + "/authorize",
+			"token_endpoint":         s.URL + "/token",
+			"jwks_uri":               s.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "stub",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+		if id != "woundiq" || secret != "s3cret" || r.FormValue("code") != "good-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		s.verifier = r.FormValue("code_verifier")
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, s.claims)
+		token.Header["kid"] = "stub"
+		signed, _ := token.SignedString(key)
+		json.NewEncoder(w).Encode(map[string]string{"id_token": signed, "token_type": "Bearer"})
+	})
+
+	return s
+}
+
+func (s *stubIssuer) provider() *Provider {
+	return NewProvider(Config{
+		IssuerURL:    s.URL,
+		ClientID:     "woundiq",
+		ClientSecret: "s3cret",
+		RedirectURL:  "http://localhost:8080/api/v1/auth/sso/callback",
+	}, s.Client())
+}
+
+func (s *stubIssuer) validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            s.URL,
+		"aud":            "woundiq",
+		"sub":            "idp-user-1",
+		"exp":            time.Now().Add(time.Minute).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          "n-123",
+		"email":          "Nurse@Hospital.org",
+		"email_verified": true,
+		"given_name":     "Nora",
+		"family_name":    "Nurse",
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	issuer := newStubIssuer(t)
+
+	raw, err := issuer.provider().AuthCodeURL(context.Background(), "st", "n-123", "verifier")
+	require.NoError(t, err)
+
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "/authorize", u.Path)
+
+	q := u.Query()
+	assert.Equal(t, "code", q.Get("response_type"))
+	assert.Equal(t, "woundiq", q.Get("client_id"))
+	assert.Equal(t, "st", q.Get("state"))
+	assert.Equal(t, "n-123", q.Get("nonce"))
+	assert.Equal(t, "openid email profile", q.Get("scope"))
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+	assert.NotEmpty(t, q.Get("code_challenge"))
+}
+
+func TestExchange(t *testing.T) {
+	issuer := newStubIssuer(t)
+	ctx := context.Background()
+
+	t.Run("valid ID token", func(t *testing.T) {
+		issuer.claims = issuer.validClaims()
+
+		claims, err := issuer.provider().Exchange(ctx, "good-code", "verifier", "n-123")
+		require.NoError(t, err)
+		assert.Equal(t, "idp-user-1", claims.Subject)
+		assert.Equal(t, issuer.URL, claims.Issuer)
+		assert.Equal(t, "Nurse@Hospital.org", claims.Email)
+		assert.True(t, claims.EmailVerified)
+		assert.Equal(t, "Nora", claims.GivenName)
+		assert.Equal(t, "verifier", issuer.verifier)
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		issuer.claims = issuer.validClaims()
+
+		_, err := issuer.provider().Exchange(ctx, "good-code", "verifier", "other")
+		assert.ErrorContains(t, err, "nonce")
+	})
+
+	t.Run("wrong audience", func(t *testing.T) {
+		issuer.claims = issuer.validClaims()
+		issuer.claims["aud"] = "someone-else"
+
+		_, err := issuer.provider().Exchange(ctx, "good-code", "verifier", "n-123")
+		assert.Error(t, err)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		issuer.claims = issuer.validClaims()
+		issuer.claims["exp"] = time.Now().Add(-time.Hour).Unix()
+
+		_, err := issuer.provider().Exchange(ctx, "good-code", "verifier", "n-123")
+		assert.Error(t, err)
+	})
+
+	t.Run("rejected code", func(t *testing.T) {
+		_, err := issuer.provider().Exchange(ctx, "bad-code", "verifier", "n-123")
+		assert.ErrorContains(t, err, "invalid_grant")
+	})
+}
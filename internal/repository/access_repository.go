@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// AccessRepository answers the record-level access questions asked by
+// service.AccessService
+type AccessRepository interface {
+	GetPatientIDByUserID(userID int) (int, error)
+	GetClinicianIDByUserID(userID int) (int, error)
+	IsOnCareTeam(userID, patientID int) (bool, error)
+}
+
+// PostgresAccessRepository is the AccessRepository backed by the Patient,
+// Clinician and care_team_assignment tables
+type PostgresAccessRepository struct {
+	db *sql.DB
+}
+
+func NewAccessRepository(db *sql.DB) *PostgresAccessRepository {
+	return &PostgresAccessRepository{db: db}
+}
+
+// ------------------------------------------------------------
+// GET PATIENT ID LINKED TO A USER ACCOUNT
+// ------------------------------------------------------------
+func (r *PostgresAccessRepository) GetPatientIDByUserID(userID int) (int, error) {
+	var patientID int
+
+	err := r.db.QueryRow(`
+		SELECT patient_id
+		FROM Patient
+		WHERE user_id = $1 AND deleted_at IS NULL
+	`, userID).Scan(&patientID)
+
+	if err == sql.ErrNoRows {
+		return 0, utils.ErrNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return patientID, nil
+}
+
+// ------------------------------------------------------------
+// GET CLINICIAN ID LINKED TO A USER ACCOUNT
+// ------------------------------------------------------------
+func (r *PostgresAccessRepository) GetClinicianIDByUserID(userID int) (int, error) {
+	var clinicianID int
+	err := r.db.QueryRow(`
+		SELECT clinician_id
+		FROM Clinician
+		WHERE user_id = $1
+	`, userID).Scan(&clinicianID)
+
+	if err == sql.ErrNoRows {
+		return 0, utils.ErrNotClinician
+	}
+	return clinicianID, err
+}
+
+// ------------------------------------------------------------
+// CHECK CLINICIAN USER IS ON PATIENT'S CARE TEAM
+// ------------------------------------------------------------
+func (r *PostgresAccessRepository) IsOnCareTeam(userID, patientID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1
+			FROM care_team_assignment ct
+			JOIN clinician c ON c.clinician_id = ct.clinician_id
+			WHERE c.user_id = $1 AND ct.patient_id = $2 AND `+activeAssignmentSQL+`
+		)
+	`, userID, patientID).Scan(&exists)
+	return exists, err
+}
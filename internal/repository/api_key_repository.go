@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// APIKeyRepository stores hashed machine API keys
+type APIKeyRepository interface {
+	CreateAPIKey(name, prefix, keyHash string, permissions []string, createdBy int, expiresAt *time.Time) (*models.APIKey, error)
+	GetAPIKey(id int) (*models.APIKey, error)
+	GetAPIKeyByHash(keyHash string) (*models.APIKey, error)
+	ListAPIKeys() ([]models.APIKey, error)
+	TouchAPIKey(id int) error
+	RevokeAPIKey(id int) error
+}
+
+// PostgresAPIKeyRepository is the APIKeyRepository backed by the api_keys table
+type PostgresAPIKeyRepository struct {
+	db *sql.DB
+}
+
+func NewAPIKeyRepository(db *sql.DB) *PostgresAPIKeyRepository {
+	return &PostgresAPIKeyRepository{db: db}
+}
+
+// apiKeyLastUsedInterval limits how often last_used_at is written for a busy key
+const apiKeyLastUsedInterval = "1 minute"
+
+// ------------------------------------------------------------
+// CREATE API KEY
+// ------------------------------------------------------------
+func (r *PostgresAPIKeyRepository) CreateAPIKey(name, prefix, keyHash string, permissions []string, createdBy int, expiresAt *time.Time) (*models.APIKey, error) {
+	var id int
+	err := r.db.QueryRow(`
+		INSERT INTO api_keys (name, key_prefix, key_hash, permissions, created_by, expires_at)
+		VALUES ($1, $2, $3, string_to_array($4, ','), NULLIF($5, 0), $6)
+		RETURNING id
+	`, name, prefix, keyHash, strings.Join(permissions, ","), createdBy, expiresAt).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetAPIKey(id)
+}
+
+// ------------------------------------------------------------
+// GET API KEY
+// ------------------------------------------------------------
+func (r *PostgresAPIKeyRepository) GetAPIKey(id int) (*models.APIKey, error) {
+	key, err := scanAPIKey(r.db.QueryRow(apiKeySelect+" WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, utils.ErrNotFound
+	}
+	return key, err
+}
+
+// ------------------------------------------------------------
+// FIND API KEY BY HASH
+// ------------------------------------------------------------
+func (r *PostgresAPIKeyRepository) GetAPIKeyByHash(keyHash string) (*models.APIKey, error) {
+	key, err := scanAPIKey(r.db.QueryRow(apiKeySelect+" WHERE key_hash = $1", keyHash))
+	if err == sql.ErrNoRows {
+		return nil, utils.ErrInvalidAPIKey
+	}
+	return key, err
+}
+
+// ------------------------------------------------------------
+// LIST API KEYS
+// ------------------------------------------------------------
+func (r *PostgresAPIKeyRepository) ListAPIKeys() ([]models.APIKey, error) {
+	rows, err := r.db.Query(apiKeySelect + " ORDER BY created_at DESC, id DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []models.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+
+	return keys, rows.Err()
+}
+
+// ------------------------------------------------------------
+// RECORD KEY USE
+// ------------------------------------------------------------
+func (r *PostgresAPIKeyRepository) TouchAPIKey(id int) error {
+	_, err := r.db.Exec(`
+		UPDATE api_keys
+		SET last_used_at = NOW()
+		WHERE id = $1
+		  AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '`+apiKeyLastUsedInterval+`')
+	`, id)
+	return err
+}
+
+// ------------------------------------------------------------
+// REVOKE API KEY
+// ------------------------------------------------------------
+func (r *PostgresAPIKeyRepository) RevokeAPIKey(id int) error {
+	result, err := r.db.Exec(`
+		UPDATE api_keys
+		SET revoked_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return utils.ErrNotFound
+	}
+	return nil
+}
+
+const apiKeySelect = `
+	SELECT id, name, key_prefix, array_to_string(permissions, ','), created_by,
+	       created_at, expires_at, last_used_at, revoked_at
+	FROM api_keys`
+
+func scanAPIKey(row interface{ Scan(...any) error }) (*models.APIKey, error) {
+	var k models.APIKey
+	var permissions string
+	var createdBy sql.NullInt64
+	var expiresAt, lastUsedAt, revokedAt sql.NullTime
+
+	if err := row.Scan(&k.ID, &k.Name, &k.Prefix, &permissions, &createdBy,
+		&k.CreatedAt, &expiresAt, &lastUsedAt, &revokedAt); err != nil {
+		return nil, err
+	}
+
+	k.Permissions = []string{}
+	if permissions != "" {
+		k.Permissions = strings.Split(permissions, ",")
+	}
+	if createdBy.Valid {
+		id := int(createdBy.Int64)
+		k.CreatedBy = &id
+	}
+	k.ExpiresAt = models.NullTime{Time: expiresAt.Time, Valid: expiresAt.Valid}
+	k.LastUsedAt = models.NullTime{Time: lastUsedAt.Time, Valid: lastUsedAt.Valid}
+	k.RevokedAt = models.NullTime{Time: revokedAt.Time, Valid: revokedAt.Valid}
+	return &k, nil
+}
@@ -0,0 +1,588 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// AssessmentRepository stores wound assessments and their child sections.
+// Deleted assessments are kept but hidden from every method except
+// ListDeletedAssessments and RestoreAssessment.
+type AssessmentRepository interface {
+	// ListAssessments returns a page of assessments, newest first, and the total count
+	ListAssessments(q models.AssessmentQuery) ([]models.AssessmentListItem, int, error)
+	GetAssessment(assessmentID int) (*models.Assessment, error)
+	CreateAssessment(req models.CreateAssessmentRequest) (int, error)
+	CreateFullAssessment(req models.FullAssessmentRequest) (int, error)
+	UpdateAssessment(a models.Assessment) error
+	// SaveSections inserts or replaces the non-nil sections in one transaction
+	SaveSections(assessmentID int, sections models.AssessmentSections) error
+	// ReplaceFullAssessment updates the header, including the clinician, and
+	// saves sections in one transaction
+	ReplaceFullAssessment(a models.Assessment, sections models.AssessmentSections) error
+	// DeleteAssessment soft-deletes an assessment, recording deletedBy and
+	// reason; its child sections are kept with it
+	DeleteAssessment(assessmentID, deletedBy int, reason string) (*models.DeleteResult, error)
+	// ListDeletedAssessments returns a page of deleted assessments, most
+	// recently deleted first, and the total count
+	ListDeletedAssessments(limit, offset int) ([]models.DeletedAssessment, int, error)
+	// RestoreAssessment undeletes an assessment. Returns utils.ErrNotFound
+	// unless it is deleted and utils.ErrPatientDeleted if its patient is.
+	RestoreAssessment(assessmentID int) (*models.RestoreResult, error)
+	GetFullAssessment(assessmentID int) (*models.FullAssessmentResponse, error)
+}
+
+// PostgresAssessmentRepository is the AssessmentRepository backed by the
+// assessment and child-section tables and the add_full_assessment function
+type PostgresAssessmentRepository struct {
+	db *sql.DB
+}
+
+func NewAssessmentRepository(db *sql.DB) *PostgresAssessmentRepository {
+	return &PostgresAssessmentRepository{db: db}
+}
+
+const assessmentSelect = `
+	SELECT assessment_id, clinician_id, patient_id, date, location, etiology,
+	       depth_of_injury, stage, chronicity, healing_status, return_to_clinic
+	FROM assessment
+	WHERE deleted_at IS NULL`
+
+// ------------------------------------------------------------
+// LIST ASSESSMENTS
+// ------------------------------------------------------------
+func (r *PostgresAssessmentRepository) ListAssessments(q models.AssessmentQuery) ([]models.AssessmentListItem, int, error) {
+	where := " WHERE a.deleted_at IS NULL"
+	args := []interface{}{}
+	argPos := 1
+
+	if q.PatientID != nil {
+		where += fmt.Sprintf(" AND a.patient_id = $%d", argPos)
+		args = append(args, *q.PatientID)
+		argPos++
+	}
+	if q.ClinicianID != nil {
+		where += fmt.Sprintf(" AND a.clinician_id = $%d", argPos)
+		args = append(args, *q.ClinicianID)
+		argPos++
+	}
+	if q.StartDate != nil {
+		where += fmt.Sprintf(" AND a.date >= $%d", argPos)
+		args = append(args, *q.StartDate)
+		argPos++
+	}
+	if q.EndDate != nil {
+		where += fmt.Sprintf(" AND a.date <= $%d", argPos)
+		args = append(args, *q.EndDate)
+		argPos++
+	}
+	if q.CareTeamUserID != 0 {
+		where += " AND " + CareTeamPatientFilter("a.patient_id", argPos)
+		args = append(args, q.CareTeamUserID)
+		argPos++
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM assessment a"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT a.assessment_id, a.date, p.patient_id, p.full_name,
+		       c.clinician_id, c.full_name, a.location
+		FROM assessment a
+		JOIN patient p ON p.patient_id = a.patient_id
+		JOIN clinician c ON c.clinician_id = a.clinician_id` + where +
+		fmt.Sprintf(" ORDER BY a.date DESC LIMIT $%d OFFSET $%d", argPos, argPos+1)
+	args = append(args, q.Limit, q.Offset)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := []models.AssessmentListItem{}
+	for rows.Next() {
+		var a models.AssessmentListItem
+		if err := rows.Scan(&a.AssessmentID, &a.Date, &a.PatientID, &a.PatientName,
+			&a.ClinicianID, &a.ClinicianName, &a.Location); err != nil {
+			return nil, 0, err
+		}
+		items = append(items, a)
+	}
+
+	return items, total, rows.Err()
+}
+
+// ------------------------------------------------------------
+// GET ASSESSMENT
+// ------------------------------------------------------------
+func (r *PostgresAssessmentRepository) GetAssessment(assessmentID int) (*models.Assessment, error) {
+	a, err := scanAssessment(r.db.QueryRow(assessmentSelect+" AND assessment_id = $1", assessmentID))
+	if err == sql.ErrNoRows {
+		return nil, utils.ErrNotFound
+	}
+	return a, err
+}
+
+// ------------------------------------------------------------
+// CREATE ASSESSMENT (HEADER ONLY)
+// ------------------------------------------------------------
+func (r *PostgresAssessmentRepository) CreateAssessment(req models.CreateAssessmentRequest) (int, error) {
+	var id int
+	err := r.db.QueryRow(`
+		INSERT INTO assessment (clinician_id, patient_id, date, location, etiology,
+		                        depth_of_injury, stage, chronicity, healing_status, return_to_clinic)
+		VALUES ($1, $2, NOW(), $3, $4, $5, $6, $7, $8, $9)
+		RETURNING assessment_id
+	`, req.ClinicianID, req.PatientID, req.Location, req.Etiology, req.DepthOfInjury,
+		req.Stage, req.Chronicity, req.HealingStatus, req.ReturnToClinic).Scan(&id)
+	return id, err
+}
+
+// ------------------------------------------------------------
+// CREATE FULL ASSESSMENT (add_full_assessment FUNCTION)
+// ------------------------------------------------------------
+func (r *PostgresAssessmentRepository) CreateFullAssessment(req models.FullAssessmentRequest) (int, error) {
+	var id int
+	err := r.db.QueryRow(`
+		SELECT add_full_assessment(
+			$1, $2, $3, $4, $5, $6, $7, $8, $9,
+			$10, $11, $12, $13, $14, $15,
+			$16, $17, $18, $19, $20, $21,
+			$22, $23, $24, $25, $26,
+			$27, $28, $29, $30, $31, $32, $33, $34, $35,
+			$36, $37, $38,
+			$39, $40, $41, $42, $43, $44
+		)
+	`,
+		// Assessment fields
+		req.ClinicianID, req.PatientID, req.Location, req.Etiology, req.DepthOfInjury,
+		req.Stage, req.Chronicity, req.HealingStatus, req.ReturnToClinic,
+		// Infection and pain
+		req.InfectionPain.LocalizedSymptoms, req.InfectionPain.SystemicSymptoms,
+		req.InfectionPain.PainPresent, req.InfectionPain.PainScore,
+		req.InfectionPain.CultureResults, req.InfectionPain.Antibiotic,
+		// Tissue status
+		req.TissueStatus.GranulationPercent, req.TissueStatus.EpithelialPercent,
+		req.TissueStatus.SloughPercent, req.TissueStatus.EscharPercent,
+		req.TissueStatus.NecroticPercent, req.TissueStatus.Debridement,
+		// Vitals
+		req.Vitals.BloodPressure, req.Vitals.Temperature, req.Vitals.Pulse,
+		req.Vitals.RespirationRate, req.Vitals.OxygenSaturation,
+		// Wound condition
+		req.WoundCondition.Length, req.WoundCondition.Width, req.WoundCondition.Depth,
+		req.WoundCondition.Tunneling, req.WoundCondition.Undermining,
+		req.WoundCondition.Edges, req.WoundCondition.SkinCondition,
+		req.WoundCondition.Edema, req.WoundCondition.Blister,
+		// Exudate
+		req.Exudate.ExudateType, req.Exudate.ExudateAmount, req.Exudate.Odor,
+		// Treatment
+		req.Treatment.PrimaryDressing, req.Treatment.SecondaryDressing,
+		req.Treatment.TertiaryDressing, req.Treatment.Frequency,
+		req.Treatment.Supplies, req.Treatment.Orders,
+	).Scan(&id)
+	return id, err
+}
+
+// ------------------------------------------------------------
+// UPDATE ASSESSMENT
+// ------------------------------------------------------------
+func (r *PostgresAssessmentRepository) UpdateAssessment(a models.Assessment) error {
+	result, err := r.db.Exec(`
+		UPDATE assessment
+		SET location = $1, etiology = $2, depth_of_injury = $3, stage = $4,
+		    chronicity = $5, healing_status = $6, return_to_clinic = $7
+		WHERE assessment_id = $8 AND deleted_at IS NULL
+	`, a.Location, a.Etiology, a.DepthOfInjury, a.Stage,
+		a.Chronicity, a.HealingStatus, a.ReturnToClinic, a.AssessmentID)
+	if err != nil {
+		return err
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return utils.ErrNotFound
+	}
+	return nil
+}
+
+// ------------------------------------------------------------
+// SAVE ASSESSMENT SECTIONS
+// ------------------------------------------------------------
+func (r *PostgresAssessmentRepository) SaveSections(assessmentID int, sections models.AssessmentSections) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Lock the header so a concurrent delete cannot orphan the sections
+	if err := lockActiveRow(tx, "assessment", "assessment_id", assessmentID); err != nil {
+		return err
+	}
+
+	if err := saveSections(tx, assessmentID, sections); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ------------------------------------------------------------
+// REPLACE FULL ASSESSMENT
+// ------------------------------------------------------------
+func (r *PostgresAssessmentRepository) ReplaceFullAssessment(a models.Assessment, sections models.AssessmentSections) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE assessment
+		SET clinician_id = $1, location = $2, etiology = $3, depth_of_injury = $4, stage = $5,
+		    chronicity = $6, healing_status = $7, return_to_clinic = $8
+		WHERE assessment_id = $9 AND deleted_at IS NULL
+	`, a.ClinicianID, a.Location, a.Etiology, a.DepthOfInjury, a.Stage,
+		a.Chronicity, a.HealingStatus, a.ReturnToClinic, a.AssessmentID)
+	if err != nil {
+		return err
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return utils.ErrNotFound
+	}
+
+	if err := saveSections(tx, a.AssessmentID, sections); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// saveSections upserts each non-nil section; every child table has a
+// unique assessment_id, so a second save replaces the first
+func saveSections(tx *sql.Tx, assessmentID int, s models.AssessmentSections) error {
+	if v := s.InfectionPain; v != nil {
+		if err := upsertSection(tx, "infection_and_pain", assessmentID,
+			[]string{"localized_symptoms", "systemic_symptoms", "pain_present", "pain_score", "culture_results", "antibiotic"},
+			v.LocalizedSymptoms, v.SystemicSymptoms, v.PainPresent, v.PainScore, v.CultureResults, v.Antibiotic); err != nil {
+			return err
+		}
+	}
+	if v := s.TissueStatus; v != nil {
+		if err := upsertSection(tx, "tissue_status", assessmentID,
+			[]string{"granulation_percent", "epithelial_percent", "slough_percent", "eschar_percent", "necrotic_percent", "debridement"},
+			v.GranulationPercent, v.EpithelialPercent, v.SloughPercent, v.EscharPercent, v.NecroticPercent, v.Debridement); err != nil {
+			return err
+		}
+	}
+	if v := s.Vitals; v != nil {
+		if err := upsertSection(tx, "vitals", assessmentID,
+			[]string{"blood_pressure", "temperature", "pulse", "respiration_rate", "oxygen_saturation"},
+			v.BloodPressure, v.Temperature, v.Pulse, v.RespirationRate, v.OxygenSaturation); err != nil {
+			return err
+		}
+	}
+	if v := s.WoundCondition; v != nil {
+		if err := upsertSection(tx, "wound_condition", assessmentID,
+			[]string{"length", "width", "depth", "tunneling", "undermining", "edges", "skin_condition", "edema", "blister"},
+			v.Length, v.Width, v.Depth, v.Tunneling, v.Undermining, v.Edges, v.SkinCondition, v.Edema, v.Blister); err != nil {
+			return err
+		}
+	}
+	if v := s.Exudate; v != nil {
+		if err := upsertSection(tx, "exudate", assessmentID,
+			[]string{"exudate_type", "exudate_amount", "odor"},
+			v.ExudateType, v.ExudateAmount, v.Odor); err != nil {
+			return err
+		}
+	}
+	if v := s.Treatment; v != nil {
+		if err := upsertSection(tx, "treatment", assessmentID,
+			[]string{"primary_dressing", "secondary_dressing", "tertiary_dressing", "frequency", "supplies", "orders"},
+			v.PrimaryDressing, v.SecondaryDressing, v.TertiaryDressing, v.Frequency, v.Supplies, v.Orders); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func upsertSection(tx *sql.Tx, table string, assessmentID int, columns []string, values ...any) error {
+	placeholders := make([]string, len(columns))
+	updates := make([]string, len(columns))
+	for i, col := range columns {
+		placeholders[i] = fmt.Sprintf("$%d", i+2)
+		updates[i] = col + " = EXCLUDED." + col
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (assessment_id, %s)
+		VALUES ($1, %s)
+		ON CONFLICT (assessment_id) DO UPDATE SET %s
+	`, table, strings.Join(columns, ", "), strings.Join(placeholders, ", "), strings.Join(updates, ", "))
+
+	if _, err := tx.Exec(query, append([]any{assessmentID}, values...)...); err != nil {
+		return fmt.Errorf("failed to save %s: %w", table, err)
+	}
+	return nil
+}
+
+// ------------------------------------------------------------
+// DELETE ASSESSMENT
+// ------------------------------------------------------------
+// Sections are part of the assessment, so they are hidden and kept with it
+func (r *PostgresAssessmentRepository) DeleteAssessment(assessmentID, deletedBy int, reason string) (*models.DeleteResult, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result := models.NewDeleteResult(models.DeleteCascade)
+	result.AssessmentIDs, err = softDelete(tx, "assessment", "assessment_id",
+		"assessment_id = $1", assessmentID, deletedBy, reason)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.AssessmentIDs) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	result.Add("assessment", 1)
+	return result, tx.Commit()
+}
+
+// ------------------------------------------------------------
+// LIST DELETED ASSESSMENTS
+// ------------------------------------------------------------
+func (r *PostgresAssessmentRepository) ListDeletedAssessments(limit, offset int) ([]models.DeletedAssessment, int, error) {
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM assessment WHERE deleted_at IS NOT NULL").Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(`
+		SELECT a.assessment_id, a.date, p.patient_id, p.full_name,
+		       c.clinician_id, c.full_name, a.location,
+		       a.deleted_at, a.deleted_by, a.delete_reason
+		FROM assessment a
+		JOIN patient p ON p.patient_id = a.patient_id
+		JOIN clinician c ON c.clinician_id = a.clinician_id
+		WHERE a.deleted_at IS NOT NULL
+		ORDER BY a.deleted_at DESC, a.assessment_id DESC
+		LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := []models.DeletedAssessment{}
+	for rows.Next() {
+		var a models.DeletedAssessment
+		var deletedBy sql.NullInt64
+		if err := rows.Scan(&a.AssessmentID, &a.Date, &a.PatientID, &a.PatientName,
+			&a.ClinicianID, &a.ClinicianName, &a.Location,
+			&a.DeletedAt, &deletedBy, &a.DeleteReason); err != nil {
+			return nil, 0, err
+		}
+		a.DeletedBy = nullIntPtr(deletedBy)
+		items = append(items, a)
+	}
+
+	return items, total, rows.Err()
+}
+
+// ------------------------------------------------------------
+// RESTORE ASSESSMENT
+// ------------------------------------------------------------
+func (r *PostgresAssessmentRepository) RestoreAssessment(assessmentID int) (*models.RestoreResult, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Lock the patient too, so it cannot be deleted while this commits
+	var assessmentDeleted, patientDeleted bool
+	err = tx.QueryRow(`
+		SELECT a.deleted_at IS NOT NULL, p.deleted_at IS NOT NULL
+		FROM assessment a
+		JOIN patient p ON p.patient_id = a.patient_id
+		WHERE a.assessment_id = $1
+		FOR UPDATE
+	`, assessmentID).Scan(&assessmentDeleted, &patientDeleted)
+	if err == sql.ErrNoRows || (err == nil && !assessmentDeleted) {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if patientDeleted {
+		return nil, utils.ErrPatientDeleted
+	}
+
+	result := models.NewRestoreResult()
+	result.AssessmentIDs, err = restore(tx, "assessment", "assessment_id", "assessment_id = $1", assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	result.Add("assessment", len(result.AssessmentIDs))
+	return result, tx.Commit()
+}
+
+// ------------------------------------------------------------
+// FULL ASSESSMENT WITH EVERY SECTION
+// ------------------------------------------------------------
+// GetFullAssessment reads the header and each child section in one
+// read-only snapshot. Sections that were never recorded are left nil.
+func (r *PostgresAssessmentRepository) GetFullAssessment(assessmentID int) (*models.FullAssessmentResponse, error) {
+	tx, err := r.db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var result models.FullAssessmentResponse
+	err = tx.QueryRow(`
+		SELECT a.assessment_id, a.date, p.patient_id, p.full_name, c.clinician_id, c.full_name,
+		       a.location, a.etiology, a.depth_of_injury, a.stage, a.chronicity,
+		       a.healing_status, a.return_to_clinic
+		FROM assessment a
+		JOIN patient p ON p.patient_id = a.patient_id
+		JOIN clinician c ON c.clinician_id = a.clinician_id
+		WHERE a.assessment_id = $1 AND a.deleted_at IS NULL
+	`, assessmentID).Scan(
+		&result.AssessmentID, &result.AssessmentDate,
+		&result.PatientID, &result.PatientName,
+		&result.ClinicianID, &result.ClinicianName,
+		&result.Location, &result.Etiology, &result.DepthOfInjury, &result.Stage,
+		&result.Chronicity, &result.HealingStatus, &result.ReturnToClinic,
+	)
+	if err == sql.ErrNoRows {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if result.InfectionPain, err = getInfectionPain(tx, assessmentID); err != nil {
+		return nil, err
+	}
+	if result.TissueStatus, err = getTissueStatus(tx, assessmentID); err != nil {
+		return nil, err
+	}
+	if result.Vitals, err = getVitals(tx, assessmentID); err != nil {
+		return nil, err
+	}
+	if result.WoundCondition, err = getWoundCondition(tx, assessmentID); err != nil {
+		return nil, err
+	}
+	if result.Exudate, err = getExudate(tx, assessmentID); err != nil {
+		return nil, err
+	}
+	if result.Treatment, err = getTreatment(tx, assessmentID); err != nil {
+		return nil, err
+	}
+
+	return &result, tx.Commit()
+}
+
+// querier is satisfied by *sql.DB and *sql.Tx
+type querier interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// scanSection reads one child-section row; a missing row is not an error
+// and returns false
+func scanSection(q querier, table, columns string, assessmentID int, dest ...any) (bool, error) {
+	err := q.QueryRow("SELECT "+columns+" FROM "+table+" WHERE assessment_id = $1", assessmentID).Scan(dest...)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func getInfectionPain(q querier, assessmentID int) (*models.InfectionPain, error) {
+	var s models.InfectionPain
+	found, err := scanSection(q, "infection_and_pain",
+		"localized_symptoms, systemic_symptoms, pain_present, pain_score, culture_results, antibiotic",
+		assessmentID, &s.LocalizedSymptoms, &s.SystemicSymptoms, &s.PainPresent, &s.PainScore,
+		&s.CultureResults, &s.Antibiotic)
+	if !found {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func getTissueStatus(q querier, assessmentID int) (*models.TissueStatus, error) {
+	var s models.TissueStatus
+	found, err := scanSection(q, "tissue_status",
+		"granulation_percent, epithelial_percent, slough_percent, eschar_percent, necrotic_percent, debridement",
+		assessmentID, &s.GranulationPercent, &s.EpithelialPercent, &s.SloughPercent, &s.EscharPercent,
+		&s.NecroticPercent, &s.Debridement)
+	if !found {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func getVitals(q querier, assessmentID int) (*models.Vitals, error) {
+	var s models.Vitals
+	found, err := scanSection(q, "vitals",
+		"blood_pressure, temperature::float8, pulse, respiration_rate, oxygen_saturation",
+		assessmentID, &s.BloodPressure, &s.Temperature, &s.Pulse, &s.RespirationRate, &s.OxygenSaturation)
+	if !found {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func getWoundCondition(q querier, assessmentID int) (*models.WoundCondition, error) {
+	var s models.WoundCondition
+	found, err := scanSection(q, "wound_condition",
+		"length::float8, width::float8, depth::float8, tunneling, undermining, edges, skin_condition, edema, blister",
+		assessmentID, &s.Length, &s.Width, &s.Depth, &s.Tunneling, &s.Undermining,
+		&s.Edges, &s.SkinCondition, &s.Edema, &s.Blister)
+	if !found {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func getExudate(q querier, assessmentID int) (*models.Exudate, error) {
+	var s models.Exudate
+	found, err := scanSection(q, "exudate", "exudate_type, exudate_amount, odor",
+		assessmentID, &s.ExudateType, &s.ExudateAmount, &s.Odor)
+	if !found {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func getTreatment(q querier, assessmentID int) (*models.Treatment, error) {
+	var s models.Treatment
+	found, err := scanSection(q, "treatment",
+		"primary_dressing, secondary_dressing, tertiary_dressing, frequency, supplies, orders",
+		assessmentID, &s.PrimaryDressing, &s.SecondaryDressing, &s.TertiaryDressing, &s.Frequency,
+		&s.Supplies, &s.Orders)
+	if !found {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func scanAssessment(row interface{ Scan(...any) error }) (*models.Assessment, error) {
+	var a models.Assessment
+	if err := row.Scan(&a.AssessmentID, &a.ClinicianID, &a.PatientID, &a.Date, &a.Location,
+		&a.Etiology, &a.DepthOfInjury, &a.Stage, &a.Chronicity, &a.HealingStatus, &a.ReturnToClinic); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
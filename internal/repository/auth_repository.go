@@ -10,18 +10,68 @@ import (
 	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
 )
 
-type AuthRepository struct {
+// AuthRepository stores user accounts and everything attached to them:
+// credentials, refresh tokens, verification and reset tokens, MFA, lockout
+// counters and linked SSO identities
+type AuthRepository interface {
+	CreateUser(email, password, role, firstName, lastName string) (*models.User, error)
+	GetUserByEmail(email string) (*models.User, error)
+	GetUserByID(userID int) (*models.User, error)
+	GetUserWithProfile(userID int) (*models.UserWithProfile, error)
+	SaveRefreshToken(userID int, tokenHash, familyID string, expiresAt time.Time, client models.ClientInfo) error
+	GetRefreshToken(tokenHash string) (*models.RefreshToken, error)
+	RotateRefreshToken(old *models.RefreshToken, newTokenHash string, expiresAt time.Time, client models.ClientInfo) error
+	RevokeTokenFamily(familyID string) error
+	RecordSecurityEvent(userID int, eventType, detail string, client models.ClientInfo) error
+	RevokeAllUserTokens(userID int) error
+	ListSessions(userID int) ([]models.Session, error)
+	RevokeSession(userID, sessionID int) error
+	SetUserActive(userID int, active bool) error
+	UpdatePassword(userID int, newPassword string) error
+	ReplacePasswordHash(userID int, oldHash, newHash string) error
+	GetPasswordHistory(userID, n int) ([]string, error)
+	EmailExists(email string) (bool, error)
+	SaveEmailVerificationToken(userID int, tokenHash string, expiresAt time.Time) error
+	VerifyEmail(tokenHash string) (int, error)
+	SavePasswordResetToken(userID int, tokenHash string, expiresAt time.Time) error
+	ResetPassword(tokenHash, newPassword string) (int, error)
+	GetPasswordResetUserID(tokenHash string) (int, error)
+	UpdateProfile(userID int, role, firstName, lastName, newEmail string) error
+	GetMFA(userID int) (*models.UserMFA, error)
+	SaveMFASecret(userID int, secret string) error
+	EnableMFA(userID int, step int64, recoveryCodeHashes []string) error
+	UpdateMFALastStep(userID int, step int64) error
+	UseRecoveryCode(userID int, codeHash string) error
+	DeleteMFA(userID int) error
+	RecordFailedLogin(userID, threshold int, lockFor time.Duration) (models.NullTime, error)
+	ClearFailedLogins(userID int) error
+	RecordIPLoginFailure(clientIP, email string, pruneBefore time.Time) error
+	CountIPLoginFailures(clientIP string, since time.Time) (int, error)
+	GetLockStatus(userID int) (*models.UserLockStatus, error)
+	ListLockedUsers() ([]models.UserLockStatus, error)
+	GetUserByIdentity(issuer, subject string) (*models.User, error)
+	LinkIdentity(userID int, issuer, subject, email string) error
+	MarkEmailVerified(userID int) error
+	ListUsers(filter models.UserFilter) ([]models.UserSummary, int, error)
+	GetUserSummary(userID int) (*models.UserSummary, error)
+	ChangeUserRole(userID int, role string) error
+	SetPasswordResetRequired(userID int) error
+}
+
+// PostgresAuthRepository is the AuthRepository backed by the Users table
+// and its companion auth tables
+type PostgresAuthRepository struct {
 	db *sql.DB
 }
 
-func NewAuthRepository(db *sql.DB) *AuthRepository {
-	return &AuthRepository{db: db}
+func NewAuthRepository(db *sql.DB) *PostgresAuthRepository {
+	return &PostgresAuthRepository{db: db}
 }
 
 // ------------------------------------------------------------
 // CREATE USER + PROFILE (PATIENT / CLINICIAN)
 // ------------------------------------------------------------
-func (r *AuthRepository) CreateUser(email, password, role, firstName, lastName string) (*models.User, error) {
+func (r *PostgresAuthRepository) CreateUser(email, password, role, firstName, lastName string) (*models.User, error) {
 
 	// Hash password
 	hashedPassword, err := utils.HashPassword(password)
@@ -29,8 +79,6 @@ func (r *AuthRepository) CreateUser(email, password, role, firstName, lastName s
 		return nil, err
 	}
 
-	fullName := firstName + " " + lastName
-
 	// Start transaction
 	tx, err := r.db.Begin()
 	if err != nil {
@@ -59,78 +107,96 @@ func (r *AuthRepository) CreateUser(email, password, role, firstName, lastName s
 	//----------------------------------------------------------
 	// 2. Insert into role-specific profile table
 	//----------------------------------------------------------
+	switch role {
+	case models.RolePatient, models.RoleClinician:
+		err = insertProfile(tx, user.ID, role, firstName, lastName)
+	default:
+		err = errors.New("invalid role specified")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// insertProfile creates the Patient or Clinician row for a user with
+// placeholder clinical details that the user or an admin fills in later
+func insertProfile(tx *sql.Tx, userID int, role, firstName, lastName string) error {
+	fullName := firstName + " " + lastName
+
 	switch role {
 
 	// ----------------------------------------------------------
 	// PATIENT PROFILE
 	// ----------------------------------------------------------
-	case "patient":
-		_, err = tx.Exec(`
+	case models.RolePatient:
+		_, err := tx.Exec(`
 			INSERT INTO Patient (
 				user_id, first_name, last_name, full_name,
 				date_of_birth, gender, medical_record_number
 			)
 			VALUES ($1, $2, $3, $4, $5, $6, $7)
 		`,
-			user.ID,
+			userID,
 			firstName,
 			lastName,
 			fullName,
-			"1900-01-01",               // default DOB
-			"Unknown",                  // default gender
-			"MRN-"+fmt.Sprint(user.ID), // generated MRN
+			"1900-01-01",              // default DOB
+			"Unknown",                 // default gender
+			"MRN-"+fmt.Sprint(userID), // generated MRN
 		)
+		return err
 
 	// ----------------------------------------------------------
 	// CLINICIAN PROFILE
 	// ----------------------------------------------------------
-	case "clinician":
-		_, err = tx.Exec(`
+	case models.RoleClinician:
+		_, err := tx.Exec(`
 			INSERT INTO Clinician (
 				user_id, first_name, last_name, full_name,
 				role, department, contact_info, license_number
 			)
 			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		`,
-			user.ID,
+			userID,
 			firstName,
 			lastName,
 			fullName,
-			"Clinician",                // default job title
-			"General Medicine",         // default department
-			"Not Provided",             // default contact info
-			"LIC-"+fmt.Sprint(user.ID), // generated license number
+			"Clinician",               // default job title
+			"General Medicine",        // default department
+			"Not Provided",            // default contact info
+			"LIC-"+fmt.Sprint(userID), // generated license number
 		)
+		return err
 
 	default:
-		err = errors.New("invalid role specified")
-	}
-
-	if err != nil {
-		return nil, err
-	}
-
-	// Commit
-	if err = tx.Commit(); err != nil {
-		return nil, err
+		return fmt.Errorf("role %s has no profile", role)
 	}
-
-	return &user, nil
 }
 
 // ------------------------------------------------------------
 // GET USER BY EMAIL
 // ------------------------------------------------------------
-func (r *AuthRepository) GetUserByEmail(email string) (*models.User, error) {
+func (r *PostgresAuthRepository) GetUserByEmail(email string) (*models.User, error) {
 	var user models.User
+	var lockedUntil sql.NullTime
 
 	err := r.db.QueryRow(`
-		SELECT id, email, password_hash, role, is_active, email_verified, created_at, updated_at
+		SELECT id, email, password_hash, role, is_active, email_verified, created_at, updated_at,
+		       failed_login_attempts, locked_until, password_reset_required, password_changed_at
 		FROM Users
 		WHERE email = $1
 	`, email).Scan(
 		&user.ID, &user.Email, &user.PasswordHash, &user.Role,
 		&user.IsActive, &user.EmailVerified, &user.CreatedAt, &user.UpdatedAt,
+		&user.FailedLoginAttempts, &lockedUntil, &user.PasswordResetRequired, &user.PasswordChangedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -140,22 +206,26 @@ func (r *AuthRepository) GetUserByEmail(email string) (*models.User, error) {
 		return nil, err
 	}
 
+	user.LockedUntil = models.NullTime{Time: lockedUntil.Time, Valid: lockedUntil.Valid}
 	return &user, nil
 }
 
 // ------------------------------------------------------------
 // GET USER BY ID
 // ------------------------------------------------------------
-func (r *AuthRepository) GetUserByID(userID int) (*models.User, error) {
+func (r *PostgresAuthRepository) GetUserByID(userID int) (*models.User, error) {
 	var user models.User
+	var lockedUntil sql.NullTime
 
 	err := r.db.QueryRow(`
-		SELECT id, email, password_hash, role, is_active, email_verified, created_at, updated_at
+		SELECT id, email, password_hash, role, is_active, email_verified, created_at, updated_at,
+		       failed_login_attempts, locked_until, password_reset_required, password_changed_at
 		FROM Users
 		WHERE id = $1
 	`, userID).Scan(
 		&user.ID, &user.Email, &user.PasswordHash, &user.Role,
 		&user.IsActive, &user.EmailVerified, &user.CreatedAt, &user.UpdatedAt,
+		&user.FailedLoginAttempts, &lockedUntil, &user.PasswordResetRequired, &user.PasswordChangedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -165,6 +235,7 @@ func (r *AuthRepository) GetUserByID(userID int) (*models.User, error) {
 		return nil, err
 	}
 
+	user.LockedUntil = models.NullTime{Time: lockedUntil.Time, Valid: lockedUntil.Valid}
 	return &user, nil
 }
 
@@ -172,7 +243,7 @@ func (r *AuthRepository) GetUserByID(userID int) (*models.User, error) {
 // GET USER WITH PROFILE (PATIENT / CLINICIAN)
 // ------------------------------------------------------------
 // GetUserWithProfile - UPDATED VERSION
-func (r *AuthRepository) GetUserWithProfile(userID int) (*models.UserWithProfile, error) {
+func (r *PostgresAuthRepository) GetUserWithProfile(userID int) (*models.UserWithProfile, error) {
 	var profile models.UserWithProfile
 
 	// Get basic user info from users table
@@ -216,6 +287,10 @@ func (r *AuthRepository) GetUserWithProfile(userID int) (*models.UserWithProfile
 			return nil, fmt.Errorf("clinician profile not found for user_id %d", userID)
 		}
 
+	case models.RoleAdmin:
+		// Administrators have no clinical profile
+		return &profile, nil
+
 	default:
 		return nil, fmt.Errorf("unknown role: %s", user.Role)
 	}
@@ -235,27 +310,32 @@ func (r *AuthRepository) GetUserWithProfile(userID int) (*models.UserWithProfile
 // ------------------------------------------------------------
 // SAVE REFRESH TOKEN
 // ------------------------------------------------------------
-func (r *AuthRepository) SaveRefreshToken(userID int, token string, expiresAt time.Time) error {
+// SaveRefreshToken stores the hash of a refresh token in a rotation family,
+// together with the client it was issued to
+func (r *PostgresAuthRepository) SaveRefreshToken(userID int, tokenHash, familyID string, expiresAt time.Time, client models.ClientInfo) error {
 	_, err := r.db.Exec(`
-		INSERT INTO refresh_tokens (user_id, token, expires_at)
-		VALUES ($1, $2, $3)
-	`, userID, token, expiresAt)
+		INSERT INTO refresh_tokens (user_id, token_hash, family_id, expires_at, user_agent, client_ip)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''))
+	`, userID, tokenHash, familyID, expiresAt, utils.TruncateUTF8(client.UserAgent, maxUserAgentLength), client.IP)
 	return err
 }
 
 // ------------------------------------------------------------
-// VALIDATE REFRESH TOKEN
+// GET REFRESH TOKEN BY HASH
 // ------------------------------------------------------------
-func (r *AuthRepository) ValidateRefreshToken(token string) (*models.RefreshToken, error) {
+// GetRefreshToken returns the token with the given hash whether or not it is
+// revoked or expired, so callers can detect reuse of a rotated token
+func (r *PostgresAuthRepository) GetRefreshToken(tokenHash string) (*models.RefreshToken, error) {
 	var rt models.RefreshToken
 
 	err := r.db.QueryRow(`
-		SELECT id, user_id, token, expires_at, created_at, revoked
+		SELECT id, user_id, token_hash, family_id, expires_at, created_at, revoked,
+		       replaced_by IS NOT NULL
 		FROM refresh_tokens
-		WHERE token = $1 AND revoked = false AND expires_at > NOW()
-	`, token).Scan(
-		&rt.ID, &rt.UserID, &rt.Token, &rt.ExpiresAt,
-		&rt.CreatedAt, &rt.Revoked,
+		WHERE token_hash = $1
+	`, tokenHash).Scan(
+		&rt.ID, &rt.UserID, &rt.TokenHash, &rt.FamilyID, &rt.ExpiresAt,
+		&rt.CreatedAt, &rt.Revoked, &rt.Rotated,
 	)
 
 	if err == sql.ErrNoRows {
@@ -269,62 +349,991 @@ func (r *AuthRepository) ValidateRefreshToken(token string) (*models.RefreshToke
 }
 
 // ------------------------------------------------------------
-// REVOKE REFRESH TOKEN
+// ROTATE REFRESH TOKEN
 // ------------------------------------------------------------
-func (r *AuthRepository) RevokeRefreshToken(token string) error {
-	result, err := r.db.Exec(`
+// RotateRefreshToken revokes the old token and stores its replacement in the
+// same family in one transaction. ErrTokenReused means the old token was
+// rotated concurrently, i.e. it was presented twice; a token revoked any
+// other way (logout, password change) gives ErrInvalidToken.
+func (r *PostgresAuthRepository) RotateRefreshToken(old *models.RefreshToken, newTokenHash string, expiresAt time.Time, client models.ClientInfo) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
 		UPDATE refresh_tokens
-		SET revoked = true
-		WHERE token = $1
-	`, token)
+		SET revoked = true, revoked_at = NOW()
+		WHERE id = $1 AND revoked = false
+	`, old.ID)
 	if err != nil {
 		return err
 	}
 
 	n, _ := result.RowsAffected()
 	if n == 0 {
-		return errors.New("token not found")
+		var rotated bool
+		if err := tx.QueryRow("SELECT replaced_by IS NOT NULL FROM refresh_tokens WHERE id = $1", old.ID).Scan(&rotated); err != nil {
+			return err
+		}
+		if rotated {
+			return utils.ErrTokenReused
+		}
+		return utils.ErrInvalidToken
 	}
-	return nil
+
+	var newID int
+	if err = tx.QueryRow(`
+		INSERT INTO refresh_tokens (user_id, token_hash, family_id, expires_at, user_agent, client_ip)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''))
+		RETURNING id
+	`, old.UserID, newTokenHash, old.FamilyID, expiresAt,
+		utils.TruncateUTF8(client.UserAgent, maxUserAgentLength), client.IP).Scan(&newID); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec("UPDATE refresh_tokens SET replaced_by = $1 WHERE id = $2", newID, old.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ------------------------------------------------------------
+// REVOKE TOKEN FAMILY
+// ------------------------------------------------------------
+func (r *PostgresAuthRepository) RevokeTokenFamily(familyID string) error {
+	_, err := r.db.Exec(`
+		UPDATE refresh_tokens
+		SET revoked = true, revoked_at = NOW()
+		WHERE family_id = $1 AND revoked = false
+	`, familyID)
+	return err
+}
+
+// ------------------------------------------------------------
+// RECORD SECURITY EVENT
+// ------------------------------------------------------------
+func (r *PostgresAuthRepository) RecordSecurityEvent(userID int, eventType, detail string, client models.ClientInfo) error {
+	_, err := r.db.Exec(`
+		INSERT INTO security_events (user_id, event_type, detail, client_ip, user_agent)
+		VALUES (NULLIF($1, 0), $2, $3, NULLIF($4, ''), NULLIF($5, ''))
+	`, userID, eventType, detail, client.IP, utils.TruncateUTF8(client.UserAgent, maxUserAgentLength))
+	return err
 }
 
 // ------------------------------------------------------------
 // REVOKE ALL TOKENS FOR USER
 // ------------------------------------------------------------
-func (r *AuthRepository) RevokeAllUserTokens(userID int) error {
+func (r *PostgresAuthRepository) RevokeAllUserTokens(userID int) error {
 	_, err := r.db.Exec(`
 		UPDATE refresh_tokens
-		SET revoked = true
+		SET revoked = true, revoked_at = NOW()
 		WHERE user_id = $1 AND revoked = false
 	`, userID)
 	return err
 }
 
+// ------------------------------------------------------------
+// LIST ACTIVE SESSIONS FOR USER
+// ------------------------------------------------------------
+func (r *PostgresAuthRepository) ListSessions(userID int) ([]models.Session, error) {
+	rows, err := r.db.Query(`
+		SELECT rt.id, COALESCE(rt.user_agent, ''), COALESCE(rt.client_ip, ''),
+		       (SELECT MIN(f.created_at) FROM refresh_tokens f WHERE f.family_id = rt.family_id),
+		       rt.created_at, rt.expires_at
+		FROM refresh_tokens rt
+		WHERE rt.user_id = $1 AND rt.revoked = false AND rt.expires_at > NOW()
+		ORDER BY rt.created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []models.Session{}
+	for rows.Next() {
+		var s models.Session
+		if err := rows.Scan(&s.ID, &s.UserAgent, &s.ClientIP, &s.CreatedAt, &s.RefreshedAt, &s.ExpiresAt); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+
+	return sessions, rows.Err()
+}
+
+// ------------------------------------------------------------
+// REVOKE ONE SESSION
+// ------------------------------------------------------------
+// RevokeSession revokes the refresh-token family whose current token is
+// sessionID, provided it belongs to userID
+func (r *PostgresAuthRepository) RevokeSession(userID, sessionID int) error {
+	result, err := r.db.Exec(`
+		UPDATE refresh_tokens
+		SET revoked = true, revoked_at = NOW()
+		WHERE revoked = false AND family_id = (
+			SELECT family_id FROM refresh_tokens
+			WHERE id = $1 AND user_id = $2 AND revoked = false AND expires_at > NOW()
+		)
+	`, sessionID, userID)
+	if err != nil {
+		return err
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return utils.ErrNotFound
+	}
+	return nil
+}
+
+// ------------------------------------------------------------
+// ACTIVATE / DEACTIVATE USER
+// ------------------------------------------------------------
+func (r *PostgresAuthRepository) SetUserActive(userID int, active bool) error {
+	result, err := r.db.Exec(`
+		UPDATE Users
+		SET is_active = $1, updated_at = NOW()
+		WHERE id = $2
+	`, active, userID)
+	if err != nil {
+		return err
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return utils.ErrUserNotFound
+	}
+	return nil
+}
+
 // ------------------------------------------------------------
 // UPDATE PASSWORD
 // ------------------------------------------------------------
-func (r *AuthRepository) UpdatePassword(userID int, newPassword string) error {
+// UpdatePassword sets a new password and moves the old hash to password_history
+func (r *PostgresAuthRepository) UpdatePassword(userID int, newPassword string) error {
 	hashedPassword, err := utils.HashPassword(newPassword)
 	if err != nil {
 		return err
 	}
 
-	_, err = r.db.Exec(`
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := setPassword(tx, userID, hashedPassword); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// setPassword archives the current hash and stores the new one
+func setPassword(tx *sql.Tx, userID int, hashedPassword string) error {
+	if _, err := tx.Exec(`
+		INSERT INTO password_history (user_id, password_hash)
+		SELECT id, password_hash FROM Users WHERE id = $1
+	`, userID); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(`
 		UPDATE Users
-		SET password_hash = $1, updated_at = NOW()
+		SET password_hash = $1, password_changed_at = NOW(), password_reset_required = false,
+		    failed_login_attempts = 0, locked_until = NULL, updated_at = NOW()
 		WHERE id = $2
 	`, hashedPassword, userID)
+	return err
+}
 
+// ------------------------------------------------------------
+// UPGRADE PASSWORD HASH
+// ------------------------------------------------------------
+// ReplacePasswordHash swaps in a rehash of the same password. It is not a
+// password change: history and password_changed_at are left alone, and
+// nothing happens if the password was changed since oldHash was read.
+func (r *PostgresAuthRepository) ReplacePasswordHash(userID int, oldHash, newHash string) error {
+	_, err := r.db.Exec(`
+		UPDATE Users
+		SET password_hash = $1
+		WHERE id = $2 AND password_hash = $3
+	`, newHash, userID, oldHash)
 	return err
 }
 
+// ------------------------------------------------------------
+// RECENT PASSWORD HASHES
+// ------------------------------------------------------------
+// GetPasswordHistory returns the current password hash followed by up to
+// n-1 previous hashes, newest first
+func (r *PostgresAuthRepository) GetPasswordHistory(userID, n int) ([]string, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	rows, err := r.db.Query(`
+		(SELECT password_hash, NOW() AS at FROM Users WHERE id = $1)
+		UNION ALL
+		(SELECT password_hash, replaced_at FROM password_history
+		 WHERE user_id = $1 ORDER BY replaced_at DESC LIMIT $2)
+		ORDER BY at DESC
+	`, userID, n-1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hashes []string
+	for rows.Next() {
+		var hash string
+		var at time.Time
+		if err := rows.Scan(&hash, &at); err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, rows.Err()
+}
+
 // ------------------------------------------------------------
 // CHECK EMAIL EXISTS
 // ------------------------------------------------------------
-func (r *AuthRepository) EmailExists(email string) (bool, error) {
+func (r *PostgresAuthRepository) EmailExists(email string) (bool, error) {
 	var exists bool
 	err := r.db.QueryRow(`
 		SELECT EXISTS(SELECT 1 FROM Users WHERE email = $1)
 	`, email).Scan(&exists)
 	return exists, err
 }
+
+// ------------------------------------------------------------
+// SAVE EMAIL VERIFICATION TOKEN
+// ------------------------------------------------------------
+// SaveEmailVerificationToken stores a new token hash and discards any
+// earlier unused tokens so only the latest link works
+func (r *PostgresAuthRepository) SaveEmailVerificationToken(userID int, tokenHash string, expiresAt time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.Exec(`
+		DELETE FROM email_verification_tokens
+		WHERE user_id = $1 AND used_at IS NULL
+	`, userID); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(`
+		INSERT INTO email_verification_tokens (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+	`, userID, tokenHash, expiresAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ------------------------------------------------------------
+// CONSUME EMAIL VERIFICATION TOKEN
+// ------------------------------------------------------------
+// VerifyEmail marks the token used and the user's email verified in one
+// transaction, returning the user ID
+func (r *PostgresAuthRepository) VerifyEmail(tokenHash string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var userID int
+	err = tx.QueryRow(`
+		UPDATE email_verification_tokens
+		SET used_at = NOW()
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING user_id
+	`, tokenHash).Scan(&userID)
+
+	if err == sql.ErrNoRows {
+		return 0, utils.ErrInvalidToken
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = tx.Exec(`
+		UPDATE Users
+		SET email_verified = true, updated_at = NOW()
+		WHERE id = $1
+	`, userID); err != nil {
+		return 0, err
+	}
+
+	return userID, tx.Commit()
+}
+
+// ------------------------------------------------------------
+// SAVE PASSWORD RESET TOKEN
+// ------------------------------------------------------------
+// SavePasswordResetToken stores a new token hash and discards any earlier
+// unused reset tokens for the user
+func (r *PostgresAuthRepository) SavePasswordResetToken(userID int, tokenHash string, expiresAt time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.Exec(`
+		DELETE FROM password_reset_tokens
+		WHERE user_id = $1 AND used_at IS NULL
+	`, userID); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(`
+		INSERT INTO password_reset_tokens (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+	`, userID, tokenHash, expiresAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ------------------------------------------------------------
+// RESET PASSWORD WITH TOKEN
+// ------------------------------------------------------------
+// ResetPassword consumes a reset token, sets the new password and revokes
+// the user's refresh tokens in one transaction, returning the user ID
+func (r *PostgresAuthRepository) ResetPassword(tokenHash, newPassword string) (int, error) {
+	hashedPassword, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var userID int
+	err = tx.QueryRow(`
+		UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING user_id
+	`, tokenHash).Scan(&userID)
+
+	if err == sql.ErrNoRows {
+		return 0, utils.ErrInvalidToken
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := setPassword(tx, userID, hashedPassword); err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE refresh_tokens
+		SET revoked = true, revoked_at = NOW()
+		WHERE user_id = $1 AND revoked = false
+	`, userID); err != nil {
+		return 0, err
+	}
+
+	return userID, tx.Commit()
+}
+
+// ------------------------------------------------------------
+// LOOK UP PASSWORD RESET TOKEN
+// ------------------------------------------------------------
+// GetPasswordResetUserID returns the user a valid reset token belongs to
+// without consuming it, so the new password can be checked first
+func (r *PostgresAuthRepository) GetPasswordResetUserID(tokenHash string) (int, error) {
+	var userID int
+	err := r.db.QueryRow(`
+		SELECT user_id
+		FROM password_reset_tokens
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+	`, tokenHash).Scan(&userID)
+
+	if err == sql.ErrNoRows {
+		return 0, utils.ErrInvalidToken
+	}
+	return userID, err
+}
+
+// ------------------------------------------------------------
+// UPDATE USER + PROFILE
+// ------------------------------------------------------------
+// UpdateProfile updates the Users row and the matching Patient/Clinician
+// profile in one transaction. Empty names leave the stored value unchanged.
+// When newEmail is non-empty the email is replaced and marked unverified.
+func (r *PostgresAuthRepository) UpdateProfile(userID int, role, firstName, lastName, newEmail string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	//----------------------------------------------------------
+	// 1. Update USERS table
+	//----------------------------------------------------------
+	if newEmail != "" {
+		_, err = tx.Exec(`
+			UPDATE Users
+			SET email = $1, email_verified = false, updated_at = NOW()
+			WHERE id = $2
+		`, newEmail, userID)
+	} else {
+		_, err = tx.Exec(`
+			UPDATE Users
+			SET updated_at = NOW()
+			WHERE id = $1
+		`, userID)
+	}
+	if err != nil {
+		return err
+	}
+
+	//----------------------------------------------------------
+	// 2. Update role-specific profile table (names + full_name)
+	//----------------------------------------------------------
+	var profileTable string
+	switch role {
+	case models.RolePatient:
+		profileTable = "Patient"
+	case models.RoleClinician:
+		profileTable = "Clinician"
+	case models.RoleAdmin:
+		// Administrators have no clinical profile
+		return tx.Commit()
+	default:
+		return fmt.Errorf("unknown role: %s", role)
+	}
+
+	result, err := tx.Exec(`
+		UPDATE `+profileTable+`
+		SET first_name = COALESCE(NULLIF($1, ''), first_name),
+		    last_name  = COALESCE(NULLIF($2, ''), last_name),
+		    full_name  = COALESCE(NULLIF($1, ''), first_name) || ' ' || COALESCE(NULLIF($2, ''), last_name)
+		WHERE user_id = $3
+	`, firstName, lastName, userID)
+	if err != nil {
+		return err
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return fmt.Errorf("%s profile not found for user_id %d", role, userID)
+	}
+
+	return tx.Commit()
+}
+
+// ------------------------------------------------------------
+// GET MFA ENROLLMENT
+// ------------------------------------------------------------
+// GetMFA returns the user's TOTP enrollment or ErrMFANotEnrolled
+func (r *PostgresAuthRepository) GetMFA(userID int) (*models.UserMFA, error) {
+	var m models.UserMFA
+	var confirmedAt sql.NullTime
+
+	err := r.db.QueryRow(`
+		SELECT user_id, secret, enabled, confirmed_at, last_used_step
+		FROM user_mfa
+		WHERE user_id = $1
+	`, userID).Scan(&m.UserID, &m.Secret, &m.Enabled, &confirmedAt, &m.LastUsedStep)
+
+	if err == sql.ErrNoRows {
+		return nil, utils.ErrMFANotEnrolled
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m.ConfirmedAt = models.NullTime{Time: confirmedAt.Time, Valid: confirmedAt.Valid}
+	return &m, nil
+}
+
+// ------------------------------------------------------------
+// START MFA ENROLLMENT
+// ------------------------------------------------------------
+// SaveMFASecret stores a new, not yet enabled, TOTP secret. Restarting
+// enrollment replaces the pending secret; an enabled one is never replaced.
+func (r *PostgresAuthRepository) SaveMFASecret(userID int, secret string) error {
+	result, err := r.db.Exec(`
+		INSERT INTO user_mfa (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, last_used_step = 0, created_at = NOW()
+		WHERE user_mfa.enabled = false
+	`, userID, secret)
+	if err != nil {
+		return err
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return utils.ErrMFAAlreadyEnabled
+	}
+	return nil
+}
+
+// ------------------------------------------------------------
+// CONFIRM MFA ENROLLMENT
+// ------------------------------------------------------------
+// EnableMFA turns on MFA, records the step of the confirming code and
+// replaces the user's recovery codes in one transaction
+func (r *PostgresAuthRepository) EnableMFA(userID int, step int64, recoveryCodeHashes []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE user_mfa
+		SET enabled = true, confirmed_at = NOW(), last_used_step = $2
+		WHERE user_id = $1 AND enabled = false
+	`, userID, step)
+	if err != nil {
+		return err
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return utils.ErrMFAAlreadyEnabled
+	}
+
+	if _, err = tx.Exec("DELETE FROM mfa_recovery_codes WHERE user_id = $1", userID); err != nil {
+		return err
+	}
+
+	for _, hash := range recoveryCodeHashes {
+		if _, err = tx.Exec(`
+			INSERT INTO mfa_recovery_codes (user_id, code_hash)
+			VALUES ($1, $2)
+		`, userID, hash); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ------------------------------------------------------------
+// RECORD USED TOTP STEP
+// ------------------------------------------------------------
+// UpdateMFALastStep records the step of an accepted code. It fails with
+// ErrInvalidMFACode if that step (or a later one) was already used, so a
+// code cannot be replayed within its validity window.
+func (r *PostgresAuthRepository) UpdateMFALastStep(userID int, step int64) error {
+	result, err := r.db.Exec(`
+		UPDATE user_mfa
+		SET last_used_step = $2
+		WHERE user_id = $1 AND last_used_step < $2
+	`, userID, step)
+	if err != nil {
+		return err
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return utils.ErrInvalidMFACode
+	}
+	return nil
+}
+
+// ------------------------------------------------------------
+// CONSUME RECOVERY CODE
+// ------------------------------------------------------------
+func (r *PostgresAuthRepository) UseRecoveryCode(userID int, codeHash string) error {
+	result, err := r.db.Exec(`
+		UPDATE mfa_recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`, userID, codeHash)
+	if err != nil {
+		return err
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return utils.ErrInvalidMFACode
+	}
+	return nil
+}
+
+// ------------------------------------------------------------
+// DISABLE MFA
+// ------------------------------------------------------------
+func (r *PostgresAuthRepository) DeleteMFA(userID int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.Exec("DELETE FROM mfa_recovery_codes WHERE user_id = $1", userID); err != nil {
+		return err
+	}
+	if _, err = tx.Exec("DELETE FROM user_mfa WHERE user_id = $1", userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ------------------------------------------------------------
+// RECORD FAILED LOGIN FOR USER
+// ------------------------------------------------------------
+// RecordFailedLogin counts a failed attempt and locks the account for
+// lockFor once threshold consecutive failures are reached. A lock that has
+// already expired restarts the count. Returns the resulting lock expiry.
+func (r *PostgresAuthRepository) RecordFailedLogin(userID, threshold int, lockFor time.Duration) (models.NullTime, error) {
+	var lockedUntil sql.NullTime
+
+	err := r.db.QueryRow(`
+		UPDATE Users u
+		SET failed_login_attempts = n.attempts,
+		    last_failed_login_at = NOW(),
+		    locked_until = CASE
+		        WHEN $2 > 0 AND n.attempts >= $2 THEN NOW() + $3 * INTERVAL '1 second'
+		        WHEN u.locked_until > NOW() THEN u.locked_until
+		        ELSE NULL
+		    END
+		FROM (
+		    SELECT id,
+		           CASE WHEN locked_until <= NOW() THEN 1 ELSE failed_login_attempts + 1 END AS attempts
+		    FROM Users
+		    WHERE id = $1
+		) n
+		WHERE u.id = n.id
+		RETURNING u.locked_until
+	`, userID, threshold, int64(lockFor/time.Second)).Scan(&lockedUntil)
+
+	if err == sql.ErrNoRows {
+		return models.NullTime{}, utils.ErrUserNotFound
+	}
+	return models.NullTime{Time: lockedUntil.Time, Valid: lockedUntil.Valid}, err
+}
+
+// ------------------------------------------------------------
+// CLEAR FAILED LOGINS (SUCCESSFUL LOGIN / ADMIN UNLOCK)
+// ------------------------------------------------------------
+func (r *PostgresAuthRepository) ClearFailedLogins(userID int) error {
+	result, err := r.db.Exec(`
+		UPDATE Users
+		SET failed_login_attempts = 0, locked_until = NULL
+		WHERE id = $1
+	`, userID)
+	if err != nil {
+		return err
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return utils.ErrUserNotFound
+	}
+	return nil
+}
+
+// ------------------------------------------------------------
+// RECORD / COUNT FAILED LOGINS BY CLIENT IP
+// ------------------------------------------------------------
+func (r *PostgresAuthRepository) RecordIPLoginFailure(clientIP, email string, pruneBefore time.Time) error {
+	// Failures older than the throttle window are never counted again
+	if _, err := r.db.Exec("DELETE FROM login_failures WHERE attempted_at < $1", pruneBefore); err != nil {
+		return err
+	}
+
+	_, err := r.db.Exec(`
+		INSERT INTO login_failures (client_ip, email)
+		VALUES ($1, NULLIF($2, ''))
+	`, clientIP, email)
+	return err
+}
+
+func (r *PostgresAuthRepository) CountIPLoginFailures(clientIP string, since time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRow(`
+		SELECT COUNT(*)
+		FROM login_failures
+		WHERE client_ip = $1 AND attempted_at > $2
+	`, clientIP, since).Scan(&count)
+	return count, err
+}
+
+// ------------------------------------------------------------
+// LOCK STATUS (ADMIN)
+// ------------------------------------------------------------
+const userLockStatusSelect = `
+	SELECT id, email, role, failed_login_attempts, last_failed_login_at,
+	       COALESCE(locked_until > NOW(), false), locked_until
+	FROM Users`
+
+func (r *PostgresAuthRepository) GetLockStatus(userID int) (*models.UserLockStatus, error) {
+	s, err := scanUserLockStatus(r.db.QueryRow(userLockStatusSelect+" WHERE id = $1", userID))
+	if err == sql.ErrNoRows {
+		return nil, utils.ErrUserNotFound
+	}
+	return s, err
+}
+
+// ListLockedUsers returns accounts whose lock has not yet expired
+func (r *PostgresAuthRepository) ListLockedUsers() ([]models.UserLockStatus, error) {
+	rows, err := r.db.Query(userLockStatusSelect + " WHERE locked_until > NOW() ORDER BY locked_until DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	statuses := []models.UserLockStatus{}
+	for rows.Next() {
+		s, err := scanUserLockStatus(rows)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, *s)
+	}
+
+	return statuses, rows.Err()
+}
+
+func scanUserLockStatus(row interface{ Scan(...any) error }) (*models.UserLockStatus, error) {
+	var s models.UserLockStatus
+	var lastFailed, lockedUntil sql.NullTime
+
+	if err := row.Scan(&s.UserID, &s.Email, &s.Role, &s.FailedLoginAttempts, &lastFailed,
+		&s.Locked, &lockedUntil); err != nil {
+		return nil, err
+	}
+
+	s.LastFailedLoginAt = models.NullTime{Time: lastFailed.Time, Valid: lastFailed.Valid}
+	s.LockedUntil = models.NullTime{Time: lockedUntil.Time, Valid: lockedUntil.Valid}
+	return &s, nil
+}
+
+// maxUserAgentLength bounds the stored User-Agent header
+const maxUserAgentLength = 512
+
+// ------------------------------------------------------------
+// GET USER BY EXTERNAL IDENTITY (SSO)
+// ------------------------------------------------------------
+func (r *PostgresAuthRepository) GetUserByIdentity(issuer, subject string) (*models.User, error) {
+	var userID int
+	err := r.db.QueryRow(`
+		UPDATE user_identities
+		SET last_login_at = NOW()
+		WHERE issuer = $1 AND subject = $2
+		RETURNING user_id
+	`, issuer, subject).Scan(&userID)
+
+	if err == sql.ErrNoRows {
+		return nil, utils.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetUserByID(userID)
+}
+
+// ------------------------------------------------------------
+// LINK EXTERNAL IDENTITY TO USER
+// ------------------------------------------------------------
+func (r *PostgresAuthRepository) LinkIdentity(userID int, issuer, subject, email string) error {
+	_, err := r.db.Exec(`
+		INSERT INTO user_identities (user_id, issuer, subject, email, last_login_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NOW())
+	`, userID, issuer, subject, email)
+	return err
+}
+
+// ------------------------------------------------------------
+// MARK EMAIL VERIFIED
+// ------------------------------------------------------------
+// MarkEmailVerified is used when an identity provider has already verified
+// the address
+func (r *PostgresAuthRepository) MarkEmailVerified(userID int) error {
+	_, err := r.db.Exec(`
+		UPDATE Users
+		SET email_verified = true, updated_at = NOW()
+		WHERE id = $1
+	`, userID)
+	return err
+}
+
+// ------------------------------------------------------------
+// LIST / SEARCH USERS (ADMIN)
+// ------------------------------------------------------------
+// Names come from the profile matching the user's current role
+const userSummarySelect = `
+	SELECT u.id, u.email, COALESCE(c.first_name, p.first_name, ''), COALESCE(c.last_name, p.last_name, ''),
+	       u.role, u.is_active, u.email_verified, COALESCE(u.locked_until > NOW(), false),
+	       u.password_reset_required, u.created_at, u.updated_at
+	FROM Users u
+	LEFT JOIN Clinician c ON c.user_id = u.id AND u.role = 'clinician'
+	LEFT JOIN Patient p ON p.user_id = u.id AND u.role = 'patient'`
+
+func (r *PostgresAuthRepository) ListUsers(filter models.UserFilter) ([]models.UserSummary, int, error) {
+	where := " WHERE 1=1"
+	args := []interface{}{}
+	argPos := 1
+
+	if filter.Query != "" {
+		where += fmt.Sprintf(" AND (u.email ILIKE $%d OR COALESCE(c.full_name, p.full_name, '') ILIKE $%d)", argPos, argPos)
+		args = append(args, "%"+filter.Query+"%")
+		argPos++
+	}
+	if filter.Role != "" {
+		where += fmt.Sprintf(" AND u.role = $%d", argPos)
+		args = append(args, filter.Role)
+		argPos++
+	}
+	if filter.Active != nil {
+		where += fmt.Sprintf(" AND u.is_active = $%d", argPos)
+		args = append(args, *filter.Active)
+		argPos++
+	}
+
+	var total int
+	countQuery := `
+		SELECT COUNT(*)
+		FROM Users u
+		LEFT JOIN Clinician c ON c.user_id = u.id AND u.role = 'clinician'
+		LEFT JOIN Patient p ON p.user_id = u.id AND u.role = 'patient'` + where
+	if err := r.db.QueryRow(countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := userSummarySelect + where +
+		fmt.Sprintf(" ORDER BY u.email LIMIT $%d OFFSET $%d", argPos, argPos+1)
+	args = append(args, filter.GetLimit(), filter.GetOffset())
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	users := []models.UserSummary{}
+	for rows.Next() {
+		u, err := scanUserSummary(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		users = append(users, *u)
+	}
+
+	return users, total, rows.Err()
+}
+
+func (r *PostgresAuthRepository) GetUserSummary(userID int) (*models.UserSummary, error) {
+	u, err := scanUserSummary(r.db.QueryRow(userSummarySelect+" WHERE u.id = $1", userID))
+	if err == sql.ErrNoRows {
+		return nil, utils.ErrUserNotFound
+	}
+	return u, err
+}
+
+func scanUserSummary(row interface{ Scan(...any) error }) (*models.UserSummary, error) {
+	var u models.UserSummary
+	if err := row.Scan(&u.ID, &u.Email, &u.FirstName, &u.LastName, &u.Role, &u.IsActive,
+		&u.EmailVerified, &u.Locked, &u.PasswordResetRequired, &u.CreatedAt, &u.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// ------------------------------------------------------------
+// CHANGE USER ROLE (ADMIN)
+// ------------------------------------------------------------
+// ChangeUserRole sets a new role and, for clinician and patient, creates the
+// matching profile if the user does not have one yet. Existing profiles are
+// kept so records that reference them stay intact.
+func (r *PostgresAuthRepository) ChangeUserRole(userID int, role string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE Users
+		SET role = $1, updated_at = NOW()
+		WHERE id = $2
+	`, role, userID)
+	if err != nil {
+		return err
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return utils.ErrUserNotFound
+	}
+
+	var profileTable string
+	switch role {
+	case models.RolePatient:
+		profileTable = "Patient"
+	case models.RoleClinician:
+		profileTable = "Clinician"
+	default:
+		return tx.Commit()
+	}
+
+	var exists bool
+	if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM "+profileTable+" WHERE user_id = $1)", userID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		// Carry the name over from the user's other profile, if any
+		var firstName, lastName string
+		if err := tx.QueryRow(`
+			SELECT COALESCE((SELECT first_name FROM Clinician WHERE user_id = $1),
+			                (SELECT first_name FROM Patient WHERE user_id = $1), ''),
+			       COALESCE((SELECT last_name FROM Clinician WHERE user_id = $1),
+			                (SELECT last_name FROM Patient WHERE user_id = $1), '')
+		`, userID).Scan(&firstName, &lastName); err != nil {
+			return err
+		}
+
+		if err := insertProfile(tx, userID, role, firstName, lastName); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ------------------------------------------------------------
+// REQUIRE PASSWORD RESET (ADMIN)
+// ------------------------------------------------------------
+func (r *PostgresAuthRepository) SetPasswordResetRequired(userID int) error {
+	result, err := r.db.Exec(`
+		UPDATE Users
+		SET password_reset_required = true, updated_at = NOW()
+		WHERE id = $1
+	`, userID)
+	if err != nil {
+		return err
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return utils.ErrUserNotFound
+	}
+	return nil
+}
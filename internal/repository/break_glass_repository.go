@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// BreakGlassRepository stores emergency-access events and the reads made under them
+type BreakGlassRepository interface {
+	CreateEvent(userID int, reason, clientIP string, expiresAt time.Time) (int, error)
+	RecordAccess(eventID, patientID int, resource string) error
+	ListEvents(filter models.BreakGlassEventFilter) ([]models.BreakGlassEvent, int, error)
+	GetEvent(eventID int) (*models.BreakGlassEvent, error)
+	ReviewEvent(eventID, reviewerID int, notes string) error
+}
+
+// PostgresBreakGlassRepository is the BreakGlassRepository backed by the
+// break_glass_event and break_glass_access tables
+type PostgresBreakGlassRepository struct {
+	db *sql.DB
+}
+
+func NewBreakGlassRepository(db *sql.DB) *PostgresBreakGlassRepository {
+	return &PostgresBreakGlassRepository{db: db}
+}
+
+// ------------------------------------------------------------
+// CREATE BREAK-GLASS EVENT
+// ------------------------------------------------------------
+func (r *PostgresBreakGlassRepository) CreateEvent(userID int, reason, clientIP string, expiresAt time.Time) (int, error) {
+	var eventID int
+	err := r.db.QueryRow(`
+		INSERT INTO break_glass_event (user_id, reason, client_ip, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING event_id
+	`, userID, reason, clientIP, expiresAt).Scan(&eventID)
+	return eventID, err
+}
+
+// ------------------------------------------------------------
+// RECORD ACCESS MADE UNDER A BREAK-GLASS EVENT
+// ------------------------------------------------------------
+// RecordAccess logs a read; patientID 0 records an access not tied to one patient (e.g. a list)
+func (r *PostgresBreakGlassRepository) RecordAccess(eventID, patientID int, resource string) error {
+	_, err := r.db.Exec(`
+		INSERT INTO break_glass_access (event_id, patient_id, resource)
+		VALUES ($1, NULLIF($2, 0), $3)
+	`, eventID, patientID, resource)
+	return err
+}
+
+// ------------------------------------------------------------
+// LIST EVENTS FOR REVIEW
+// ------------------------------------------------------------
+func (r *PostgresBreakGlassRepository) ListEvents(filter models.BreakGlassEventFilter) ([]models.BreakGlassEvent, int, error) {
+	where := " WHERE 1=1"
+	args := []interface{}{}
+	argPos := 1
+
+	if filter.Unreviewed {
+		where += " AND e.reviewed_at IS NULL"
+	}
+	if filter.UserID != nil {
+		where += fmt.Sprintf(" AND e.user_id = $%d", argPos)
+		args = append(args, *filter.UserID)
+		argPos++
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM break_glass_event e"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := breakGlassEventSelect + where +
+		fmt.Sprintf(" ORDER BY e.started_at DESC LIMIT $%d OFFSET $%d", argPos, argPos+1)
+	args = append(args, filter.GetLimit(), filter.GetOffset())
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	events := []models.BreakGlassEvent{}
+	for rows.Next() {
+		e, err := scanBreakGlassEvent(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		events = append(events, *e)
+	}
+
+	return events, total, rows.Err()
+}
+
+// ------------------------------------------------------------
+// GET EVENT WITH ACCESS LOG
+// ------------------------------------------------------------
+func (r *PostgresBreakGlassRepository) GetEvent(eventID int) (*models.BreakGlassEvent, error) {
+	event, err := scanBreakGlassEvent(r.db.QueryRow(breakGlassEventSelect+" WHERE e.event_id = $1", eventID))
+	if err == sql.ErrNoRows {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(`
+		SELECT access_id, patient_id, resource, accessed_at
+		FROM break_glass_access
+		WHERE event_id = $1
+		ORDER BY accessed_at
+	`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	event.Accesses = []models.BreakGlassAccess{}
+	for rows.Next() {
+		var a models.BreakGlassAccess
+		var patientID sql.NullInt64
+		if err := rows.Scan(&a.AccessID, &patientID, &a.Resource, &a.AccessedAt); err != nil {
+			return nil, err
+		}
+		if patientID.Valid {
+			id := int(patientID.Int64)
+			a.PatientID = &id
+		}
+		event.Accesses = append(event.Accesses, a)
+	}
+
+	return event, rows.Err()
+}
+
+// ------------------------------------------------------------
+// MARK EVENT REVIEWED
+// ------------------------------------------------------------
+func (r *PostgresBreakGlassRepository) ReviewEvent(eventID, reviewerID int, notes string) error {
+	result, err := r.db.Exec(`
+		UPDATE break_glass_event
+		SET reviewed_by = $1, reviewed_at = NOW(), review_notes = $2
+		WHERE event_id = $3
+	`, reviewerID, notes, eventID)
+	if err != nil {
+		return err
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return utils.ErrNotFound
+	}
+	return nil
+}
+
+const breakGlassEventSelect = `
+	SELECT e.event_id, e.user_id, u.email, e.reason, COALESCE(e.client_ip, ''),
+	       e.started_at, e.expires_at, e.reviewed_by, e.reviewed_at, COALESCE(e.review_notes, ''),
+	       (SELECT COUNT(*) FROM break_glass_access a WHERE a.event_id = e.event_id)
+	FROM break_glass_event e
+	JOIN Users u ON u.id = e.user_id`
+
+func scanBreakGlassEvent(row interface{ Scan(...any) error }) (*models.BreakGlassEvent, error) {
+	var e models.BreakGlassEvent
+	var reviewedBy sql.NullInt64
+	var reviewedAt sql.NullTime
+
+	if err := row.Scan(&e.EventID, &e.UserID, &e.UserEmail, &e.Reason, &e.ClientIP,
+		&e.StartedAt, &e.ExpiresAt, &reviewedBy, &reviewedAt, &e.ReviewNotes, &e.AccessCount); err != nil {
+		return nil, err
+	}
+
+	if reviewedBy.Valid {
+		id := int(reviewedBy.Int64)
+		e.ReviewedBy = &id
+	}
+	e.ReviewedAt = models.NullTime{Time: reviewedAt.Time, Valid: reviewedAt.Valid}
+	return &e, nil
+}
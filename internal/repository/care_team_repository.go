@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// CareTeamRepository stores clinician-to-patient care-team assignments
+type CareTeamRepository interface {
+	ListByPatient(patientID int) ([]models.CareTeamAssignment, error)
+	GetAssignment(patientID, assignmentID int) (*models.CareTeamAssignment, error)
+	// HasOverlappingAssignment reports whether the clinician already has an
+	// assignment to the patient overlapping [startDate, endDate); a nil
+	// endDate is open-ended
+	HasOverlappingAssignment(patientID, clinicianID int, startDate time.Time, endDate *time.Time) (bool, error)
+	// Assign returns utils.ErrAlreadyAssigned if the new assignment overlaps
+	// an existing one for the same clinician and patient
+	Assign(patientID, clinicianID int, startDate time.Time, endDate *time.Time, assignedBy int) (int, error)
+	EndAssignment(patientID, assignmentID int) error
+	PatientExists(patientID int) (bool, error)
+	ClinicianExists(clinicianID int) (bool, error)
+}
+
+// PostgresCareTeamRepository is the CareTeamRepository backed by the
+// care_team_assignment table
+type PostgresCareTeamRepository struct {
+	db *sql.DB
+}
+
+func NewCareTeamRepository(db *sql.DB) *PostgresCareTeamRepository {
+	return &PostgresCareTeamRepository{db: db}
+}
+
+// exclusionViolation is the SQLSTATE raised by the care_team_no_overlap
+// constraint
+const exclusionViolation = "23P01"
+
+// activeAssignmentSQL is the predicate for an assignment that is in effect today
+const activeAssignmentSQL = `ct.start_date <= CURRENT_DATE AND (ct.end_date IS NULL OR ct.end_date > CURRENT_DATE)`
+
+// ------------------------------------------------------------
+// LIST CARE TEAM FOR PATIENT
+// ------------------------------------------------------------
+func (r *PostgresCareTeamRepository) ListByPatient(patientID int) ([]models.CareTeamAssignment, error) {
+	rows, err := r.db.Query(`
+		SELECT ct.assignment_id, ct.patient_id, ct.clinician_id, c.full_name,
+		       ct.start_date, ct.end_date, `+activeAssignmentSQL+`
+		FROM care_team_assignment ct
+		JOIN clinician c ON c.clinician_id = ct.clinician_id
+		WHERE ct.patient_id = $1
+		ORDER BY ct.start_date DESC, ct.assignment_id DESC
+	`, patientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assignments := []models.CareTeamAssignment{}
+	for rows.Next() {
+		a, err := scanCareTeamAssignment(rows)
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, *a)
+	}
+
+	return assignments, rows.Err()
+}
+
+// ------------------------------------------------------------
+// GET SINGLE ASSIGNMENT
+// ------------------------------------------------------------
+func (r *PostgresCareTeamRepository) GetAssignment(patientID, assignmentID int) (*models.CareTeamAssignment, error) {
+	row := r.db.QueryRow(`
+		SELECT ct.assignment_id, ct.patient_id, ct.clinician_id, c.full_name,
+		       ct.start_date, ct.end_date, `+activeAssignmentSQL+`
+		FROM care_team_assignment ct
+		JOIN clinician c ON c.clinician_id = ct.clinician_id
+		WHERE ct.patient_id = $1 AND ct.assignment_id = $2
+	`, patientID, assignmentID)
+
+	a, err := scanCareTeamAssignment(row)
+	if err == sql.ErrNoRows {
+		return nil, utils.ErrNotFound
+	}
+	return a, err
+}
+
+// ------------------------------------------------------------
+// CHECK OVERLAPPING ASSIGNMENT
+// ------------------------------------------------------------
+func (r *PostgresCareTeamRepository) HasOverlappingAssignment(patientID, clinicianID int, startDate time.Time, endDate *time.Time) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1 FROM care_team_assignment ct
+			WHERE ct.patient_id = $1 AND ct.clinician_id = $2
+			  AND daterange(ct.start_date, ct.end_date, '[)') && daterange($3::date, $4::date, '[)')
+		)
+	`, patientID, clinicianID, startDate, endDate).Scan(&exists)
+	return exists, err
+}
+
+// ------------------------------------------------------------
+// ASSIGN CLINICIAN TO PATIENT
+// ------------------------------------------------------------
+func (r *PostgresCareTeamRepository) Assign(patientID, clinicianID int, startDate time.Time, endDate *time.Time, assignedBy int) (int, error) {
+	var id int
+	err := r.db.QueryRow(`
+		INSERT INTO care_team_assignment (patient_id, clinician_id, start_date, end_date, assigned_by)
+		VALUES ($1, $2, $3, $4, NULLIF($5, 0))
+		RETURNING assignment_id
+	`, patientID, clinicianID, startDate, endDate, assignedBy).Scan(&id)
+
+	// The care_team_no_overlap constraint catches assignments racing past
+	// the service's HasOverlappingAssignment check
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == exclusionViolation {
+		return 0, utils.ErrAlreadyAssigned
+	}
+	return id, err
+}
+
+// ------------------------------------------------------------
+// END ASSIGNMENT (UNASSIGN)
+// ------------------------------------------------------------
+// EndAssignment closes an assignment as of today. Assignments that start in
+// the future are ended on their start date so the date range stays valid.
+func (r *PostgresCareTeamRepository) EndAssignment(patientID, assignmentID int) error {
+	result, err := r.db.Exec(`
+		UPDATE care_team_assignment
+		SET end_date = GREATEST(start_date, CURRENT_DATE)
+		WHERE patient_id = $1 AND assignment_id = $2
+		  AND (end_date IS NULL OR end_date > CURRENT_DATE)
+	`, patientID, assignmentID)
+	if err != nil {
+		return err
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return utils.ErrNotFound
+	}
+	return nil
+}
+
+// ------------------------------------------------------------
+// EXISTENCE CHECKS
+// ------------------------------------------------------------
+func (r *PostgresCareTeamRepository) PatientExists(patientID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM patient WHERE patient_id = $1 AND deleted_at IS NULL)", patientID).Scan(&exists)
+	return exists, err
+}
+
+func (r *PostgresCareTeamRepository) ClinicianExists(clinicianID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM clinician WHERE clinician_id = $1)", clinicianID).Scan(&exists)
+	return exists, err
+}
+
+func scanCareTeamAssignment(row interface{ Scan(...any) error }) (*models.CareTeamAssignment, error) {
+	var a models.CareTeamAssignment
+	var endDate sql.NullTime
+
+	if err := row.Scan(&a.AssignmentID, &a.PatientID, &a.ClinicianID, &a.ClinicianName,
+		&a.StartDate, &endDate, &a.Active); err != nil {
+		return nil, err
+	}
+
+	a.EndDate = models.NullTime{Time: endDate.Time, Valid: endDate.Valid}
+	return &a, nil
+}
+
+// CareTeamPatientFilter returns a SQL predicate restricting column to the
+// patients on the active care team of the clinician linked to user $argPos
+func CareTeamPatientFilter(column string, argPos int) string {
+	return fmt.Sprintf(`%s IN (
+		SELECT ct.patient_id
+		FROM care_team_assignment ct
+		JOIN clinician c ON c.clinician_id = ct.clinician_id
+		WHERE c.user_id = $%d AND %s
+	)`, column, argPos, activeAssignmentSQL)
+}
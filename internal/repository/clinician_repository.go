@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// ClinicianRepository stores clinician profiles
+type ClinicianRepository interface {
+	// ListClinicians returns a page of clinicians ordered by name and the total count
+	ListClinicians(limit, offset int) ([]models.Clinician, int, error)
+	GetClinician(clinicianID int) (*models.Clinician, error)
+	CreateClinician(cl models.Clinician) (int, error)
+	UpdateClinician(cl models.Clinician) error
+	// DeleteClinician removes a clinician and their care-team assignments.
+	// Assessments they charted, deleted or not, are medical records and block
+	// the delete in either mode with a *utils.DependentsError.
+	DeleteClinician(clinicianID int, mode models.DeleteMode) (*models.DeleteResult, error)
+	ClinicianExists(clinicianID int) (bool, error)
+}
+
+// PostgresClinicianRepository is the ClinicianRepository backed by the clinician table
+type PostgresClinicianRepository struct {
+	db *sql.DB
+}
+
+func NewClinicianRepository(db *sql.DB) *PostgresClinicianRepository {
+	return &PostgresClinicianRepository{db: db}
+}
+
+const clinicianSelect = `
+	SELECT clinician_id, full_name, role, department, contact_info, license_number
+	FROM clinician`
+
+// ------------------------------------------------------------
+// LIST CLINICIANS
+// ------------------------------------------------------------
+func (r *PostgresClinicianRepository) ListClinicians(limit, offset int) ([]models.Clinician, int, error) {
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM clinician").Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(clinicianSelect+" ORDER BY full_name LIMIT $1 OFFSET $2", limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	clinicians := []models.Clinician{}
+	for rows.Next() {
+		cl, err := scanClinician(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		clinicians = append(clinicians, *cl)
+	}
+
+	return clinicians, total, rows.Err()
+}
+
+// ------------------------------------------------------------
+// GET CLINICIAN
+// ------------------------------------------------------------
+func (r *PostgresClinicianRepository) GetClinician(clinicianID int) (*models.Clinician, error) {
+	cl, err := scanClinician(r.db.QueryRow(clinicianSelect+" WHERE clinician_id = $1", clinicianID))
+	if err == sql.ErrNoRows {
+		return nil, utils.ErrNotFound
+	}
+	return cl, err
+}
+
+// ------------------------------------------------------------
+// CREATE CLINICIAN
+// ------------------------------------------------------------
+func (r *PostgresClinicianRepository) CreateClinician(cl models.Clinician) (int, error) {
+	var id int
+	err := r.db.QueryRow(`
+		INSERT INTO clinician (full_name, role, department, contact_info, license_number)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING clinician_id
+	`, cl.FullName, cl.Role, cl.Department, cl.ContactInfo, cl.LicenseNumber).Scan(&id)
+	return id, err
+}
+
+// ------------------------------------------------------------
+// UPDATE CLINICIAN
+// ------------------------------------------------------------
+func (r *PostgresClinicianRepository) UpdateClinician(cl models.Clinician) error {
+	result, err := r.db.Exec(`
+		UPDATE clinician
+		SET full_name = $1, role = $2, department = $3, contact_info = $4, license_number = $5
+		WHERE clinician_id = $6
+	`, cl.FullName, cl.Role, cl.Department, cl.ContactInfo, cl.LicenseNumber, cl.ClinicianID)
+	if err != nil {
+		return err
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return utils.ErrNotFound
+	}
+	return nil
+}
+
+// ------------------------------------------------------------
+// DELETE CLINICIAN
+// ------------------------------------------------------------
+func (r *PostgresClinicianRepository) DeleteClinician(clinicianID int, mode models.DeleteMode) (*models.DeleteResult, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockRow(tx, "clinician", "clinician_id", clinicianID); err != nil {
+		return nil, err
+	}
+
+	n, err := countRows(tx, "assessment", "clinician_id = $1", clinicianID)
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 {
+		return nil, &utils.DependentsError{Dependents: map[string]int{"assessment": n}}
+	}
+
+	result := models.NewDeleteResult(mode)
+	if err := deleteRows(tx, result, "care_team_assignment",
+		"DELETE FROM care_team_assignment WHERE clinician_id = $1", clinicianID); err != nil {
+		return nil, err
+	}
+	if err := deleteRows(tx, result, "clinician", "DELETE FROM clinician WHERE clinician_id = $1", clinicianID); err != nil {
+		return nil, err
+	}
+	return result, tx.Commit()
+}
+
+// ------------------------------------------------------------
+// EXISTENCE CHECK
+// ------------------------------------------------------------
+func (r *PostgresClinicianRepository) ClinicianExists(clinicianID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM clinician WHERE clinician_id = $1)", clinicianID).Scan(&exists)
+	return exists, err
+}
+
+func scanClinician(row interface{ Scan(...any) error }) (*models.Clinician, error) {
+	var cl models.Clinician
+	if err := row.Scan(&cl.ClinicianID, &cl.FullName, &cl.Role, &cl.Department, &cl.ContactInfo, &cl.LicenseNumber); err != nil {
+		return nil, err
+	}
+	return &cl, nil
+}
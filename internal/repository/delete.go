@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// Helpers shared by the transactional deletes and restores of patients,
+// clinicians and assessments. Table and column names are constants, never
+// user input.
+
+// lockRow locks the row of table whose key column equals id for the rest of
+// tx, returning utils.ErrNotFound if there is none
+func lockRow(tx *sql.Tx, table, key string, id int) error {
+	var found int
+	err := tx.QueryRow("SELECT "+key+" FROM "+table+" WHERE "+key+" = $1 FOR UPDATE", id).Scan(&found)
+	if err == sql.ErrNoRows {
+		return utils.ErrNotFound
+	}
+	return err
+}
+
+// lockActiveRow is lockRow for a soft-deletable table; a deleted row counts
+// as missing
+func lockActiveRow(tx *sql.Tx, table, key string, id int) error {
+	var found int
+	err := tx.QueryRow("SELECT "+key+" FROM "+table+" WHERE "+key+" = $1 AND deleted_at IS NULL FOR UPDATE", id).Scan(&found)
+	if err == sql.ErrNoRows {
+		return utils.ErrNotFound
+	}
+	return err
+}
+
+// softDelete marks the live rows of table matching cond ($1 = arg) as
+// deleted by deletedBy (0 if unknown) for reason, returning their keys
+func softDelete(tx *sql.Tx, table, key, cond string, arg any, deletedBy int, reason string) ([]int, error) {
+	return collectIDs(tx.Query(`
+		UPDATE `+table+`
+		SET deleted_at = NOW(), deleted_by = NULLIF($2, 0), delete_reason = $3
+		WHERE `+cond+` AND deleted_at IS NULL
+		RETURNING `+key, arg, deletedBy, reason))
+}
+
+// restore clears the deletion marks of the deleted rows of table matching
+// cond, returning their keys
+func restore(tx *sql.Tx, table, key, cond string, args ...any) ([]int, error) {
+	return collectIDs(tx.Query(`
+		UPDATE `+table+`
+		SET deleted_at = NULL, deleted_by = NULL, delete_reason = ''
+		WHERE `+cond+` AND deleted_at IS NOT NULL
+		RETURNING `+key, args...))
+}
+
+// collectIDs reads a single integer column, sorted ascending
+func collectIDs(rows *sql.Rows, err error) ([]int, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids, rows.Err()
+}
+
+// nullIntPtr converts a nullable integer column to *int
+func nullIntPtr(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	id := int(v.Int64)
+	return &id
+}
+
+// deleteRows runs a DELETE inside tx and adds the rows removed to result
+func deleteRows(tx *sql.Tx, result *models.DeleteResult, table, query string, args ...any) error {
+	res, err := tx.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", table, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	result.Add(table, int(n))
+	return nil
+}
+
+// countRows returns the number of rows in table matching cond
+func countRows(tx *sql.Tx, table, cond string, arg any) (int, error) {
+	var n int
+	err := tx.QueryRow("SELECT COUNT(*) FROM "+table+" WHERE "+cond, arg).Scan(&n)
+	return n, err
+}
@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// AccessRepository is the in-memory repository.AccessRepository
+type AccessRepository struct {
+	s *Store
+}
+
+var _ repository.AccessRepository = (*AccessRepository)(nil)
+
+func (r *AccessRepository) GetPatientIDByUserID(userID int) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	p := r.s.patientByUser(userID)
+	if p == nil || p.deleted != nil {
+		return 0, utils.ErrNotFound
+	}
+	return p.PatientID, nil
+}
+
+func (r *AccessRepository) GetClinicianIDByUserID(userID int) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	c := r.s.clinicianByUser(userID)
+	if c == nil {
+		return 0, utils.ErrNotClinician
+	}
+	return c.ClinicianID, nil
+}
+
+func (r *AccessRepository) IsOnCareTeam(userID, patientID int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.onCareTeam(userID, patientID), nil
+}
+
+// onCareTeam reports whether the clinician linked to userID holds an active
+// assignment for the patient
+func (s *Store) onCareTeam(userID, patientID int) bool {
+	c := s.clinicianByUser(userID)
+	if c == nil {
+		return false
+	}
+
+	now := today()
+	for _, a := range s.careTeam {
+		if a.patientID == patientID && a.clinicianID == c.ClinicianID && a.active(now) {
+			return true
+		}
+	}
+	return false
+}
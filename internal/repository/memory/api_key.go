@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// APIKeyRepository is the in-memory repository.APIKeyRepository
+type APIKeyRepository struct {
+	s *Store
+}
+
+var _ repository.APIKeyRepository = (*APIKeyRepository)(nil)
+
+// apiKeyLastUsedInterval matches the Postgres repository
+const apiKeyLastUsedInterval = time.Minute
+
+func (r *APIKeyRepository) CreateAPIKey(name, prefix, keyHash string, permissions []string, createdBy int, expiresAt *time.Time) (*models.APIKey, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	k := &apiKeyRow{
+		APIKey: models.APIKey{
+			ID:          r.s.id(),
+			Name:        name,
+			Prefix:      prefix,
+			Permissions: append([]string{}, permissions...),
+			CreatedAt:   time.Now(),
+		},
+		hash: keyHash,
+	}
+	if createdBy != 0 {
+		k.CreatedBy = &createdBy
+	}
+	if expiresAt != nil {
+		k.ExpiresAt = models.NullTime{Time: *expiresAt, Valid: true}
+	}
+	r.s.apiKeys[k.ID] = k
+
+	key := k.APIKey
+	return &key, nil
+}
+
+func (r *APIKeyRepository) GetAPIKey(id int) (*models.APIKey, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	k, ok := r.s.apiKeys[id]
+	if !ok {
+		return nil, utils.ErrNotFound
+	}
+	key := k.APIKey
+	return &key, nil
+}
+
+func (r *APIKeyRepository) GetAPIKeyByHash(keyHash string) (*models.APIKey, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, k := range r.s.apiKeys {
+		if k.hash == keyHash {
+			key := k.APIKey
+			return &key, nil
+		}
+	}
+	return nil, utils.ErrInvalidAPIKey
+}
+
+func (r *APIKeyRepository) ListAPIKeys() ([]models.APIKey, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	keys := []models.APIKey{}
+	for _, k := range r.s.apiKeys {
+		keys = append(keys, k.APIKey)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].CreatedAt.After(keys[j].CreatedAt)
+		}
+		return keys[i].ID > keys[j].ID
+	})
+	return keys, nil
+}
+
+func (r *APIKeyRepository) TouchAPIKey(id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	if k, ok := r.s.apiKeys[id]; ok &&
+		(!k.LastUsedAt.Valid || k.LastUsedAt.Time.Before(now.Add(-apiKeyLastUsedInterval))) {
+		k.LastUsedAt = models.NullTime{Time: now, Valid: true}
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) RevokeAPIKey(id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	k, ok := r.s.apiKeys[id]
+	if !ok || k.RevokedAt.Valid {
+		return utils.ErrNotFound
+	}
+	k.RevokedAt = models.NullTime{Time: time.Now(), Valid: true}
+	return nil
+}
@@ -0,0 +1,347 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// AssessmentRepository is the in-memory repository.AssessmentRepository
+type AssessmentRepository struct {
+	s *Store
+}
+
+var _ repository.AssessmentRepository = (*AssessmentRepository)(nil)
+
+func (r *AssessmentRepository) ListAssessments(q models.AssessmentQuery) ([]models.AssessmentListItem, int, error) {
+	s := r.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := []models.AssessmentListItem{}
+	for _, a := range s.assessments {
+		if a.deleted != nil {
+			continue
+		}
+		if q.PatientID != nil && a.PatientID != *q.PatientID {
+			continue
+		}
+		if q.ClinicianID != nil && a.ClinicianID != *q.ClinicianID {
+			continue
+		}
+		if q.StartDate != nil && a.Date.Before(*q.StartDate) {
+			continue
+		}
+		if q.EndDate != nil && a.Date.After(*q.EndDate) {
+			continue
+		}
+		if q.CareTeamUserID != 0 && !s.onCareTeam(q.CareTeamUserID, a.PatientID) {
+			continue
+		}
+
+		p, pok := s.patients[a.PatientID]
+		c, cok := s.clinicians[a.ClinicianID]
+		if !pok || !cok {
+			continue
+		}
+		items = append(items, models.AssessmentListItem{
+			AssessmentID:  a.AssessmentID,
+			Date:          a.Date,
+			PatientID:     a.PatientID,
+			PatientName:   p.FullName,
+			ClinicianID:   a.ClinicianID,
+			ClinicianName: c.FullName,
+			Location:      a.Location,
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].Date.Equal(items[j].Date) {
+			return items[i].Date.After(items[j].Date)
+		}
+		return items[i].AssessmentID > items[j].AssessmentID
+	})
+	start, end := paginate(len(items), q.Limit, q.Offset)
+	return items[start:end], len(items), nil
+}
+
+func (r *AssessmentRepository) GetAssessment(assessmentID int) (*models.Assessment, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	a, ok := r.s.liveAssessment(assessmentID)
+	if !ok {
+		return nil, utils.ErrNotFound
+	}
+	assessment := a.Assessment
+	return &assessment, nil
+}
+
+func (r *AssessmentRepository) CreateAssessment(req models.CreateAssessmentRequest) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	_, id, err := r.s.insertAssessment(req)
+	return id, err
+}
+
+func (r *AssessmentRepository) CreateFullAssessment(req models.FullAssessmentRequest) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, id, err := r.s.insertAssessment(req.CreateAssessmentRequest)
+	if err != nil {
+		return 0, err
+	}
+
+	row.saveSections(req.Sections())
+	return id, nil
+}
+
+func (s *Store) insertAssessment(req models.CreateAssessmentRequest) (*assessmentRow, int, error) {
+	// Foreign keys on patient_id and clinician_id
+	if _, ok := s.patients[req.PatientID]; !ok {
+		return nil, 0, utils.ErrInvalidPatient
+	}
+	if _, ok := s.clinicians[req.ClinicianID]; !ok {
+		return nil, 0, utils.ErrInvalidClinician
+	}
+
+	id := s.id()
+	row := &assessmentRow{
+		Assessment: models.Assessment{
+			AssessmentID:   id,
+			ClinicianID:    req.ClinicianID,
+			PatientID:      req.PatientID,
+			Date:           time.Now(),
+			Location:       req.Location,
+			Etiology:       req.Etiology,
+			DepthOfInjury:  req.DepthOfInjury,
+			Stage:          req.Stage,
+			Chronicity:     req.Chronicity,
+			HealingStatus:  req.HealingStatus,
+			ReturnToClinic: req.ReturnToClinic,
+		},
+	}
+	s.assessments[id] = row
+	return row, id, nil
+}
+
+func (r *AssessmentRepository) UpdateAssessment(a models.Assessment) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.liveAssessment(a.AssessmentID)
+	if !ok {
+		return utils.ErrNotFound
+	}
+	row.setHeader(a)
+	return nil
+}
+
+// setHeader copies the descriptive columns of a; the IDs and date are fixed
+func (row *assessmentRow) setHeader(a models.Assessment) {
+	row.Location = a.Location
+	row.Etiology = a.Etiology
+	row.DepthOfInjury = a.DepthOfInjury
+	row.Stage = a.Stage
+	row.Chronicity = a.Chronicity
+	row.HealingStatus = a.HealingStatus
+	row.ReturnToClinic = a.ReturnToClinic
+}
+
+func (r *AssessmentRepository) SaveSections(assessmentID int, sections models.AssessmentSections) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.liveAssessment(assessmentID)
+	if !ok {
+		return utils.ErrNotFound
+	}
+	row.saveSections(sections)
+	return nil
+}
+
+func (r *AssessmentRepository) ReplaceFullAssessment(a models.Assessment, sections models.AssessmentSections) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.liveAssessment(a.AssessmentID)
+	if !ok {
+		return utils.ErrNotFound
+	}
+	if _, ok := r.s.clinicians[a.ClinicianID]; !ok {
+		return utils.ErrInvalidClinician
+	}
+	row.ClinicianID = a.ClinicianID
+	row.setHeader(a)
+	row.saveSections(sections)
+	return nil
+}
+
+// saveSections replaces the non-nil sections in sections
+func (row *assessmentRow) saveSections(sections models.AssessmentSections) {
+	sections = cloneSections(sections)
+	if sections.InfectionPain != nil {
+		row.sections.InfectionPain = sections.InfectionPain
+	}
+	if sections.TissueStatus != nil {
+		row.sections.TissueStatus = sections.TissueStatus
+	}
+	if sections.Vitals != nil {
+		row.sections.Vitals = sections.Vitals
+	}
+	if sections.WoundCondition != nil {
+		row.sections.WoundCondition = sections.WoundCondition
+	}
+	if sections.Exudate != nil {
+		row.sections.Exudate = sections.Exudate
+	}
+	if sections.Treatment != nil {
+		row.sections.Treatment = sections.Treatment
+	}
+}
+
+func (r *AssessmentRepository) DeleteAssessment(assessmentID, deletedBy int, reason string) (*models.DeleteResult, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	a, ok := r.s.liveAssessment(assessmentID)
+	if !ok {
+		return nil, utils.ErrNotFound
+	}
+
+	a.deleted = deletion(deletedBy, reason)
+	result := models.NewDeleteResult(models.DeleteCascade)
+	result.Add("assessment", 1)
+	result.AssessmentIDs = append(result.AssessmentIDs, assessmentID)
+	return result, nil
+}
+
+// ListDeletedAssessments returns deleted assessments, most recently deleted first
+func (r *AssessmentRepository) ListDeletedAssessments(limit, offset int) ([]models.DeletedAssessment, int, error) {
+	s := r.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := []models.DeletedAssessment{}
+	for _, a := range s.assessments {
+		if a.deleted == nil {
+			continue
+		}
+
+		p, pok := s.patients[a.PatientID]
+		c, cok := s.clinicians[a.ClinicianID]
+		if !pok || !cok {
+			continue
+		}
+		items = append(items, models.DeletedAssessment{
+			AssessmentListItem: models.AssessmentListItem{
+				AssessmentID:  a.AssessmentID,
+				Date:          a.Date,
+				PatientID:     a.PatientID,
+				PatientName:   p.FullName,
+				ClinicianID:   a.ClinicianID,
+				ClinicianName: c.FullName,
+				Location:      a.Location,
+			},
+			DeletionInfo: *a.deleted,
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].DeletedAt.Equal(items[j].DeletedAt) {
+			return items[i].DeletedAt.After(items[j].DeletedAt)
+		}
+		return items[i].AssessmentID > items[j].AssessmentID
+	})
+	start, end := paginate(len(items), limit, offset)
+	return items[start:end], len(items), nil
+}
+
+func (r *AssessmentRepository) RestoreAssessment(assessmentID int) (*models.RestoreResult, error) {
+	s := r.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.assessments[assessmentID]
+	if !ok || a.deleted == nil {
+		return nil, utils.ErrNotFound
+	}
+	if _, ok := s.livePatient(a.PatientID); !ok {
+		return nil, utils.ErrPatientDeleted
+	}
+
+	a.deleted = nil
+	result := models.NewRestoreResult()
+	result.Add("assessment", 1)
+	result.AssessmentIDs = append(result.AssessmentIDs, assessmentID)
+	return result, nil
+}
+
+// countAssessments returns how many assessments match match
+func (s *Store) countAssessments(match func(*assessmentRow) bool) int {
+	n := 0
+	for _, a := range s.assessments {
+		if match(a) {
+			n++
+		}
+	}
+	return n
+}
+
+// deleteCareTeam removes the care-team rows matching match, recording them in result
+func (s *Store) deleteCareTeam(match func(*careTeamRow) bool, result *models.DeleteResult) {
+	for id, a := range s.careTeam {
+		if match(a) {
+			delete(s.careTeam, id)
+			result.Add("care_team_assignment", 1)
+		}
+	}
+}
+
+func (r *AssessmentRepository) GetFullAssessment(assessmentID int) (*models.FullAssessmentResponse, error) {
+	s := r.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.liveAssessment(assessmentID)
+	if !ok {
+		return nil, utils.ErrNotFound
+	}
+
+	result := models.FullAssessmentResponse{
+		AssessmentID:       a.AssessmentID,
+		AssessmentDate:     a.Date,
+		PatientID:          a.PatientID,
+		ClinicianID:        a.ClinicianID,
+		Location:           a.Location,
+		Etiology:           a.Etiology,
+		DepthOfInjury:      a.DepthOfInjury,
+		Stage:              a.Stage,
+		Chronicity:         a.Chronicity,
+		HealingStatus:      a.HealingStatus,
+		ReturnToClinic:     a.ReturnToClinic,
+		AssessmentSections: cloneSections(a.sections),
+	}
+	if p, ok := s.patients[a.PatientID]; ok {
+		result.PatientName = p.FullName
+	}
+	if c, ok := s.clinicians[a.ClinicianID]; ok {
+		result.ClinicianName = c.FullName
+	}
+	return &result, nil
+}
+
+func cloneSections(s models.AssessmentSections) models.AssessmentSections {
+	return models.AssessmentSections{
+		InfectionPain:  clone(s.InfectionPain),
+		TissueStatus:   clone(s.TissueStatus),
+		Vitals:         clone(s.Vitals),
+		WoundCondition: clone(s.WoundCondition),
+		Exudate:        clone(s.Exudate),
+		Treatment:      clone(s.Treatment),
+	}
+}
@@ -0,0 +1,854 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// AuthRepository is the in-memory repository.AuthRepository
+type AuthRepository struct {
+	s *Store
+}
+
+var _ repository.AuthRepository = (*AuthRepository)(nil)
+
+// maxUserAgentLength matches the Postgres repository
+const maxUserAgentLength = 512
+
+func (r *AuthRepository) CreateUser(email, password, role, firstName, lastName string) (*models.User, error) {
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	s := r.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if role != models.RolePatient && role != models.RoleClinician {
+		return nil, errors.New("invalid role specified")
+	}
+	if s.findUserByEmail(email) != nil {
+		return nil, fmt.Errorf("duplicate key value violates unique constraint on email %q", email)
+	}
+
+	now := time.Now()
+	u := &userRow{User: models.User{
+		ID:                s.id(),
+		Email:             email,
+		PasswordHash:      hashedPassword,
+		Role:              role,
+		IsActive:          true,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+		PasswordChangedAt: now,
+	}}
+	s.users[u.ID] = u
+	s.insertProfile(u.ID, role, firstName, lastName)
+
+	user := u.User
+	return &user, nil
+}
+
+// insertProfile mirrors the placeholder profile created by the Postgres repository
+func (s *Store) insertProfile(userID int, role, firstName, lastName string) {
+	fullName := firstName + " " + lastName
+
+	switch role {
+	case models.RolePatient:
+		id := s.id()
+		s.patients[id] = &patientRow{
+			Patient: models.Patient{
+				PatientID:           id,
+				FullName:            fullName,
+				DateOfBirth:         time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC),
+				Gender:              "Unknown",
+				MedicalRecordNumber: "MRN-" + fmt.Sprint(userID),
+			},
+			userID: userID, firstName: firstName, lastName: lastName,
+		}
+	case models.RoleClinician:
+		id := s.id()
+		s.clinicians[id] = &clinicianRow{
+			Clinician: models.Clinician{
+				ClinicianID:   id,
+				FullName:      fullName,
+				Role:          "Clinician",
+				Department:    "General Medicine",
+				ContactInfo:   "Not Provided",
+				LicenseNumber: "LIC-" + fmt.Sprint(userID),
+			},
+			userID: userID, firstName: firstName, lastName: lastName,
+		}
+	}
+}
+
+func (s *Store) findUserByEmail(email string) *userRow {
+	for _, u := range s.users {
+		if u.Email == email {
+			return u
+		}
+	}
+	return nil
+}
+
+func (s *Store) patientByUser(userID int) *patientRow {
+	for _, p := range s.patients {
+		if p.userID == userID {
+			return p
+		}
+	}
+	return nil
+}
+
+func (s *Store) clinicianByUser(userID int) *clinicianRow {
+	for _, c := range s.clinicians {
+		if c.userID == userID {
+			return c
+		}
+	}
+	return nil
+}
+
+// profileNames returns the names on the profile matching the user's role
+func (s *Store) profileNames(u *userRow) (string, string, bool) {
+	switch u.Role {
+	case models.RolePatient:
+		if p := s.patientByUser(u.ID); p != nil {
+			return p.firstName, p.lastName, true
+		}
+	case models.RoleClinician:
+		if c := s.clinicianByUser(u.ID); c != nil {
+			return c.firstName, c.lastName, true
+		}
+	}
+	return "", "", false
+}
+
+func (r *AuthRepository) GetUserByEmail(email string) (*models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	u := r.s.findUserByEmail(email)
+	if u == nil {
+		return nil, utils.ErrUserNotFound
+	}
+	user := u.User
+	return &user, nil
+}
+
+func (r *AuthRepository) GetUserByID(userID int) (*models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.getUser(userID)
+}
+
+func (s *Store) getUser(userID int) (*models.User, error) {
+	u, ok := s.users[userID]
+	if !ok {
+		return nil, utils.ErrUserNotFound
+	}
+	user := u.User
+	return &user, nil
+}
+
+func (r *AuthRepository) GetUserWithProfile(userID int) (*models.UserWithProfile, error) {
+	s := r.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("user not found: %w", utils.ErrUserNotFound)
+	}
+
+	profile := models.UserWithProfile{
+		ID:            u.ID,
+		Email:         u.Email,
+		Role:          u.Role,
+		IsActive:      u.IsActive,
+		EmailVerified: u.EmailVerified,
+		CreatedAt:     u.CreatedAt,
+		UpdatedAt:     u.UpdatedAt,
+	}
+
+	switch u.Role {
+	case models.RoleAdmin:
+		return &profile, nil
+	case models.RolePatient, models.RoleClinician:
+		first, last, ok := s.profileNames(u)
+		if !ok {
+			return nil, fmt.Errorf("%s profile not found for user_id %d", u.Role, userID)
+		}
+		profile.FirstName, profile.LastName = first, last
+		return &profile, nil
+	default:
+		return nil, fmt.Errorf("unknown role: %s", u.Role)
+	}
+}
+
+func (r *AuthRepository) SaveRefreshToken(userID int, tokenHash, familyID string, expiresAt time.Time, client models.ClientInfo) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.insertRefreshToken(userID, tokenHash, familyID, expiresAt, client)
+	return nil
+}
+
+func (s *Store) insertRefreshToken(userID int, tokenHash, familyID string, expiresAt time.Time, client models.ClientInfo) int {
+	client.UserAgent = utils.TruncateUTF8(client.UserAgent, maxUserAgentLength)
+
+	id := s.id()
+	s.refreshTokens[id] = &refreshTokenRow{
+		RefreshToken: models.RefreshToken{
+			ID:        id,
+			UserID:    userID,
+			TokenHash: tokenHash,
+			FamilyID:  familyID,
+			ExpiresAt: expiresAt,
+			CreatedAt: time.Now(),
+		},
+		client: client,
+	}
+	return id
+}
+
+func (r *AuthRepository) GetRefreshToken(tokenHash string) (*models.RefreshToken, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, t := range r.s.refreshTokens {
+		if t.TokenHash == tokenHash {
+			rt := t.RefreshToken
+			return &rt, nil
+		}
+	}
+	return nil, utils.ErrInvalidToken
+}
+
+func (r *AuthRepository) RotateRefreshToken(old *models.RefreshToken, newTokenHash string, expiresAt time.Time, client models.ClientInfo) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	t, ok := r.s.refreshTokens[old.ID]
+	if !ok {
+		return utils.ErrInvalidToken
+	}
+	if t.Revoked {
+		if t.Rotated {
+			return utils.ErrTokenReused
+		}
+		return utils.ErrInvalidToken
+	}
+
+	t.Revoked = true
+	t.Rotated = true
+	r.s.insertRefreshToken(old.UserID, newTokenHash, old.FamilyID, expiresAt, client)
+	return nil
+}
+
+func (r *AuthRepository) RevokeTokenFamily(familyID string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, t := range r.s.refreshTokens {
+		if t.FamilyID == familyID {
+			t.Revoked = true
+		}
+	}
+	return nil
+}
+
+func (r *AuthRepository) RecordSecurityEvent(userID int, eventType, detail string, client models.ClientInfo) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.securityEvents = append(r.s.securityEvents, SecurityEvent{UserID: userID, EventType: eventType, Detail: detail})
+	return nil
+}
+
+func (r *AuthRepository) RevokeAllUserTokens(userID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.revokeUserTokens(userID)
+	return nil
+}
+
+func (s *Store) revokeUserTokens(userID int) {
+	for _, t := range s.refreshTokens {
+		if t.UserID == userID {
+			t.Revoked = true
+		}
+	}
+}
+
+func (r *AuthRepository) ListSessions(userID int) ([]models.Session, error) {
+	s := r.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	sessions := []models.Session{}
+	for _, t := range s.refreshTokens {
+		if t.UserID != userID || t.Revoked || !t.ExpiresAt.After(now) {
+			continue
+		}
+
+		loginAt := t.CreatedAt
+		for _, f := range s.refreshTokens {
+			if f.FamilyID == t.FamilyID && f.CreatedAt.Before(loginAt) {
+				loginAt = f.CreatedAt
+			}
+		}
+
+		sessions = append(sessions, models.Session{
+			ID:          t.ID,
+			UserAgent:   t.client.UserAgent,
+			ClientIP:    t.client.IP,
+			CreatedAt:   loginAt,
+			RefreshedAt: t.CreatedAt,
+			ExpiresAt:   t.ExpiresAt,
+		})
+	}
+
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].ID > sessions[j].ID })
+	return sessions, nil
+}
+
+func (r *AuthRepository) RevokeSession(userID, sessionID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	t, ok := r.s.refreshTokens[sessionID]
+	if !ok || t.UserID != userID || t.Revoked || !t.ExpiresAt.After(time.Now()) {
+		return utils.ErrNotFound
+	}
+
+	for _, f := range r.s.refreshTokens {
+		if f.FamilyID == t.FamilyID {
+			f.Revoked = true
+		}
+	}
+	return nil
+}
+
+func (r *AuthRepository) SetUserActive(userID int, active bool) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	u, ok := r.s.users[userID]
+	if !ok {
+		return utils.ErrUserNotFound
+	}
+	u.IsActive = active
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+func (r *AuthRepository) UpdatePassword(userID int, newPassword string) error {
+	hashedPassword, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.setPassword(userID, hashedPassword)
+	return nil
+}
+
+// setPassword archives the current hash and stores the new one
+func (s *Store) setPassword(userID int, hashedPassword string) {
+	u, ok := s.users[userID]
+	if !ok {
+		return
+	}
+
+	s.passwordHistory[userID] = append(s.passwordHistory[userID], u.PasswordHash)
+	now := time.Now()
+	u.PasswordHash = hashedPassword
+	u.PasswordChangedAt = now
+	u.PasswordResetRequired = false
+	u.FailedLoginAttempts = 0
+	u.LockedUntil = models.NullTime{}
+	u.UpdatedAt = now
+}
+
+func (r *AuthRepository) ReplacePasswordHash(userID int, oldHash, newHash string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if u, ok := r.s.users[userID]; ok && u.PasswordHash == oldHash {
+		u.PasswordHash = newHash
+	}
+	return nil
+}
+
+func (r *AuthRepository) GetPasswordHistory(userID, n int) ([]string, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var hashes []string
+	if u, ok := r.s.users[userID]; ok {
+		hashes = append(hashes, u.PasswordHash)
+	}
+	history := r.s.passwordHistory[userID]
+	for i := len(history) - 1; i >= 0 && len(hashes) < n; i-- {
+		hashes = append(hashes, history[i])
+	}
+	return hashes, nil
+}
+
+func (r *AuthRepository) EmailExists(email string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.findUserByEmail(email) != nil, nil
+}
+
+func (r *AuthRepository) SaveEmailVerificationToken(userID int, tokenHash string, expiresAt time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	saveOneTimeToken(r.s.emailTokens, userID, tokenHash, expiresAt)
+	return nil
+}
+
+// saveOneTimeToken discards the user's earlier unused tokens and stores the new one
+func saveOneTimeToken(tokens map[string]*oneTimeToken, userID int, tokenHash string, expiresAt time.Time) {
+	for hash, t := range tokens {
+		if t.userID == userID && !t.used {
+			delete(tokens, hash)
+		}
+	}
+	tokens[tokenHash] = &oneTimeToken{userID: userID, expiresAt: expiresAt}
+}
+
+func (r *AuthRepository) VerifyEmail(tokenHash string) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	t, ok := r.s.emailTokens[tokenHash]
+	if !ok || !t.valid(time.Now()) {
+		return 0, utils.ErrInvalidToken
+	}
+	t.used = true
+
+	if u, ok := r.s.users[t.userID]; ok {
+		u.EmailVerified = true
+		u.UpdatedAt = time.Now()
+	}
+	return t.userID, nil
+}
+
+func (r *AuthRepository) SavePasswordResetToken(userID int, tokenHash string, expiresAt time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	saveOneTimeToken(r.s.resetTokens, userID, tokenHash, expiresAt)
+	return nil
+}
+
+func (r *AuthRepository) ResetPassword(tokenHash, newPassword string) (int, error) {
+	hashedPassword, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return 0, err
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	t, ok := r.s.resetTokens[tokenHash]
+	if !ok || !t.valid(time.Now()) {
+		return 0, utils.ErrInvalidToken
+	}
+	t.used = true
+
+	r.s.setPassword(t.userID, hashedPassword)
+	r.s.revokeUserTokens(t.userID)
+	return t.userID, nil
+}
+
+func (r *AuthRepository) GetPasswordResetUserID(tokenHash string) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	t, ok := r.s.resetTokens[tokenHash]
+	if !ok || !t.valid(time.Now()) {
+		return 0, utils.ErrInvalidToken
+	}
+	return t.userID, nil
+}
+
+func (r *AuthRepository) UpdateProfile(userID int, role, firstName, lastName, newEmail string) error {
+	s := r.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[userID]
+	if !ok {
+		return utils.ErrUserNotFound
+	}
+
+	var first, last *string
+	var fullName *string
+	switch role {
+	case models.RolePatient:
+		if p := s.patientByUser(userID); p != nil {
+			first, last, fullName = &p.firstName, &p.lastName, &p.FullName
+		}
+	case models.RoleClinician:
+		if c := s.clinicianByUser(userID); c != nil {
+			first, last, fullName = &c.firstName, &c.lastName, &c.FullName
+		}
+	case models.RoleAdmin:
+	default:
+		return fmt.Errorf("unknown role: %s", role)
+	}
+	if role != models.RoleAdmin && first == nil {
+		return fmt.Errorf("%s profile not found for user_id %d", role, userID)
+	}
+
+	if newEmail != "" {
+		u.Email = newEmail
+		u.EmailVerified = false
+	}
+	u.UpdatedAt = time.Now()
+
+	if first != nil {
+		if firstName != "" {
+			*first = firstName
+		}
+		if lastName != "" {
+			*last = lastName
+		}
+		*fullName = *first + " " + *last
+	}
+	return nil
+}
+
+func (r *AuthRepository) GetMFA(userID int) (*models.UserMFA, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	m, ok := r.s.mfa[userID]
+	if !ok {
+		return nil, utils.ErrMFANotEnrolled
+	}
+	mfa := *m
+	return &mfa, nil
+}
+
+func (r *AuthRepository) SaveMFASecret(userID int, secret string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if m, ok := r.s.mfa[userID]; ok && m.Enabled {
+		return utils.ErrMFAAlreadyEnabled
+	}
+	r.s.mfa[userID] = &models.UserMFA{UserID: userID, Secret: secret}
+	return nil
+}
+
+func (r *AuthRepository) EnableMFA(userID int, step int64, recoveryCodeHashes []string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	m, ok := r.s.mfa[userID]
+	if !ok || m.Enabled {
+		return utils.ErrMFAAlreadyEnabled
+	}
+	m.Enabled = true
+	m.ConfirmedAt = models.NullTime{Time: time.Now(), Valid: true}
+	m.LastUsedStep = step
+
+	codes := map[string]bool{}
+	for _, hash := range recoveryCodeHashes {
+		codes[hash] = false
+	}
+	r.s.recoveryCodes[userID] = codes
+	return nil
+}
+
+func (r *AuthRepository) UpdateMFALastStep(userID int, step int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	m, ok := r.s.mfa[userID]
+	if !ok || m.LastUsedStep >= step {
+		return utils.ErrInvalidMFACode
+	}
+	m.LastUsedStep = step
+	return nil
+}
+
+func (r *AuthRepository) UseRecoveryCode(userID int, codeHash string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	used, ok := r.s.recoveryCodes[userID][codeHash]
+	if !ok || used {
+		return utils.ErrInvalidMFACode
+	}
+	r.s.recoveryCodes[userID][codeHash] = true
+	return nil
+}
+
+func (r *AuthRepository) DeleteMFA(userID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	delete(r.s.recoveryCodes, userID)
+	delete(r.s.mfa, userID)
+	return nil
+}
+
+func (r *AuthRepository) RecordFailedLogin(userID, threshold int, lockFor time.Duration) (models.NullTime, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	u, ok := r.s.users[userID]
+	if !ok {
+		return models.NullTime{}, utils.ErrUserNotFound
+	}
+
+	now := time.Now()
+	attempts := u.FailedLoginAttempts + 1
+	if u.LockedUntil.Valid && !u.LockedUntil.Time.After(now) {
+		attempts = 1
+	}
+
+	switch {
+	case threshold > 0 && attempts >= threshold:
+		u.LockedUntil = models.NullTime{Time: now.Add(lockFor), Valid: true}
+	case u.IsLocked(now):
+	default:
+		u.LockedUntil = models.NullTime{}
+	}
+	u.FailedLoginAttempts = attempts
+	u.lastFailedLoginAt = models.NullTime{Time: now, Valid: true}
+
+	return u.LockedUntil, nil
+}
+
+func (r *AuthRepository) ClearFailedLogins(userID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	u, ok := r.s.users[userID]
+	if !ok {
+		return utils.ErrUserNotFound
+	}
+	u.FailedLoginAttempts = 0
+	u.LockedUntil = models.NullTime{}
+	return nil
+}
+
+func (r *AuthRepository) RecordIPLoginFailure(clientIP, email string, pruneBefore time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	kept := r.s.ipFailures[:0]
+	for _, f := range r.s.ipFailures {
+		if !f.at.Before(pruneBefore) {
+			kept = append(kept, f)
+		}
+	}
+	r.s.ipFailures = append(kept, ipFailure{clientIP: clientIP, at: time.Now()})
+	return nil
+}
+
+func (r *AuthRepository) CountIPLoginFailures(clientIP string, since time.Time) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	count := 0
+	for _, f := range r.s.ipFailures {
+		if f.clientIP == clientIP && f.at.After(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *AuthRepository) GetLockStatus(userID int) (*models.UserLockStatus, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	u, ok := r.s.users[userID]
+	if !ok {
+		return nil, utils.ErrUserNotFound
+	}
+	status := lockStatus(u, time.Now())
+	return &status, nil
+}
+
+func (r *AuthRepository) ListLockedUsers() ([]models.UserLockStatus, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	statuses := []models.UserLockStatus{}
+	for _, u := range r.s.users {
+		if u.IsLocked(now) {
+			statuses = append(statuses, lockStatus(u, now))
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].LockedUntil.Time.After(statuses[j].LockedUntil.Time)
+	})
+	return statuses, nil
+}
+
+func lockStatus(u *userRow, now time.Time) models.UserLockStatus {
+	return models.UserLockStatus{
+		UserID:              u.ID,
+		Email:               u.Email,
+		Role:                u.Role,
+		FailedLoginAttempts: u.FailedLoginAttempts,
+		LastFailedLoginAt:   u.lastFailedLoginAt,
+		Locked:              u.IsLocked(now),
+		LockedUntil:         u.LockedUntil,
+	}
+}
+
+func (r *AuthRepository) GetUserByIdentity(issuer, subject string) (*models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	userID, ok := r.s.identities[identityKey{issuer, subject}]
+	if !ok {
+		return nil, utils.ErrUserNotFound
+	}
+	return r.s.getUser(userID)
+}
+
+func (r *AuthRepository) LinkIdentity(userID int, issuer, subject, email string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	key := identityKey{issuer, subject}
+	if _, ok := r.s.identities[key]; ok {
+		return fmt.Errorf("identity %s/%s is already linked", issuer, subject)
+	}
+	r.s.identities[key] = userID
+	return nil
+}
+
+func (r *AuthRepository) MarkEmailVerified(userID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if u, ok := r.s.users[userID]; ok {
+		u.EmailVerified = true
+		u.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+func (r *AuthRepository) ListUsers(filter models.UserFilter) ([]models.UserSummary, int, error) {
+	s := r.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := strings.ToLower(filter.Query)
+	now := time.Now()
+
+	users := []models.UserSummary{}
+	for _, u := range s.users {
+		summary := s.userSummary(u, now)
+		if query != "" &&
+			!strings.Contains(strings.ToLower(u.Email), query) &&
+			!strings.Contains(strings.ToLower(summary.FirstName+" "+summary.LastName), query) {
+			continue
+		}
+		if filter.Role != "" && u.Role != filter.Role {
+			continue
+		}
+		if filter.Active != nil && u.IsActive != *filter.Active {
+			continue
+		}
+		users = append(users, summary)
+	}
+
+	sort.Slice(users, func(i, j int) bool { return users[i].Email < users[j].Email })
+	start, end := paginate(len(users), filter.GetLimit(), filter.GetOffset())
+	return users[start:end], len(users), nil
+}
+
+func (r *AuthRepository) GetUserSummary(userID int) (*models.UserSummary, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	u, ok := r.s.users[userID]
+	if !ok {
+		return nil, utils.ErrUserNotFound
+	}
+	summary := r.s.userSummary(u, time.Now())
+	return &summary, nil
+}
+
+func (s *Store) userSummary(u *userRow, now time.Time) models.UserSummary {
+	first, last, _ := s.profileNames(u)
+	return models.UserSummary{
+		ID:                    u.ID,
+		Email:                 u.Email,
+		FirstName:             first,
+		LastName:              last,
+		Role:                  u.Role,
+		IsActive:              u.IsActive,
+		EmailVerified:         u.EmailVerified,
+		Locked:                u.IsLocked(now),
+		PasswordResetRequired: u.PasswordResetRequired,
+		CreatedAt:             u.CreatedAt,
+		UpdatedAt:             u.UpdatedAt,
+	}
+}
+
+func (r *AuthRepository) ChangeUserRole(userID int, role string) error {
+	s := r.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[userID]
+	if !ok {
+		return utils.ErrUserNotFound
+	}
+	u.Role = role
+	u.UpdatedAt = time.Now()
+
+	p, c := s.patientByUser(userID), s.clinicianByUser(userID)
+	if (role == models.RolePatient && p == nil) || (role == models.RoleClinician && c == nil) {
+		// Carry the name over from the user's other profile, if any
+		var first, last string
+		switch {
+		case c != nil:
+			first, last = c.firstName, c.lastName
+		case p != nil:
+			first, last = p.firstName, p.lastName
+		}
+		s.insertProfile(userID, role, first, last)
+	}
+	return nil
+}
+
+func (r *AuthRepository) SetPasswordResetRequired(userID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	u, ok := r.s.users[userID]
+	if !ok {
+		return utils.ErrUserNotFound
+	}
+	u.PasswordResetRequired = true
+	u.UpdatedAt = time.Now()
+	return nil
+}
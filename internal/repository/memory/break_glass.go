@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// BreakGlassRepository is the in-memory repository.BreakGlassRepository
+type BreakGlassRepository struct {
+	s *Store
+}
+
+var _ repository.BreakGlassRepository = (*BreakGlassRepository)(nil)
+
+func (r *BreakGlassRepository) CreateEvent(userID int, reason, clientIP string, expiresAt time.Time) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	u, ok := r.s.users[userID]
+	if !ok {
+		return 0, utils.ErrUserNotFound
+	}
+
+	id := r.s.id()
+	r.s.breakGlass[id] = &models.BreakGlassEvent{
+		EventID:   id,
+		UserID:    userID,
+		UserEmail: u.Email,
+		Reason:    reason,
+		ClientIP:  clientIP,
+		StartedAt: time.Now(),
+		ExpiresAt: expiresAt,
+		Accesses:  []models.BreakGlassAccess{},
+	}
+	return id, nil
+}
+
+func (r *BreakGlassRepository) RecordAccess(eventID, patientID int, resource string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	e, ok := r.s.breakGlass[eventID]
+	if !ok {
+		return utils.ErrNotFound
+	}
+
+	access := models.BreakGlassAccess{
+		AccessID:   r.s.id(),
+		Resource:   resource,
+		AccessedAt: time.Now(),
+	}
+	if patientID != 0 {
+		access.PatientID = &patientID
+	}
+	e.Accesses = append(e.Accesses, access)
+	return nil
+}
+
+func (r *BreakGlassRepository) ListEvents(filter models.BreakGlassEventFilter) ([]models.BreakGlassEvent, int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	events := []models.BreakGlassEvent{}
+	for _, e := range r.s.breakGlass {
+		if filter.Unreviewed && e.ReviewedAt.Valid {
+			continue
+		}
+		if filter.UserID != nil && e.UserID != *filter.UserID {
+			continue
+		}
+		event := *e
+		event.AccessCount = len(e.Accesses)
+		event.Accesses = nil
+		events = append(events, event)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].StartedAt.Equal(events[j].StartedAt) {
+			return events[i].StartedAt.After(events[j].StartedAt)
+		}
+		return events[i].EventID > events[j].EventID
+	})
+	start, end := paginate(len(events), filter.GetLimit(), filter.GetOffset())
+	return events[start:end], len(events), nil
+}
+
+func (r *BreakGlassRepository) GetEvent(eventID int) (*models.BreakGlassEvent, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	e, ok := r.s.breakGlass[eventID]
+	if !ok {
+		return nil, utils.ErrNotFound
+	}
+	event := *e
+	event.AccessCount = len(e.Accesses)
+	event.Accesses = append([]models.BreakGlassAccess{}, e.Accesses...)
+	return &event, nil
+}
+
+func (r *BreakGlassRepository) ReviewEvent(eventID, reviewerID int, notes string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	e, ok := r.s.breakGlass[eventID]
+	if !ok {
+		return utils.ErrNotFound
+	}
+	e.ReviewedBy = &reviewerID
+	e.ReviewedAt = models.NullTime{Time: time.Now(), Valid: true}
+	e.ReviewNotes = notes
+	return nil
+}
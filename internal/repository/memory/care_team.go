@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// CareTeamRepository is the in-memory repository.CareTeamRepository
+type CareTeamRepository struct {
+	s *Store
+}
+
+var _ repository.CareTeamRepository = (*CareTeamRepository)(nil)
+
+func (r *CareTeamRepository) ListByPatient(patientID int) ([]models.CareTeamAssignment, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := today()
+	assignments := []models.CareTeamAssignment{}
+	for _, a := range r.s.careTeam {
+		if a.patientID == patientID {
+			assignments = append(assignments, r.s.careTeamAssignment(a, now))
+		}
+	}
+
+	sort.Slice(assignments, func(i, j int) bool {
+		if !assignments[i].StartDate.Equal(assignments[j].StartDate) {
+			return assignments[i].StartDate.After(assignments[j].StartDate)
+		}
+		return assignments[i].AssignmentID > assignments[j].AssignmentID
+	})
+	return assignments, nil
+}
+
+func (r *CareTeamRepository) GetAssignment(patientID, assignmentID int) (*models.CareTeamAssignment, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	a, ok := r.s.careTeam[assignmentID]
+	if !ok || a.patientID != patientID {
+		return nil, utils.ErrNotFound
+	}
+	assignment := r.s.careTeamAssignment(a, today())
+	return &assignment, nil
+}
+
+func (s *Store) careTeamAssignment(a *careTeamRow, now time.Time) models.CareTeamAssignment {
+	assignment := models.CareTeamAssignment{
+		AssignmentID: a.assignmentID,
+		PatientID:    a.patientID,
+		ClinicianID:  a.clinicianID,
+		StartDate:    a.startDate,
+		Active:       a.active(now),
+	}
+	if c, ok := s.clinicians[a.clinicianID]; ok {
+		assignment.ClinicianName = c.FullName
+	}
+	if a.endDate != nil {
+		assignment.EndDate = models.NullTime{Time: *a.endDate, Valid: true}
+	}
+	return assignment
+}
+
+func (r *CareTeamRepository) HasOverlappingAssignment(patientID, clinicianID int, startDate time.Time, endDate *time.Time) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	startDate, endDate = dateRange(startDate, endDate)
+	return r.s.overlappingAssignment(patientID, clinicianID, startDate, endDate), nil
+}
+
+func (s *Store) overlappingAssignment(patientID, clinicianID int, startDate time.Time, endDate *time.Time) bool {
+	for _, a := range s.careTeam {
+		if a.patientID == patientID && a.clinicianID == clinicianID && a.overlaps(startDate, endDate) {
+			return true
+		}
+	}
+	return false
+}
+
+// dateRange truncates an assignment's dates as the DATE columns in Postgres do
+func dateRange(startDate time.Time, endDate *time.Time) (time.Time, *time.Time) {
+	startDate = startDate.UTC().Truncate(24 * time.Hour)
+	if endDate != nil {
+		end := endDate.UTC().Truncate(24 * time.Hour)
+		endDate = &end
+	}
+	return startDate, endDate
+}
+
+func (r *CareTeamRepository) Assign(patientID, clinicianID int, startDate time.Time, endDate *time.Time, assignedBy int) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.patients[patientID]; !ok {
+		return 0, utils.ErrNotFound
+	}
+	if _, ok := r.s.clinicians[clinicianID]; !ok {
+		return 0, utils.ErrNotClinician
+	}
+
+	startDate, endDate = dateRange(startDate, endDate)
+	if r.s.overlappingAssignment(patientID, clinicianID, startDate, endDate) {
+		return 0, utils.ErrAlreadyAssigned
+	}
+
+	id := r.s.id()
+	r.s.careTeam[id] = &careTeamRow{
+		assignmentID: id,
+		patientID:    patientID,
+		clinicianID:  clinicianID,
+		startDate:    startDate,
+		endDate:      endDate,
+	}
+	return id, nil
+}
+
+func (r *CareTeamRepository) EndAssignment(patientID, assignmentID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := today()
+	a, ok := r.s.careTeam[assignmentID]
+	if !ok || a.patientID != patientID || (a.endDate != nil && !a.endDate.After(now)) {
+		return utils.ErrNotFound
+	}
+
+	end := now
+	if a.startDate.After(now) {
+		end = a.startDate
+	}
+	a.endDate = &end
+	return nil
+}
+
+func (r *CareTeamRepository) PatientExists(patientID int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	_, ok := r.s.livePatient(patientID)
+	return ok, nil
+}
+
+func (r *CareTeamRepository) ClinicianExists(clinicianID int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	_, ok := r.s.clinicians[clinicianID]
+	return ok, nil
+}
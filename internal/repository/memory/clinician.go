@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// ClinicianRepository is the in-memory repository.ClinicianRepository
+type ClinicianRepository struct {
+	s *Store
+}
+
+var _ repository.ClinicianRepository = (*ClinicianRepository)(nil)
+
+func (r *ClinicianRepository) ListClinicians(limit, offset int) ([]models.Clinician, int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	clinicians := []models.Clinician{}
+	for _, c := range r.s.clinicians {
+		clinicians = append(clinicians, c.Clinician)
+	}
+
+	sort.Slice(clinicians, func(i, j int) bool {
+		if clinicians[i].FullName != clinicians[j].FullName {
+			return clinicians[i].FullName < clinicians[j].FullName
+		}
+		return clinicians[i].ClinicianID < clinicians[j].ClinicianID
+	})
+	start, end := paginate(len(clinicians), limit, offset)
+	return clinicians[start:end], len(clinicians), nil
+}
+
+func (r *ClinicianRepository) GetClinician(clinicianID int) (*models.Clinician, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	c, ok := r.s.clinicians[clinicianID]
+	if !ok {
+		return nil, utils.ErrNotFound
+	}
+	clinician := c.Clinician
+	return &clinician, nil
+}
+
+func (r *ClinicianRepository) CreateClinician(cl models.Clinician) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	cl.ClinicianID = r.s.id()
+	r.s.clinicians[cl.ClinicianID] = &clinicianRow{Clinician: cl}
+	return cl.ClinicianID, nil
+}
+
+func (r *ClinicianRepository) UpdateClinician(cl models.Clinician) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.clinicians[cl.ClinicianID]
+	if !ok {
+		return utils.ErrNotFound
+	}
+	row.Clinician = cl
+	return nil
+}
+
+func (r *ClinicianRepository) DeleteClinician(clinicianID int, mode models.DeleteMode) (*models.DeleteResult, error) {
+	s := r.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.clinicians[clinicianID]; !ok {
+		return nil, utils.ErrNotFound
+	}
+
+	// Deleted assessments count too; they are kept as medical records
+	if n := s.countAssessments(func(a *assessmentRow) bool { return a.ClinicianID == clinicianID }); n > 0 {
+		return nil, &utils.DependentsError{Dependents: map[string]int{"assessment": n}}
+	}
+
+	result := models.NewDeleteResult(mode)
+	s.deleteCareTeam(func(a *careTeamRow) bool { return a.clinicianID == clinicianID }, result)
+	delete(s.clinicians, clinicianID)
+	result.Add("clinician", 1)
+	return result, nil
+}
+
+func (r *ClinicianRepository) ClinicianExists(clinicianID int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	_, ok := r.s.clinicians[clinicianID]
+	return ok, nil
+}
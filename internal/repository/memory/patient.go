@@ -0,0 +1,208 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// PatientRepository is the in-memory repository.PatientRepository
+type PatientRepository struct {
+	s *Store
+}
+
+var _ repository.PatientRepository = (*PatientRepository)(nil)
+
+func (r *PatientRepository) ListPatients(limit, offset, careTeamUserID int) ([]models.Patient, int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	patients := []models.Patient{}
+	for _, p := range r.s.patients {
+		if p.deleted != nil {
+			continue
+		}
+		if careTeamUserID != 0 && !r.s.onCareTeam(careTeamUserID, p.PatientID) {
+			continue
+		}
+		patients = append(patients, p.Patient)
+	}
+
+	sort.Slice(patients, func(i, j int) bool {
+		if patients[i].FullName != patients[j].FullName {
+			return patients[i].FullName < patients[j].FullName
+		}
+		return patients[i].PatientID < patients[j].PatientID
+	})
+	start, end := paginate(len(patients), limit, offset)
+	return patients[start:end], len(patients), nil
+}
+
+func (r *PatientRepository) GetPatient(patientID int) (*models.Patient, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	p, ok := r.s.livePatient(patientID)
+	if !ok {
+		return nil, utils.ErrNotFound
+	}
+	patient := p.Patient
+	return &patient, nil
+}
+
+func (r *PatientRepository) CreatePatient(p models.Patient, careTeamClinicianID, assignedBy int) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if careTeamClinicianID != 0 {
+		if _, ok := r.s.clinicians[careTeamClinicianID]; !ok {
+			return 0, utils.ErrNotClinician
+		}
+	}
+
+	p.PatientID = r.s.id()
+	r.s.patients[p.PatientID] = &patientRow{Patient: p}
+
+	if careTeamClinicianID != 0 {
+		id := r.s.id()
+		r.s.careTeam[id] = &careTeamRow{
+			assignmentID: id,
+			patientID:    p.PatientID,
+			clinicianID:  careTeamClinicianID,
+			startDate:    today(),
+		}
+	}
+	return p.PatientID, nil
+}
+
+func (r *PatientRepository) UpdatePatient(p models.Patient) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.livePatient(p.PatientID)
+	if !ok {
+		return utils.ErrNotFound
+	}
+	row.Patient = p
+	return nil
+}
+
+// DeletePatient stamps the patient and any assessments deleted with it
+// with the same DeletionInfo, as the Postgres transaction's NOW() does
+func (r *PatientRepository) DeletePatient(patientID int, mode models.DeleteMode, deletedBy int, reason string) (*models.DeleteResult, error) {
+	s := r.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.livePatient(patientID)
+	if !ok {
+		return nil, utils.ErrNotFound
+	}
+
+	forPatient := func(a *assessmentRow) bool { return a.PatientID == patientID && a.deleted == nil }
+	if n := s.countAssessments(forPatient); n > 0 && mode != models.DeleteCascade {
+		return nil, &utils.DependentsError{Dependents: map[string]int{"assessment": n}}
+	}
+
+	info := deletion(deletedBy, reason)
+	result := models.NewDeleteResult(mode)
+	for _, a := range s.assessments {
+		if forPatient(a) {
+			a.deleted = info
+			result.Add("assessment", 1)
+			result.AssessmentIDs = append(result.AssessmentIDs, a.AssessmentID)
+		}
+	}
+	sort.Ints(result.AssessmentIDs)
+
+	p.deleted = info
+	result.Add("patient", 1)
+	return result, nil
+}
+
+// ListDeletedPatients returns deleted patients, most recently deleted first
+func (r *PatientRepository) ListDeletedPatients(limit, offset int) ([]models.DeletedPatient, int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	patients := []models.DeletedPatient{}
+	for _, p := range r.s.patients {
+		if p.deleted != nil {
+			patients = append(patients, models.DeletedPatient{Patient: p.Patient, DeletionInfo: *p.deleted})
+		}
+	}
+
+	sort.Slice(patients, func(i, j int) bool {
+		if !patients[i].DeletedAt.Equal(patients[j].DeletedAt) {
+			return patients[i].DeletedAt.After(patients[j].DeletedAt)
+		}
+		return patients[i].PatientID > patients[j].PatientID
+	})
+	start, end := paginate(len(patients), limit, offset)
+	return patients[start:end], len(patients), nil
+}
+
+// RestorePatient undeletes the patient and the assessments that share its
+// DeletionInfo, i.e. those its cascade delete removed
+func (r *PatientRepository) RestorePatient(patientID int) (*models.RestoreResult, error) {
+	s := r.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.patients[patientID]
+	if !ok || p.deleted == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	result := models.NewRestoreResult()
+	for _, a := range s.assessments {
+		if a.PatientID == patientID && a.deleted != nil && a.deleted.DeletedAt.Equal(p.deleted.DeletedAt) {
+			a.deleted = nil
+			result.Add("assessment", 1)
+			result.AssessmentIDs = append(result.AssessmentIDs, a.AssessmentID)
+		}
+	}
+	sort.Ints(result.AssessmentIDs)
+
+	p.deleted = nil
+	result.Add("patient", 1)
+	return result, nil
+}
+
+func (r *PatientRepository) PatientExists(patientID int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	_, ok := r.s.livePatient(patientID)
+	return ok, nil
+}
+
+// GetWoundHistory returns the patient's assessments, newest first
+func (r *PatientRepository) GetWoundHistory(patientID int) ([]models.WoundHistory, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	history := []models.WoundHistory{}
+	for _, a := range r.s.assessments {
+		if a.PatientID != patientID || a.deleted != nil {
+			continue
+		}
+		history = append(history, models.WoundHistory{
+			AssessmentID:   a.AssessmentID,
+			AssessmentDate: a.Date,
+			Location:       a.Location,
+			Stage:          a.Stage,
+			HealingStatus:  a.HealingStatus,
+		})
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		if !history[i].AssessmentDate.Equal(history[j].AssessmentDate) {
+			return history[i].AssessmentDate.After(history[j].AssessmentDate)
+		}
+		return history[i].AssessmentID > history[j].AssessmentID
+	})
+	return history, nil
+}
@@ -0,0 +1,220 @@
+// Package memory implements the repository interfaces on in-process maps.
+// It backs the hermetic HTTP tests: behaviour the services depend on, such as
+// sentinel errors, profile creation and care-team scoping, mirrors the
+// Postgres implementations. Nothing is persisted.
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository"
+)
+
+// Store holds every table. The repositories returned by Repositories share
+// it, so a user registered through AuthRepository has a profile visible to
+// PatientRepository or ClinicianRepository, as it would in Postgres.
+type Store struct {
+	mu     sync.Mutex
+	nextID int
+
+	users           map[int]*userRow
+	passwordHistory map[int][]string // oldest first
+	refreshTokens   map[int]*refreshTokenRow
+	emailTokens     map[string]*oneTimeToken
+	resetTokens     map[string]*oneTimeToken
+	mfa             map[int]*models.UserMFA
+	recoveryCodes   map[int]map[string]bool // code hash -> used
+	ipFailures      []ipFailure
+	identities      map[identityKey]int
+	securityEvents  []SecurityEvent
+
+	patients    map[int]*patientRow
+	clinicians  map[int]*clinicianRow
+	assessments map[int]*assessmentRow
+	careTeam    map[int]*careTeamRow
+	breakGlass  map[int]*models.BreakGlassEvent
+	apiKeys     map[int]*apiKeyRow
+}
+
+// NewStore returns an empty store
+func NewStore() *Store {
+	return &Store{
+		users:           map[int]*userRow{},
+		passwordHistory: map[int][]string{},
+		refreshTokens:   map[int]*refreshTokenRow{},
+		emailTokens:     map[string]*oneTimeToken{},
+		resetTokens:     map[string]*oneTimeToken{},
+		mfa:             map[int]*models.UserMFA{},
+		recoveryCodes:   map[int]map[string]bool{},
+		identities:      map[identityKey]int{},
+		patients:        map[int]*patientRow{},
+		clinicians:      map[int]*clinicianRow{},
+		assessments:     map[int]*assessmentRow{},
+		careTeam:        map[int]*careTeamRow{},
+		breakGlass:      map[int]*models.BreakGlassEvent{},
+		apiKeys:         map[int]*apiKeyRow{},
+	}
+}
+
+// Repositories returns every repository backed by this store
+func (s *Store) Repositories() repository.Repositories {
+	return repository.Repositories{
+		Auth:        &AuthRepository{s},
+		Access:      &AccessRepository{s},
+		APIKeys:     &APIKeyRepository{s},
+		BreakGlass:  &BreakGlassRepository{s},
+		CareTeam:    &CareTeamRepository{s},
+		Patients:    &PatientRepository{s},
+		Clinicians:  &ClinicianRepository{s},
+		Assessments: &AssessmentRepository{s},
+	}
+}
+
+// SecurityEvent is a row recorded by AuthRepository.RecordSecurityEvent
+type SecurityEvent struct {
+	UserID    int
+	EventType string
+	Detail    string
+}
+
+// SecurityEvents returns the security events recorded so far
+func (s *Store) SecurityEvents() []SecurityEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SecurityEvent(nil), s.securityEvents...)
+}
+
+// id returns the next row ID; one sequence is shared by all tables
+func (s *Store) id() int {
+	s.nextID++
+	return s.nextID
+}
+
+type userRow struct {
+	models.User
+	lastFailedLoginAt models.NullTime
+}
+
+type refreshTokenRow struct {
+	models.RefreshToken
+	client models.ClientInfo
+}
+
+type oneTimeToken struct {
+	userID    int
+	expiresAt time.Time
+	used      bool
+}
+
+func (t *oneTimeToken) valid(now time.Time) bool {
+	return !t.used && t.expiresAt.After(now)
+}
+
+type ipFailure struct {
+	clientIP string
+	at       time.Time
+}
+
+type identityKey struct {
+	issuer, subject string
+}
+
+type patientRow struct {
+	models.Patient
+	userID              int
+	firstName, lastName string
+	deleted             *models.DeletionInfo // nil unless soft-deleted
+}
+
+type clinicianRow struct {
+	models.Clinician
+	userID              int
+	firstName, lastName string
+}
+
+type assessmentRow struct {
+	models.Assessment
+	sections models.AssessmentSections // child tables; nil until recorded
+	deleted  *models.DeletionInfo      // nil unless soft-deleted
+}
+
+// livePatient returns the patient unless it is missing or soft-deleted,
+// mirroring the Postgres "deleted_at IS NULL" filters
+func (s *Store) livePatient(patientID int) (*patientRow, bool) {
+	p, ok := s.patients[patientID]
+	if !ok || p.deleted != nil {
+		return nil, false
+	}
+	return p, true
+}
+
+// liveAssessment is livePatient for assessments
+func (s *Store) liveAssessment(assessmentID int) (*assessmentRow, bool) {
+	a, ok := s.assessments[assessmentID]
+	if !ok || a.deleted != nil {
+		return nil, false
+	}
+	return a, true
+}
+
+// deletion returns the marks for a soft delete made now
+func deletion(deletedBy int, reason string) *models.DeletionInfo {
+	info := &models.DeletionInfo{DeletedAt: time.Now(), DeleteReason: reason}
+	if deletedBy != 0 {
+		info.DeletedBy = &deletedBy
+	}
+	return info
+}
+
+// clone returns a copy of *p so callers cannot alias stored rows
+func clone[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+type careTeamRow struct {
+	assignmentID, patientID, clinicianID int
+	startDate                            time.Time
+	endDate                              *time.Time
+}
+
+// active mirrors the Postgres activeAssignmentSQL predicate
+func (a *careTeamRow) active(today time.Time) bool {
+	return !a.startDate.After(today) && (a.endDate == nil || a.endDate.After(today))
+}
+
+// overlaps mirrors the care_team_no_overlap constraint: the half-open ranges
+// [start, end) intersect, and empty ranges never overlap anything
+func (a *careTeamRow) overlaps(start time.Time, end *time.Time) bool {
+	if (a.endDate != nil && !a.endDate.After(a.startDate)) || (end != nil && !end.After(start)) {
+		return false
+	}
+	return (end == nil || a.startDate.Before(*end)) && (a.endDate == nil || start.Before(*a.endDate))
+}
+
+type apiKeyRow struct {
+	models.APIKey
+	hash string
+}
+
+// today is CURRENT_DATE in UTC
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+// paginate returns the [offset, offset+limit) window of n items
+func paginate(n, limit, offset int) (int, int) {
+	if offset > n {
+		offset = n
+	}
+	end := offset + limit
+	if end > n {
+		end = n
+	}
+	return offset, end
+}
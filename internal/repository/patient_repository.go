@@ -0,0 +1,298 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// PatientRepository stores patient demographics. Deleted patients are kept
+// but hidden from every method except ListDeletedPatients and RestorePatient.
+type PatientRepository interface {
+	// ListPatients returns a page of patients ordered by name and the total
+	// count. A non-zero careTeamUserID limits the result to the patients on
+	// that clinician user's active care team.
+	ListPatients(limit, offset, careTeamUserID int) ([]models.Patient, int, error)
+	GetPatient(patientID int) (*models.Patient, error)
+	CreatePatient(p models.Patient, careTeamClinicianID, assignedBy int) (int, error)
+	UpdatePatient(p models.Patient) error
+	// DeletePatient soft-deletes a patient, recording deletedBy and reason. In
+	// restrict mode assessments block the delete with a *utils.DependentsError;
+	// in cascade mode they are soft-deleted too.
+	DeletePatient(patientID int, mode models.DeleteMode, deletedBy int, reason string) (*models.DeleteResult, error)
+	// ListDeletedPatients returns a page of deleted patients, most recently
+	// deleted first, and the total count
+	ListDeletedPatients(limit, offset int) ([]models.DeletedPatient, int, error)
+	// RestorePatient undeletes a patient along with the assessments deleted
+	// with it. Returns utils.ErrNotFound unless the patient is deleted.
+	RestorePatient(patientID int) (*models.RestoreResult, error)
+	PatientExists(patientID int) (bool, error)
+	GetWoundHistory(patientID int) ([]models.WoundHistory, error)
+}
+
+// PostgresPatientRepository is the PatientRepository backed by the patient table
+type PostgresPatientRepository struct {
+	db *sql.DB
+}
+
+func NewPatientRepository(db *sql.DB) *PostgresPatientRepository {
+	return &PostgresPatientRepository{db: db}
+}
+
+const patientSelect = `
+	SELECT patient_id, full_name, date_of_birth, gender, medical_record_number
+	FROM patient
+	WHERE deleted_at IS NULL`
+
+// ------------------------------------------------------------
+// LIST PATIENTS
+// ------------------------------------------------------------
+func (r *PostgresPatientRepository) ListPatients(limit, offset, careTeamUserID int) ([]models.Patient, int, error) {
+	where := ""
+	args := []interface{}{}
+	if careTeamUserID != 0 {
+		where = " AND " + CareTeamPatientFilter("patient_id", 1)
+		args = append(args, careTeamUserID)
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM patient WHERE deleted_at IS NULL"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := patientSelect + where +
+		fmt.Sprintf(" ORDER BY full_name LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	patients := []models.Patient{}
+	for rows.Next() {
+		p, err := scanPatient(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		patients = append(patients, *p)
+	}
+
+	return patients, total, rows.Err()
+}
+
+// ------------------------------------------------------------
+// GET PATIENT
+// ------------------------------------------------------------
+func (r *PostgresPatientRepository) GetPatient(patientID int) (*models.Patient, error) {
+	p, err := scanPatient(r.db.QueryRow(patientSelect+" AND patient_id = $1", patientID))
+	if err == sql.ErrNoRows {
+		return nil, utils.ErrNotFound
+	}
+	return p, err
+}
+
+// ------------------------------------------------------------
+// CREATE PATIENT (add_patient FUNCTION)
+// ------------------------------------------------------------
+// CreatePatient inserts the patient and, when careTeamClinicianID is set,
+// puts that clinician on the new patient's care team in the same transaction
+func (r *PostgresPatientRepository) CreatePatient(p models.Patient, careTeamClinicianID, assignedBy int) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int
+	if err := tx.QueryRow(`
+		SELECT add_patient($1, $2, $3, $4)
+	`, p.FullName, p.DateOfBirth, p.Gender, p.MedicalRecordNumber).Scan(&id); err != nil {
+		return 0, err
+	}
+
+	if careTeamClinicianID != 0 {
+		if _, err := tx.Exec(`
+			INSERT INTO care_team_assignment (patient_id, clinician_id, start_date, assigned_by)
+			VALUES ($1, $2, CURRENT_DATE, NULLIF($3, 0))
+		`, id, careTeamClinicianID, assignedBy); err != nil {
+			return 0, err
+		}
+	}
+
+	return id, tx.Commit()
+}
+
+// ------------------------------------------------------------
+// UPDATE PATIENT
+// ------------------------------------------------------------
+func (r *PostgresPatientRepository) UpdatePatient(p models.Patient) error {
+	result, err := r.db.Exec(`
+		UPDATE patient
+		SET full_name = $1, date_of_birth = $2, gender = $3, medical_record_number = $4
+		WHERE patient_id = $5 AND deleted_at IS NULL
+	`, p.FullName, p.DateOfBirth, p.Gender, p.MedicalRecordNumber, p.PatientID)
+	if err != nil {
+		return err
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return utils.ErrNotFound
+	}
+	return nil
+}
+
+// ------------------------------------------------------------
+// DELETE PATIENT
+// ------------------------------------------------------------
+// NOW() is fixed for the transaction, so the patient and the assessments
+// deleted with it share deleted_at; RestorePatient relies on this.
+func (r *PostgresPatientRepository) DeletePatient(patientID int, mode models.DeleteMode, deletedBy int, reason string) (*models.DeleteResult, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockActiveRow(tx, "patient", "patient_id", patientID); err != nil {
+		return nil, err
+	}
+
+	if mode != models.DeleteCascade {
+		n, err := countRows(tx, "assessment", "patient_id = $1 AND deleted_at IS NULL", patientID)
+		if err != nil {
+			return nil, err
+		}
+		if n > 0 {
+			return nil, &utils.DependentsError{Dependents: map[string]int{"assessment": n}}
+		}
+	}
+
+	result := models.NewDeleteResult(mode)
+	result.AssessmentIDs, err = softDelete(tx, "assessment", "assessment_id",
+		"patient_id = $1", patientID, deletedBy, reason)
+	if err != nil {
+		return nil, err
+	}
+	result.Add("assessment", len(result.AssessmentIDs))
+
+	if _, err := softDelete(tx, "patient", "patient_id", "patient_id = $1", patientID, deletedBy, reason); err != nil {
+		return nil, err
+	}
+	result.Add("patient", 1)
+	return result, tx.Commit()
+}
+
+// ------------------------------------------------------------
+// LIST DELETED PATIENTS
+// ------------------------------------------------------------
+func (r *PostgresPatientRepository) ListDeletedPatients(limit, offset int) ([]models.DeletedPatient, int, error) {
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM patient WHERE deleted_at IS NOT NULL").Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(`
+		SELECT patient_id, full_name, date_of_birth, gender, medical_record_number,
+		       deleted_at, deleted_by, delete_reason
+		FROM patient
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, patient_id DESC
+		LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	patients := []models.DeletedPatient{}
+	for rows.Next() {
+		var p models.DeletedPatient
+		var deletedBy sql.NullInt64
+		if err := rows.Scan(&p.PatientID, &p.FullName, &p.DateOfBirth, &p.Gender, &p.MedicalRecordNumber,
+			&p.DeletedAt, &deletedBy, &p.DeleteReason); err != nil {
+			return nil, 0, err
+		}
+		p.DeletedBy = nullIntPtr(deletedBy)
+		patients = append(patients, p)
+	}
+
+	return patients, total, rows.Err()
+}
+
+// ------------------------------------------------------------
+// RESTORE PATIENT
+// ------------------------------------------------------------
+func (r *PostgresPatientRepository) RestorePatient(patientID int) (*models.RestoreResult, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var deletedAt sql.NullTime
+	err = tx.QueryRow("SELECT deleted_at FROM patient WHERE patient_id = $1 FOR UPDATE", patientID).Scan(&deletedAt)
+	if err == sql.ErrNoRows || (err == nil && !deletedAt.Valid) {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := models.NewRestoreResult()
+	result.AssessmentIDs, err = restore(tx, "assessment", "assessment_id",
+		"patient_id = $1 AND deleted_at = $2", patientID, deletedAt.Time)
+	if err != nil {
+		return nil, err
+	}
+	result.Add("assessment", len(result.AssessmentIDs))
+
+	if _, err := restore(tx, "patient", "patient_id", "patient_id = $1", patientID); err != nil {
+		return nil, err
+	}
+	result.Add("patient", 1)
+	return result, tx.Commit()
+}
+
+// ------------------------------------------------------------
+// EXISTENCE CHECK
+// ------------------------------------------------------------
+func (r *PostgresPatientRepository) PatientExists(patientID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM patient WHERE patient_id = $1 AND deleted_at IS NULL)", patientID).Scan(&exists)
+	return exists, err
+}
+
+// ------------------------------------------------------------
+// WOUND HISTORY (get_patient_wound_history FUNCTION)
+// ------------------------------------------------------------
+func (r *PostgresPatientRepository) GetWoundHistory(patientID int) ([]models.WoundHistory, error) {
+	rows, err := r.db.Query("SELECT * FROM get_patient_wound_history($1)", patientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []models.WoundHistory{}
+	for rows.Next() {
+		var h models.WoundHistory
+		if err := rows.Scan(&h.AssessmentID, &h.AssessmentDate, &h.Location, &h.Stage, &h.HealingStatus); err != nil {
+			return nil, fmt.Errorf("failed to scan wound history: %w", err)
+		}
+		history = append(history, h)
+	}
+
+	return history, rows.Err()
+}
+
+func scanPatient(row interface{ Scan(...any) error }) (*models.Patient, error) {
+	var p models.Patient
+	if err := row.Scan(&p.PatientID, &p.FullName, &p.DateOfBirth, &p.Gender, &p.MedicalRecordNumber); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
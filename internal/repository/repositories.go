@@ -0,0 +1,30 @@
+package repository
+
+import "database/sql"
+
+// Repositories bundles the stores the API is built on, so the router can be
+// wired to Postgres in production or to package memory in tests
+type Repositories struct {
+	Auth        AuthRepository
+	Access      AccessRepository
+	APIKeys     APIKeyRepository
+	BreakGlass  BreakGlassRepository
+	CareTeam    CareTeamRepository
+	Patients    PatientRepository
+	Clinicians  ClinicianRepository
+	Assessments AssessmentRepository
+}
+
+// NewPostgresRepositories returns every repository backed by db
+func NewPostgresRepositories(db *sql.DB) Repositories {
+	return Repositories{
+		Auth:        NewAuthRepository(db),
+		Access:      NewAccessRepository(db),
+		APIKeys:     NewAPIKeyRepository(db),
+		BreakGlass:  NewBreakGlassRepository(db),
+		CareTeam:    NewCareTeamRepository(db),
+		Patients:    NewPatientRepository(db),
+		Clinicians:  NewClinicianRepository(db),
+		Assessments: NewAssessmentRepository(db),
+	}
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// TokenDenylistRepository is a Postgres-backed utils.TokenDenylist shared by
+// every API instance
+type TokenDenylistRepository struct {
+	db *sql.DB
+}
+
+func NewTokenDenylistRepository(db *sql.DB) *TokenDenylistRepository {
+	return &TokenDenylistRepository{db: db}
+}
+
+// ------------------------------------------------------------
+// REVOKE SINGLE TOKEN
+// ------------------------------------------------------------
+func (r *TokenDenylistRepository) RevokeToken(jti string, expiresAt time.Time) error {
+	if jti == "" {
+		return nil
+	}
+
+	// Expired entries can never match again; clear them as we go
+	if _, err := r.db.Exec("DELETE FROM revoked_access_tokens WHERE expires_at < NOW()"); err != nil {
+		return err
+	}
+
+	_, err := r.db.Exec(`
+		INSERT INTO revoked_access_tokens (jti, expires_at)
+		VALUES ($1, $2)
+		ON CONFLICT (jti) DO NOTHING
+	`, jti, expiresAt)
+	return err
+}
+
+// ------------------------------------------------------------
+// REVOKE EVERY TOKEN ISSUED TO A USER
+// ------------------------------------------------------------
+func (r *TokenDenylistRepository) RevokeUserTokens(userID int, before time.Time) error {
+	_, err := r.db.Exec(`
+		INSERT INTO user_token_revocations (user_id, revoked_before)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET revoked_before = GREATEST(user_token_revocations.revoked_before, EXCLUDED.revoked_before)
+	`, userID, utils.RevocationCutoff(before))
+	return err
+}
+
+// ------------------------------------------------------------
+// CHECK TOKEN
+// ------------------------------------------------------------
+func (r *TokenDenylistRepository) IsRevoked(claims *utils.Claims) (bool, error) {
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+
+	var revoked bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS(SELECT 1 FROM revoked_access_tokens WHERE jti = $1)
+		    OR EXISTS(SELECT 1 FROM user_token_revocations WHERE user_id = $2 AND revoked_before > $3)
+	`, claims.ID, claims.UserID, issuedAt).Scan(&revoked)
+	return revoked, err
+}
@@ -5,14 +5,24 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/vellalasantosh/wound_iq_api_claude/internal/db"
 	"github.com/vellalasantosh/wound_iq_api_claude/internal/handlers"
 	"github.com/vellalasantosh/wound_iq_api_claude/internal/middleware"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/service"
 )
 
-func SetupRouter(database *db.DB, authHandler *handlers.AuthHandler) *gin.Engine {
+// Each client IP may request at most resendVerificationLimit verification
+// emails per resendVerificationWindow
+const (
+	resendVerificationLimit  = 5
+	resendVerificationWindow = 15 * time.Minute
+)
+
+func SetupRouter(repos repository.Repositories, authHandler *handlers.AuthHandler, adminHandler *handlers.AdminHandler) *gin.Engine {
 
 	r := gin.New()
+	// Client IPs come from the socket until main trusts specific proxies
+	_ = r.SetTrustedProxies(nil)
 	r.Use(gin.Logger())
 	r.Use(gin.Recovery())
 	r.Use(corsMiddleware())
@@ -25,11 +35,23 @@ func SetupRouter(database *db.DB, authHandler *handlers.AuthHandler) *gin.Engine
 		})
 	})
 
+	// Token verification keys for other services
+	r.GET("/.well-known/jwks.json", handlers.JWKS)
+
 	// Handlers
-	patientHandler := handlers.NewPatientHandler(database)
-	clinicianHandler := handlers.NewClinicianHandler(database)
-	assessmentHandler := handlers.NewAssessmentHandler(database)
-	reportHandler := handlers.NewReportHandler(database)
+	accessService := service.NewAccessService(repos.Access, repos.BreakGlass)
+	careTeamService := service.NewCareTeamService(repos.CareTeam)
+	patientService := service.NewPatientService(repos.Patients)
+	clinicianService := service.NewClinicianService(repos.Clinicians)
+	assessmentService := service.NewAssessmentService(repos.Assessments, repos.Patients, repos.Clinicians)
+	patientHandler := handlers.NewPatientHandler(patientService, accessService)
+	clinicianHandler := handlers.NewClinicianHandler(clinicianService)
+	assessmentHandler := handlers.NewAssessmentHandler(assessmentService, accessService)
+	reportHandler := handlers.NewReportHandler(patientService, assessmentService, accessService)
+	portalHandler := handlers.NewPortalHandler(patientService, assessmentService, accessService)
+	careTeamHandler := handlers.NewCareTeamHandler(careTeamService, accessService)
+	breakGlassHandler := handlers.NewBreakGlassHandler(service.NewBreakGlassService(repos.BreakGlass))
+	apiKeyHandler := handlers.NewAPIKeyHandler(service.NewAPIKeyService(repos.APIKeys))
 
 	// Unified API root
 	v1 := r.Group("/api/v1")
@@ -39,48 +61,130 @@ func SetupRouter(database *db.DB, authHandler *handlers.AuthHandler) *gin.Engine
 	{
 		auth.POST("/register", authHandler.Register)
 		auth.POST("/login", authHandler.Login)
+		auth.POST("/login/mfa", authHandler.LoginMFA)
+		auth.GET("/sso/login", authHandler.SSOLogin)
+		auth.GET("/sso/callback", authHandler.SSOCallback)
 		auth.POST("/refresh", authHandler.RefreshToken)
+		auth.GET("/verify-email", authHandler.VerifyEmail)
+		auth.POST("/verify-email", authHandler.VerifyEmail)
+		auth.POST("/resend-verification", middleware.RateLimit(resendVerificationLimit, resendVerificationWindow), authHandler.ResendVerification)
+		auth.POST("/forgot-password", authHandler.ForgotPassword)
+		auth.POST("/reset-password", authHandler.ResetPassword)
 
 		protected := auth.Group("")
 		protected.Use(middleware.AuthMiddleware())
 		{
 			protected.POST("/logout", authHandler.Logout)
+			protected.GET("/sessions", authHandler.ListSessions)
+			protected.DELETE("/sessions/:id", authHandler.RevokeSession)
 			protected.GET("/profile", authHandler.GetProfile)
+			protected.PUT("/profile", authHandler.UpdateProfile)
 			protected.POST("/change-password", authHandler.ChangePassword)
+			protected.POST("/mfa/enroll", authHandler.EnrollMFA)
+			protected.POST("/mfa/confirm", authHandler.ConfirmMFA)
+			protected.POST("/mfa/disable", authHandler.DisableMFA)
 		}
 	}
 
+	// Resource routes require a valid token; each route declares the
+	// permission it needs (see middleware.RolePermissions)
+	require := middleware.RequirePermission
+
 	// Patients
 	patients := v1.Group("/patients")
+	patients.Use(middleware.AuthMiddleware())
 	{
-		patients.GET("", patientHandler.GetAllPatients)
-		patients.GET("/:id", patientHandler.GetPatientByID)
-		patients.POST("", patientHandler.CreatePatient)
-		patients.PUT("/:id", patientHandler.UpdatePatient)
-		patients.DELETE("/:id", patientHandler.DeletePatient)
-		patients.GET("/:id/history", reportHandler.GetPatientWoundHistory)
+		patients.GET("", require(middleware.PermPatientsList), patientHandler.GetAllPatients)
+		patients.GET("/:id", require(middleware.PermPatientsRead), patientHandler.GetPatientByID)
+		patients.POST("", require(middleware.PermPatientsWrite), patientHandler.CreatePatient)
+		patients.PUT("/:id", require(middleware.PermPatientsWrite), patientHandler.UpdatePatient)
+		patients.DELETE("/:id", require(middleware.PermPatientsDelete), patientHandler.DeletePatient)
+		patients.GET("/:id/history", require(middleware.PermPatientsRead), reportHandler.GetPatientWoundHistory)
+		patients.GET("/:id/care-team", require(middleware.PermPatientsRead), careTeamHandler.GetCareTeam)
+		patients.POST("/:id/care-team", require(middleware.PermCareTeamManage), careTeamHandler.AssignClinician)
+		patients.DELETE("/:id/care-team/:assignment_id", require(middleware.PermCareTeamManage), careTeamHandler.UnassignClinician)
 	}
 
 	// Clinicians
 	clinicians := v1.Group("/clinicians")
+	clinicians.Use(middleware.AuthMiddleware())
 	{
-		clinicians.GET("", clinicianHandler.GetAllClinicians)
-		clinicians.GET("/:id", clinicianHandler.GetClinicianByID)
-		clinicians.POST("", clinicianHandler.CreateClinician)
-		clinicians.PUT("/:id", clinicianHandler.UpdateClinician)
-		clinicians.DELETE("/:id", clinicianHandler.DeleteClinician)
+		clinicians.GET("", require(middleware.PermCliniciansRead), clinicianHandler.GetAllClinicians)
+		clinicians.GET("/:id", require(middleware.PermCliniciansRead), clinicianHandler.GetClinicianByID)
+		clinicians.POST("", require(middleware.PermCliniciansManage), clinicianHandler.CreateClinician)
+		clinicians.PUT("/:id", require(middleware.PermCliniciansManage), clinicianHandler.UpdateClinician)
+		clinicians.DELETE("/:id", require(middleware.PermCliniciansManage), clinicianHandler.DeleteClinician)
 	}
 
 	// Assessments
 	assessments := v1.Group("/assessments")
+	assessments.Use(middleware.AuthMiddleware())
+	{
+		assessments.GET("", require(middleware.PermAssessmentsList), assessmentHandler.GetAllAssessments)
+		assessments.GET("/:id", require(middleware.PermAssessmentsRead), assessmentHandler.GetAssessmentByID)
+		assessments.POST("", require(middleware.PermAssessmentsWrite), assessmentHandler.CreateAssessment)
+		assessments.POST("/full", require(middleware.PermAssessmentsWrite), assessmentHandler.CreateFullAssessment)
+		assessments.PUT("/:id", require(middleware.PermAssessmentsWrite), assessmentHandler.UpdateAssessment)
+		assessments.DELETE("/:id", require(middleware.PermAssessmentsDelete), assessmentHandler.DeleteAssessment)
+		assessments.GET("/:id/full", require(middleware.PermAssessmentsRead), reportHandler.GetFullAssessment)
+		assessments.PUT("/:id/full", require(middleware.PermAssessmentsWrite), assessmentHandler.ReplaceFullAssessment)
+
+		// Child sections: PUT replaces a section, PATCH changes the fields sent
+		for _, section := range []string{"infection-pain", "tissue-status", "vitals", "wound-condition", "exudate", "treatment"} {
+			assessments.PUT("/:id/"+section, require(middleware.PermAssessmentsWrite), assessmentHandler.ReplaceSection(section))
+			assessments.PATCH("/:id/"+section, require(middleware.PermAssessmentsWrite), assessmentHandler.PatchSection(section))
+		}
+	}
+
+	// Break-glass emergency access
+	breakGlass := v1.Group("/break-glass")
+	breakGlass.Use(middleware.AuthMiddleware())
+	{
+		breakGlass.POST("", require(middleware.PermBreakGlassStart), breakGlassHandler.StartBreakGlass)
+		breakGlass.GET("/events", require(middleware.PermBreakGlassReview), breakGlassHandler.ListBreakGlassEvents)
+		breakGlass.GET("/events/:id", require(middleware.PermBreakGlassReview), breakGlassHandler.GetBreakGlassEvent)
+		breakGlass.POST("/events/:id/review", require(middleware.PermBreakGlassReview), breakGlassHandler.ReviewBreakGlassEvent)
+	}
+
+	// Patient self-service portal
+	me := v1.Group("/me")
+	me.Use(middleware.AuthMiddleware(), require(middleware.PermPortalRead))
+	{
+		me.GET("", portalHandler.GetMyPatient)
+		me.GET("/history", portalHandler.GetMyWoundHistory)
+		me.GET("/assessments/:id", portalHandler.GetMyFullAssessment)
+	}
+
+	// Account administration
+	admin := v1.Group("/admin")
+	admin.Use(middleware.AuthMiddleware(), require(middleware.PermUsersManage))
+	{
+		admin.GET("/users", adminHandler.ListUsers)
+		admin.POST("/users", adminHandler.CreateUser)
+		admin.GET("/users/locked", adminHandler.ListLockedUsers)
+		admin.GET("/users/:id", adminHandler.GetUser)
+		admin.PUT("/users/:id/role", adminHandler.ChangeUserRole)
+		admin.POST("/users/:id/force-password-reset", adminHandler.ForcePasswordReset)
+		admin.GET("/users/:id/lock", adminHandler.GetUserLock)
+		admin.DELETE("/users/:id/lock", adminHandler.UnlockUser)
+		admin.POST("/users/:id/deactivate", adminHandler.DeactivateUser)
+		admin.POST("/users/:id/activate", adminHandler.ActivateUser)
+		admin.GET("/users/:id/sessions", adminHandler.ListUserSessions)
+		admin.DELETE("/users/:id/sessions", adminHandler.RevokeAllUserSessions)
+		admin.DELETE("/users/:id/sessions/:session_id", adminHandler.RevokeUserSession)
+		admin.GET("/api-keys", apiKeyHandler.ListAPIKeys)
+		admin.POST("/api-keys", apiKeyHandler.CreateAPIKey)
+		admin.DELETE("/api-keys/:id", apiKeyHandler.RevokeAPIKey)
+	}
+
+	// Soft-deleted clinical records
+	deleted := v1.Group("/admin/deleted")
+	deleted.Use(middleware.AuthMiddleware(), require(middleware.PermRecordsRestore))
 	{
-		assessments.GET("", assessmentHandler.GetAllAssessments)
-		assessments.GET("/:id", assessmentHandler.GetAssessmentByID)
-		assessments.POST("", assessmentHandler.CreateAssessment)
-		assessments.POST("/full", assessmentHandler.CreateFullAssessment)
-		assessments.PUT("/:id", assessmentHandler.UpdateAssessment)
-		assessments.DELETE("/:id", assessmentHandler.DeleteAssessment)
-		assessments.GET("/:id/full", reportHandler.GetFullAssessment)
+		deleted.GET("/patients", patientHandler.ListDeletedPatients)
+		deleted.POST("/patients/:id/restore", patientHandler.RestorePatient)
+		deleted.GET("/assessments", assessmentHandler.ListDeletedAssessments)
+		deleted.POST("/assessments/:id/restore", assessmentHandler.RestoreAssessment)
 	}
 
 	// 404
@@ -100,7 +204,7 @@ func corsMiddleware() gin.HandlerFunc {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 		c.Writer.Header().Set("Access-Control-Allow-Headers",
-			"Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+			"Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key, accept, origin, Cache-Control, X-Requested-With")
 		c.Writer.Header().Set("Access-Control-Allow-Methods",
 			"POST, OPTIONS, GET, PUT, DELETE, PATCH")
 
@@ -14,15 +14,29 @@ func SetupAuthRoutes(router *gin.RouterGroup, authHandler *handlers.AuthHandler)
 		// Public routes (no authentication required)
 		auth.POST("/register", authHandler.Register)
 		auth.POST("/login", authHandler.Login)
+		auth.POST("/login/mfa", authHandler.LoginMFA)
+		auth.GET("/sso/login", authHandler.SSOLogin)
+		auth.GET("/sso/callback", authHandler.SSOCallback)
 		auth.POST("/refresh", authHandler.RefreshToken)
+		auth.GET("/verify-email", authHandler.VerifyEmail)
+		auth.POST("/verify-email", authHandler.VerifyEmail)
+		auth.POST("/resend-verification", authHandler.ResendVerification)
+		auth.POST("/forgot-password", authHandler.ForgotPassword)
+		auth.POST("/reset-password", authHandler.ResetPassword)
 
 		// Protected routes (authentication required)
 		protected := auth.Group("")
 		protected.Use(middleware.AuthMiddleware())
 		{
 			protected.POST("/logout", authHandler.Logout)
+			protected.GET("/sessions", authHandler.ListSessions)
+			protected.DELETE("/sessions/:id", authHandler.RevokeSession)
 			protected.GET("/profile", authHandler.GetProfile)
+			protected.PUT("/profile", authHandler.UpdateProfile)
 			protected.POST("/change-password", authHandler.ChangePassword)
+			protected.POST("/mfa/enroll", authHandler.EnrollMFA)
+			protected.POST("/mfa/confirm", authHandler.ConfirmMFA)
+			protected.POST("/mfa/disable", authHandler.DisableMFA)
 		}
 	}
 }
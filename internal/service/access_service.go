@@ -0,0 +1,92 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// AccessService decides which patients' records an authenticated caller may see.
+// Route-level role checks live in middleware.RolePermissions; this service
+// handles the record-level rules that depend on who the caller is.
+type AccessService struct {
+	accessRepo     repository.AccessRepository
+	breakGlassRepo repository.BreakGlassRepository
+}
+
+func NewAccessService(accessRepo repository.AccessRepository, breakGlassRepo repository.BreakGlassRepository) *AccessService {
+	return &AccessService{accessRepo: accessRepo, breakGlassRepo: breakGlassRepo}
+}
+
+// Caller identifies the authenticated principal making a request
+type Caller struct {
+	UserID int
+	Role   string
+
+	// BreakGlassID is non-zero while the caller uses an emergency-access token
+	BreakGlassID int
+}
+
+// GetOwnPatientID resolves the patient record linked to a patient user account.
+// Returns utils.ErrNotFound if the user has no patient profile.
+func (s *AccessService) GetOwnPatientID(userID int) (int, error) {
+	return s.accessRepo.GetPatientIDByUserID(userID)
+}
+
+// GetOwnClinicianID resolves the clinician record linked to a clinician user
+// account. Returns utils.ErrNotClinician if the user has no clinician profile.
+func (s *AccessService) GetOwnClinicianID(userID int) (int, error) {
+	return s.accessRepo.GetClinicianIDByUserID(userID)
+}
+
+// IsElevatedRole reports whether the role may see every patient's records
+// regardless of care-team membership. API keys (models.RoleService) are
+// limited by their permissions rather than by patient.
+func IsElevatedRole(role string) bool {
+	return role == models.RoleAdmin || role == models.RoleService
+}
+
+// ScopeToCareTeam reports whether list results for the caller must be
+// restricted to the patients on their care team
+func (s *AccessService) ScopeToCareTeam(caller Caller) bool {
+	return caller.Role == models.RoleClinician && caller.BreakGlassID == 0
+}
+
+// CanAccessPatient reports whether the caller may read or chart against the
+// given patient's records. Elevated roles and break-glass tokens may access
+// any patient, clinicians only patients on their active care team, and
+// patients only themselves.
+func (s *AccessService) CanAccessPatient(caller Caller, patientID int) (bool, error) {
+	if IsElevatedRole(caller.Role) || caller.BreakGlassID != 0 {
+		return true, nil
+	}
+
+	switch caller.Role {
+	case models.RoleClinician:
+		return s.accessRepo.IsOnCareTeam(caller.UserID, patientID)
+
+	case models.RolePatient:
+		ownPatientID, err := s.GetOwnPatientID(caller.UserID)
+		if errors.Is(err, utils.ErrNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return ownPatientID == patientID, nil
+
+	default:
+		return false, nil
+	}
+}
+
+// RecordAccess audits a read made with a break-glass token; it is a no-op for
+// ordinary tokens. patientID 0 records an access not tied to one patient.
+func (s *AccessService) RecordAccess(caller Caller, patientID int, resource string) error {
+	if caller.BreakGlassID == 0 {
+		return nil
+	}
+	return s.breakGlassRepo.RecordAccess(caller.BreakGlassID, patientID, resource)
+}
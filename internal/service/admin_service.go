@@ -0,0 +1,146 @@
+package service
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// AdminService holds account administration used by the /admin endpoints
+type AdminService struct {
+	authRepo    repository.AuthRepository
+	authService *AuthService
+	denylist    utils.TokenDenylist
+}
+
+// NewAdminService creates the admin service; authService sends the emails
+// for administrator-initiated password resets
+func NewAdminService(authRepo repository.AuthRepository, authService *AuthService, denylist utils.TokenDenylist) *AdminService {
+	return &AdminService{authRepo: authRepo, authService: authService, denylist: denylist}
+}
+
+// ListUsers searches accounts by email or name, role and active state
+func (s *AdminService) ListUsers(filter models.UserFilter) ([]models.UserSummary, int, error) {
+	return s.authRepo.ListUsers(filter)
+}
+
+// GetUser returns the admin view of one account
+func (s *AdminService) GetUser(userID int) (*models.UserSummary, error) {
+	return s.authRepo.GetUserSummary(userID)
+}
+
+// CreateUser creates a clinician or patient account, the only way clinician
+// accounts are made. The user is emailed a verification link.
+func (s *AdminService) CreateUser(req models.CreateUserRequest, adminID int) (*models.UserSummary, error) {
+	user, err := s.authService.CreateAccount(req.Email, req.Password, req.Role, req.FirstName, req.LastName)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[ADMIN] User %d (%s) created as %s by admin %d", user.ID, user.Email, req.Role, adminID)
+	return s.authRepo.GetUserSummary(user.ID)
+}
+
+// ChangeRole moves a user to a new role. Existing tokens carry the old role,
+// so the user is signed out everywhere.
+func (s *AdminService) ChangeRole(userID int, role string, adminID int) (*models.UserSummary, error) {
+	user, err := s.authRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role != role {
+		if err := s.authRepo.ChangeUserRole(userID, role); err != nil {
+			return nil, err
+		}
+		if err := revokeUserAccess(s.authRepo, s.denylist, userID); err != nil {
+			return nil, fmt.Errorf("role changed but failed to revoke sessions: %w", err)
+		}
+		log.Printf("[ADMIN] User %d role changed from %s to %s by admin %d", userID, user.Role, role, adminID)
+	}
+
+	return s.authRepo.GetUserSummary(userID)
+}
+
+// ForcePasswordReset invalidates a user's password: password login is refused
+// and every session revoked until the user completes the emailed reset
+func (s *AdminService) ForcePasswordReset(userID, adminID int) error {
+	if err := s.authService.RequirePasswordReset(userID); err != nil {
+		return err
+	}
+
+	log.Printf("[ADMIN] Password reset forced for user %d by admin %d", userID, adminID)
+	return nil
+}
+
+// RevokeAllUserSessions signs a user out of every session and invalidates
+// their outstanding access tokens
+func (s *AdminService) RevokeAllUserSessions(userID, adminID int) error {
+	if _, err := s.authRepo.GetUserByID(userID); err != nil {
+		return err
+	}
+	if err := revokeUserAccess(s.authRepo, s.denylist, userID); err != nil {
+		return err
+	}
+
+	log.Printf("[ADMIN] All sessions of user %d revoked by admin %d", userID, adminID)
+	return nil
+}
+
+// SetUserActive activates or deactivates an account. Deactivation signs the
+// user out everywhere, including access tokens that have not expired yet.
+func (s *AdminService) SetUserActive(userID int, active bool, adminID int) error {
+	if err := s.authRepo.SetUserActive(userID, active); err != nil {
+		return err
+	}
+
+	if !active {
+		if err := revokeUserAccess(s.authRepo, s.denylist, userID); err != nil {
+			return fmt.Errorf("user deactivated but failed to revoke sessions: %w", err)
+		}
+	}
+
+	log.Printf("[ADMIN] User %d set active=%t by admin %d", userID, active, adminID)
+	return nil
+}
+
+// ListLockedUsers returns every account that is currently locked out
+func (s *AdminService) ListLockedUsers() ([]models.UserLockStatus, error) {
+	return s.authRepo.ListLockedUsers()
+}
+
+// GetLockStatus returns a user's failed-login count and lock state
+func (s *AdminService) GetLockStatus(userID int) (*models.UserLockStatus, error) {
+	return s.authRepo.GetLockStatus(userID)
+}
+
+// ListUserSessions returns a user's active refresh-token sessions
+func (s *AdminService) ListUserSessions(userID int) ([]models.Session, error) {
+	if _, err := s.authRepo.GetUserByID(userID); err != nil {
+		return nil, err
+	}
+	return s.authRepo.ListSessions(userID)
+}
+
+// RevokeUserSession signs out one of a user's sessions
+func (s *AdminService) RevokeUserSession(userID, sessionID, adminID int) error {
+	if err := s.authRepo.RevokeSession(userID, sessionID); err != nil {
+		return err
+	}
+
+	log.Printf("[ADMIN] Session %d of user %d revoked by admin %d", sessionID, userID, adminID)
+	return nil
+}
+
+// UnlockUser clears a lockout and the failed-login count
+func (s *AdminService) UnlockUser(userID, adminID int) (*models.UserLockStatus, error) {
+	if err := s.authRepo.ClearFailedLogins(userID); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[ADMIN] User %d unlocked by admin %d", userID, adminID)
+	return s.authRepo.GetLockStatus(userID)
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// apiKeySecretBytes is the random part of an API key (hex-encoded)
+const apiKeySecretBytes = 32
+
+// apiKeyDisplayPrefixLen is how much of a key is kept in clear for listings
+const apiKeyDisplayPrefixLen = len(models.APIKeyPrefix) + 8
+
+// APIKeyService issues, verifies and revokes machine API keys
+type APIKeyService struct {
+	apiKeyRepo repository.APIKeyRepository
+}
+
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository) *APIKeyService {
+	return &APIKeyService{apiKeyRepo: apiKeyRepo}
+}
+
+// CreateAPIKey issues a new key. Permissions must already be validated by the
+// caller. The returned plaintext key cannot be recovered later.
+func (s *APIKeyService) CreateAPIKey(req models.CreateAPIKeyRequest, adminID int) (*models.CreateAPIKeyResponse, error) {
+	var expiresAt *time.Time
+	if req.ExpiresAt != "" {
+		t, err := time.Parse(time.RFC3339, req.ExpiresAt)
+		if err != nil {
+			return nil, utils.ErrBadRequest
+		}
+		if !t.After(time.Now()) {
+			return nil, utils.ErrInvalidDateRange
+		}
+		expiresAt = &t
+	}
+
+	secret, err := utils.GenerateSecureToken(apiKeySecretBytes)
+	if err != nil {
+		return nil, err
+	}
+	rawKey := models.APIKeyPrefix + secret
+
+	key, err := s.apiKeyRepo.CreateAPIKey(req.Name, rawKey[:apiKeyDisplayPrefixLen], utils.HashToken(rawKey),
+		req.Permissions, adminID, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[ADMIN] API key %d (%s) created by admin %d with permissions %v", key.ID, key.Name, adminID, key.Permissions)
+	return &models.CreateAPIKeyResponse{Key: rawKey, APIKey: *key}, nil
+}
+
+// ListAPIKeys returns every key, including revoked and expired ones
+func (s *APIKeyService) ListAPIKeys() ([]models.APIKey, error) {
+	return s.apiKeyRepo.ListAPIKeys()
+}
+
+// RevokeAPIKey disables a key immediately
+func (s *APIKeyService) RevokeAPIKey(id, adminID int) error {
+	if err := s.apiKeyRepo.RevokeAPIKey(id); err != nil {
+		return err
+	}
+
+	log.Printf("[ADMIN] API key %d revoked by admin %d", id, adminID)
+	return nil
+}
+
+// VerifyAPIKey resolves a presented key and records its use. Unknown,
+// revoked and expired keys all return utils.ErrInvalidAPIKey.
+func (s *APIKeyService) VerifyAPIKey(rawKey string) (*models.APIKey, error) {
+	key, err := s.apiKeyRepo.GetAPIKeyByHash(utils.HashToken(rawKey))
+	if err != nil {
+		return nil, err
+	}
+
+	if !key.IsUsable(time.Now()) {
+		return nil, utils.ErrInvalidAPIKey
+	}
+
+	if err := s.apiKeyRepo.TouchAPIKey(key.ID); err != nil {
+		log.Printf("[AUTH] Failed to record use of API key %d: %v", key.ID, err)
+	}
+
+	return key, nil
+}
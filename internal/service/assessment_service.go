@@ -0,0 +1,201 @@
+package service
+
+import (
+	"time"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// AssessmentService manages wound assessments. As with PatientService,
+// callers check patient access before reading or charting.
+type AssessmentService struct {
+	assessmentRepo repository.AssessmentRepository
+	patientRepo    repository.PatientRepository
+	clinicianRepo  repository.ClinicianRepository
+}
+
+func NewAssessmentService(assessmentRepo repository.AssessmentRepository, patientRepo repository.PatientRepository, clinicianRepo repository.ClinicianRepository) *AssessmentService {
+	return &AssessmentService{assessmentRepo: assessmentRepo, patientRepo: patientRepo, clinicianRepo: clinicianRepo}
+}
+
+// ListAssessments returns a page of assessments matching filter and the total
+// count. A non-zero careTeamUserID limits the result to that clinician user's
+// care team. Returns utils.ErrBadRequest if a date is not RFC 3339.
+func (s *AssessmentService) ListAssessments(filter *models.AssessmentFilter, careTeamUserID int) ([]models.AssessmentListItem, int, error) {
+	q := models.AssessmentQuery{
+		PatientID:      filter.PatientID,
+		ClinicianID:    filter.ClinicianID,
+		CareTeamUserID: careTeamUserID,
+		Limit:          filter.GetLimit(),
+		Offset:         filter.GetOffset(),
+	}
+
+	if filter.StartDate != "" {
+		t, err := time.Parse(time.RFC3339, filter.StartDate)
+		if err != nil {
+			return nil, 0, utils.ErrBadRequest
+		}
+		q.StartDate = &t
+	}
+	if filter.EndDate != "" {
+		t, err := time.Parse(time.RFC3339, filter.EndDate)
+		if err != nil {
+			return nil, 0, utils.ErrBadRequest
+		}
+		q.EndDate = &t
+	}
+
+	return s.assessmentRepo.ListAssessments(q)
+}
+
+// GetAssessment returns utils.ErrNotFound if the assessment does not exist
+func (s *AssessmentService) GetAssessment(assessmentID int) (*models.Assessment, error) {
+	return s.assessmentRepo.GetAssessment(assessmentID)
+}
+
+// CreateAssessment records an assessment header without its child sections
+func (s *AssessmentService) CreateAssessment(req models.CreateAssessmentRequest) (*models.Assessment, error) {
+	if err := s.checkReferences(req); err != nil {
+		return nil, err
+	}
+
+	id, err := s.assessmentRepo.CreateAssessment(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.assessmentRepo.GetAssessment(id)
+}
+
+// CreateFullAssessment records an assessment with every child section and
+// returns the new assessment ID
+func (s *AssessmentService) CreateFullAssessment(req models.FullAssessmentRequest) (int, error) {
+	if err := s.checkReferences(req.CreateAssessmentRequest); err != nil {
+		return 0, err
+	}
+
+	return s.assessmentRepo.CreateFullAssessment(req)
+}
+
+// UpdateAssessment applies the non-empty fields of req to an existing assessment
+func (s *AssessmentService) UpdateAssessment(assessmentID int, req models.UpdateAssessmentRequest) (*models.Assessment, error) {
+	a, err := s.assessmentRepo.GetAssessment(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Location != "" {
+		a.Location = req.Location
+	}
+	if req.Etiology != "" {
+		a.Etiology = req.Etiology
+	}
+	if req.DepthOfInjury != "" {
+		a.DepthOfInjury = req.DepthOfInjury
+	}
+	if req.Stage != "" {
+		a.Stage = req.Stage
+	}
+	if req.Chronicity != "" {
+		a.Chronicity = req.Chronicity
+	}
+	if req.HealingStatus != "" {
+		a.HealingStatus = req.HealingStatus
+	}
+	if req.ReturnToClinic != nil {
+		a.ReturnToClinic = *req.ReturnToClinic
+	}
+
+	if err := s.assessmentRepo.UpdateAssessment(*a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// SaveSections inserts or replaces the non-nil sections of an assessment and
+// returns the updated assessment
+func (s *AssessmentService) SaveSections(assessmentID int, sections models.AssessmentSections) (*models.FullAssessmentResponse, error) {
+	if err := s.assessmentRepo.SaveSections(assessmentID, sections); err != nil {
+		return nil, err
+	}
+	return s.assessmentRepo.GetFullAssessment(assessmentID)
+}
+
+// ReplaceFullAssessment overwrites the header and every section of an
+// assessment at once. The clinician may change but the patient may not
+// (utils.ErrPatientMismatch).
+func (s *AssessmentService) ReplaceFullAssessment(assessmentID int, req models.FullAssessmentRequest) (*models.FullAssessmentResponse, error) {
+	existing, err := s.assessmentRepo.GetAssessment(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if req.PatientID != existing.PatientID {
+		return nil, utils.ErrPatientMismatch
+	}
+	if err := s.checkReferences(req.CreateAssessmentRequest); err != nil {
+		return nil, err
+	}
+
+	a := models.Assessment{
+		AssessmentID:   assessmentID,
+		ClinicianID:    req.ClinicianID,
+		PatientID:      existing.PatientID,
+		Date:           existing.Date,
+		Location:       req.Location,
+		Etiology:       req.Etiology,
+		DepthOfInjury:  req.DepthOfInjury,
+		Stage:          req.Stage,
+		Chronicity:     req.Chronicity,
+		HealingStatus:  req.HealingStatus,
+		ReturnToClinic: req.ReturnToClinic,
+	}
+	if err := s.assessmentRepo.ReplaceFullAssessment(a, req.Sections()); err != nil {
+		return nil, err
+	}
+	return s.assessmentRepo.GetFullAssessment(assessmentID)
+}
+
+// DeleteAssessment soft-deletes an assessment, keeping its sections, and
+// reports what was deleted. Returns utils.ErrNotFound if it does not exist.
+func (s *AssessmentService) DeleteAssessment(assessmentID, deletedBy int, reason string) (*models.DeleteResult, error) {
+	return s.assessmentRepo.DeleteAssessment(assessmentID, deletedBy, reason)
+}
+
+// ListDeletedAssessments returns a page of deleted assessments and the total count
+func (s *AssessmentService) ListDeletedAssessments(params *models.PaginationParams) ([]models.DeletedAssessment, int, error) {
+	return s.assessmentRepo.ListDeletedAssessments(params.GetLimit(), params.GetOffset())
+}
+
+// RestoreAssessment undeletes an assessment. Returns utils.ErrNotFound if it
+// is not deleted, or utils.ErrPatientDeleted if its patient is.
+func (s *AssessmentService) RestoreAssessment(assessmentID int) (*models.RestoreResult, error) {
+	return s.assessmentRepo.RestoreAssessment(assessmentID)
+}
+
+// GetFullAssessment returns utils.ErrNotFound if the assessment does not exist
+func (s *AssessmentService) GetFullAssessment(assessmentID int) (*models.FullAssessmentResponse, error) {
+	return s.assessmentRepo.GetFullAssessment(assessmentID)
+}
+
+// checkReferences returns utils.ErrInvalidPatient or utils.ErrInvalidClinician
+// when the assessment refers to a record that does not exist
+func (s *AssessmentService) checkReferences(req models.CreateAssessmentRequest) error {
+	exists, err := s.patientRepo.PatientExists(req.PatientID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return utils.ErrInvalidPatient
+	}
+
+	exists, err = s.clinicianRepo.ClinicianExists(req.ClinicianID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return utils.ErrInvalidClinician
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClinicianRepo is a map-backed repository.ClinicianRepository
+type fakeClinicianRepo map[int]models.Clinician
+
+func (r fakeClinicianRepo) ListClinicians(limit, offset int) ([]models.Clinician, int, error) {
+	return nil, 0, nil
+}
+
+func (r fakeClinicianRepo) GetClinician(clinicianID int) (*models.Clinician, error) {
+	cl, ok := r[clinicianID]
+	if !ok {
+		return nil, utils.ErrNotFound
+	}
+	return &cl, nil
+}
+
+func (r fakeClinicianRepo) CreateClinician(cl models.Clinician) (int, error) {
+	cl.ClinicianID = len(r) + 1
+	r[cl.ClinicianID] = cl
+	return cl.ClinicianID, nil
+}
+
+func (r fakeClinicianRepo) UpdateClinician(cl models.Clinician) error {
+	r[cl.ClinicianID] = cl
+	return nil
+}
+
+func (r fakeClinicianRepo) DeleteClinician(clinicianID int, mode models.DeleteMode) (*models.DeleteResult, error) {
+	delete(r, clinicianID)
+	result := models.NewDeleteResult(mode)
+	result.Add("clinician", 1)
+	return result, nil
+}
+
+func (r fakeClinicianRepo) ClinicianExists(clinicianID int) (bool, error) {
+	_, ok := r[clinicianID]
+	return ok, nil
+}
+
+// fakeAssessmentRepo is a map-backed repository.AssessmentRepository that
+// also records the last list query it was given
+type fakeAssessmentRepo struct {
+	assessments map[int]models.Assessment
+	lastQuery   models.AssessmentQuery
+}
+
+func (r *fakeAssessmentRepo) ListAssessments(q models.AssessmentQuery) ([]models.AssessmentListItem, int, error) {
+	r.lastQuery = q
+	return []models.AssessmentListItem{}, 0, nil
+}
+
+func (r *fakeAssessmentRepo) GetAssessment(assessmentID int) (*models.Assessment, error) {
+	a, ok := r.assessments[assessmentID]
+	if !ok {
+		return nil, utils.ErrNotFound
+	}
+	return &a, nil
+}
+
+func (r *fakeAssessmentRepo) CreateAssessment(req models.CreateAssessmentRequest) (int, error) {
+	id := len(r.assessments) + 1
+	r.assessments[id] = models.Assessment{
+		AssessmentID: id, PatientID: req.PatientID, ClinicianID: req.ClinicianID, Location: req.Location,
+	}
+	return id, nil
+}
+
+func (r *fakeAssessmentRepo) CreateFullAssessment(req models.FullAssessmentRequest) (int, error) {
+	return r.CreateAssessment(req.CreateAssessmentRequest)
+}
+
+func (r *fakeAssessmentRepo) UpdateAssessment(a models.Assessment) error {
+	r.assessments[a.AssessmentID] = a
+	return nil
+}
+
+func (r *fakeAssessmentRepo) SaveSections(assessmentID int, sections models.AssessmentSections) error {
+	if _, ok := r.assessments[assessmentID]; !ok {
+		return utils.ErrNotFound
+	}
+	return nil
+}
+
+func (r *fakeAssessmentRepo) ReplaceFullAssessment(a models.Assessment, sections models.AssessmentSections) error {
+	if _, ok := r.assessments[a.AssessmentID]; !ok {
+		return utils.ErrNotFound
+	}
+	r.assessments[a.AssessmentID] = a
+	return nil
+}
+
+func (r *fakeAssessmentRepo) DeleteAssessment(assessmentID, deletedBy int, reason string) (*models.DeleteResult, error) {
+	if _, ok := r.assessments[assessmentID]; !ok {
+		return nil, utils.ErrNotFound
+	}
+	delete(r.assessments, assessmentID)
+	result := models.NewDeleteResult(models.DeleteCascade)
+	result.Add("assessment", 1)
+	result.AssessmentIDs = append(result.AssessmentIDs, assessmentID)
+	return result, nil
+}
+
+func (r *fakeAssessmentRepo) ListDeletedAssessments(limit, offset int) ([]models.DeletedAssessment, int, error) {
+	return []models.DeletedAssessment{}, 0, nil
+}
+
+func (r *fakeAssessmentRepo) RestoreAssessment(assessmentID int) (*models.RestoreResult, error) {
+	return nil, utils.ErrNotFound
+}
+
+func (r *fakeAssessmentRepo) GetFullAssessment(assessmentID int) (*models.FullAssessmentResponse, error) {
+	a, ok := r.assessments[assessmentID]
+	if !ok {
+		return nil, utils.ErrNotFound
+	}
+	return &models.FullAssessmentResponse{AssessmentID: a.AssessmentID, PatientID: a.PatientID}, nil
+}
+
+func newTestAssessmentService() (*AssessmentService, *fakeAssessmentRepo) {
+	assessments := &fakeAssessmentRepo{assessments: map[int]models.Assessment{}}
+	patients := newFakePatientRepo(models.Patient{PatientID: 1})
+	clinicians := fakeClinicianRepo{5: {ClinicianID: 5}}
+	return NewAssessmentService(assessments, patients, clinicians), assessments
+}
+
+func TestAssessmentServiceCreateChecksReferences(t *testing.T) {
+	svc, _ := newTestAssessmentService()
+
+	_, err := svc.CreateAssessment(models.CreateAssessmentRequest{PatientID: 2, ClinicianID: 5})
+	assert.ErrorIs(t, err, utils.ErrInvalidPatient)
+
+	_, err = svc.CreateAssessment(models.CreateAssessmentRequest{PatientID: 1, ClinicianID: 6})
+	assert.ErrorIs(t, err, utils.ErrInvalidClinician)
+
+	_, err = svc.CreateFullAssessment(models.FullAssessmentRequest{
+		CreateAssessmentRequest: models.CreateAssessmentRequest{PatientID: 2, ClinicianID: 5},
+	})
+	assert.ErrorIs(t, err, utils.ErrInvalidPatient)
+
+	a, err := svc.CreateAssessment(models.CreateAssessmentRequest{PatientID: 1, ClinicianID: 5, Location: "Heel"})
+	require.NoError(t, err)
+	assert.Equal(t, "Heel", a.Location)
+}
+
+func TestAssessmentServiceListParsesFilter(t *testing.T) {
+	svc, repo := newTestAssessmentService()
+
+	_, _, err := svc.ListAssessments(&models.AssessmentFilter{StartDate: "2024-01-15"}, 0)
+	assert.ErrorIs(t, err, utils.ErrBadRequest)
+
+	patientID := 1
+	filter := models.AssessmentFilter{
+		PatientID: &patientID,
+		StartDate: "2024-01-01T00:00:00Z",
+		EndDate:   "2024-02-01T00:00:00Z",
+	}
+	filter.Page, filter.PageSize = 2, 20
+
+	_, _, err = svc.ListAssessments(&filter, 42)
+	require.NoError(t, err)
+
+	q := repo.lastQuery
+	assert.Equal(t, &patientID, q.PatientID)
+	require.NotNil(t, q.StartDate)
+	require.NotNil(t, q.EndDate)
+	assert.True(t, q.StartDate.Before(*q.EndDate))
+	assert.Equal(t, 42, q.CareTeamUserID)
+	assert.Equal(t, 20, q.Limit)
+	assert.Equal(t, 20, q.Offset)
+}
+
+func TestAssessmentServiceUpdateAppliesReturnToClinic(t *testing.T) {
+	svc, repo := newTestAssessmentService()
+	repo.assessments[1] = models.Assessment{AssessmentID: 1, PatientID: 1, Stage: "2", ReturnToClinic: true}
+
+	no := false
+	a, err := svc.UpdateAssessment(1, models.UpdateAssessmentRequest{ReturnToClinic: &no})
+	require.NoError(t, err)
+	assert.False(t, a.ReturnToClinic)
+	assert.Equal(t, "2", a.Stage)
+
+	_, err = svc.UpdateAssessment(9, models.UpdateAssessmentRequest{Stage: "3"})
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+}
+
+func TestAssessmentServiceReplaceFullKeepsPatient(t *testing.T) {
+	svc, repo := newTestAssessmentService()
+	repo.assessments[1] = models.Assessment{AssessmentID: 1, PatientID: 1, ClinicianID: 5, Location: "Heel"}
+
+	req := models.FullAssessmentRequest{
+		CreateAssessmentRequest: models.CreateAssessmentRequest{PatientID: 2, ClinicianID: 5, Location: "Toe"},
+	}
+	_, err := svc.ReplaceFullAssessment(1, req)
+	assert.ErrorIs(t, err, utils.ErrPatientMismatch)
+
+	req.PatientID, req.ClinicianID = 1, 6
+	_, err = svc.ReplaceFullAssessment(1, req)
+	assert.ErrorIs(t, err, utils.ErrInvalidClinician)
+
+	req.ClinicianID = 5
+	_, err = svc.ReplaceFullAssessment(1, req)
+	require.NoError(t, err)
+	assert.Equal(t, "Toe", repo.assessments[1].Location)
+
+	_, err = svc.ReplaceFullAssessment(9, req)
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+}
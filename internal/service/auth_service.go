@@ -1,32 +1,94 @@
 package service
 
 import (
+	"context"
+	"crypto/subtle"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/mailer"
 	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/oidc"
 	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository"
 	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
 )
 
+// AuthConfig holds the configurable authentication policies
+type AuthConfig struct {
+	// AppBaseURL is the public URL used to build links sent by email
+	AppBaseURL string
+
+	// PasswordResetURL is the client page that accepts a reset token;
+	// the token is appended as a "token" query parameter
+	PasswordResetURL string
+
+	// RequireEmailVerification blocks login until the user verifies their email
+	RequireEmailVerification bool
+
+	// MFARequiredRoles lists the roles that must enroll in TOTP MFA; until
+	// they do, their access tokens only reach the /auth endpoints
+	MFARequiredRoles []string
+
+	// MFAIssuer is the account issuer shown in authenticator apps
+	MFAIssuer string
+
+	// LockoutThreshold consecutive failed logins lock the account for
+	// LockoutDuration; 0 disables lockout
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+
+	// IPThrottleThreshold failed logins from one client IP within
+	// IPThrottleWindow refuse further attempts from it; 0 disables throttling
+	IPThrottleThreshold int
+	IPThrottleWindow    time.Duration
+
+	// PasswordPolicy applies to every new password (registration, change
+	// and reset); a zero policy falls back to utils.DefaultPasswordPolicy
+	PasswordPolicy utils.PasswordPolicy
+
+	// SSO is the OIDC identity provider; nil disables single sign-on
+	SSO *oidc.Provider
+
+	// SSOProvisionClinicians creates a clinician account on first SSO login
+	// when no user matches the identity or its verified email
+	SSOProvisionClinicians bool
+
+	// SSOTrustIdPMFA skips the local MFA challenge after SSO, for identity
+	// providers that already enforce a second factor; off by default
+	SSOTrustIdPMFA bool
+}
+
+// recoveryCodeCount is the number of recovery codes issued on MFA enrollment
+const recoveryCodeCount = 10
+
 type AuthService struct {
-	authRepo *repository.AuthRepository
+	authRepo repository.AuthRepository
+	mailer   mailer.Mailer
+	denylist utils.TokenDenylist
+	cfg      AuthConfig
 }
 
-func NewAuthService(authRepo *repository.AuthRepository) *AuthService {
-	return &AuthService{authRepo: authRepo}
+func NewAuthService(authRepo repository.AuthRepository, mailer mailer.Mailer, denylist utils.TokenDenylist, cfg AuthConfig) *AuthService {
+	if cfg.PasswordPolicy.MinLength == 0 && cfg.PasswordPolicy.MaxLength == 0 {
+		cfg.PasswordPolicy = utils.DefaultPasswordPolicy()
+	}
+	return &AuthService{authRepo: authRepo, mailer: mailer, denylist: denylist, cfg: cfg}
 }
 
-// Register creates a new user account
-func (s *AuthService) Register(req models.RegisterRequest) (*models.LoginResponse, error) {
-	// Validate password strength
-	if err := utils.ValidatePasswordStrength(req.Password); err != nil {
+// CreateAccount creates a user with the profile for role and emails them a
+// verification link. Returns utils.ErrEmailExists or a
+// *utils.PasswordPolicyError.
+func (s *AuthService) CreateAccount(email, password, role, firstName, lastName string) (*models.User, error) {
+	// Validate password against the policy
+	if err := s.cfg.PasswordPolicy.Validate(password); err != nil {
 		return nil, err
 	}
 
 	// Check if email already exists
-	exists, err := s.authRepo.EmailExists(req.Email)
+	exists, err := s.authRepo.EmailExists(email)
 	if err != nil {
 		return nil, err
 	}
@@ -35,31 +97,46 @@ func (s *AuthService) Register(req models.RegisterRequest) (*models.LoginRespons
 	}
 
 	// Create user (this creates both user and profile records)
-	user, err := s.authRepo.CreateUser(
-		req.Email,
-		req.Password,
-		req.Role,
-		req.FirstName,
-		req.LastName,
-	)
+	user, err := s.authRepo.CreateUser(email, password, role, firstName, lastName)
 	if err != nil {
 		return nil, err
 	}
 
-	// Generate tokens
-	accessToken, err := utils.GenerateAccessToken(user.ID, user.Email, user.Role)
+	// Send verification email; the account is still created if delivery
+	// fails because the user can request a new link
+	if err := s.sendVerificationEmail(user); err != nil {
+		log.Printf("[AUTH] Warning: Failed to send verification email to %s: %v", user.Email, err)
+	}
+	return user, nil
+}
+
+// Register creates a new user account
+func (s *AuthService) Register(req models.RegisterRequest, client models.ClientInfo) (*models.LoginResponse, error) {
+	// Self-registration only creates patients; clinician accounts are
+	// created by an administrator
+	user, err := s.CreateAccount(req.Email, req.Password, models.RolePatient, req.FirstName, req.LastName)
 	if err != nil {
 		return nil, err
 	}
 
-	refreshToken, err := utils.GenerateRefreshToken(user.ID)
+	// Without a verified email the user cannot log in, so no tokens are issued
+	if s.cfg.RequireEmailVerification {
+		profile, err := s.authRepo.GetUserWithProfile(user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("user created but profile fetch failed: %w", err)
+		}
+		return &models.LoginResponse{User: *profile}, nil
+	}
+
+	// Generate tokens
+	accessToken, err := s.generateAccessToken(user)
 	if err != nil {
 		return nil, err
 	}
 
-	// Save refresh token
-	expiresAt := time.Now().Add(utils.RefreshTokenExpiry)
-	if err := s.authRepo.SaveRefreshToken(user.ID, refreshToken, expiresAt); err != nil {
+	// Generate and save refresh token
+	refreshToken, err := s.startSession(user.ID, client)
+	if err != nil {
 		return nil, err
 	}
 
@@ -79,7 +156,10 @@ func (s *AuthService) Register(req models.RegisterRequest) (*models.LoginRespons
 // Login authenticates a user and returns tokens
 // Authentication uses ONLY the users table
 // Profile is fetched separately for display purposes
-func (s *AuthService) Login(req models.LoginRequest) (*models.LoginResponse, error) {
+// Users with MFA enabled get an MFA challenge instead of tokens; the challenge
+// is completed with LoginMFA.
+// Failed attempts are counted per user and per client IP (see AuthConfig).
+func (s *AuthService) Login(req models.LoginRequest, client models.ClientInfo) (*models.LoginResponse, *models.MFAChallengeResponse, error) {
 
 	log.Printf("[AUTH] Login attempt for email: %s", req.Email)
 
@@ -87,11 +167,18 @@ func (s *AuthService) Login(req models.LoginRequest) (*models.LoginResponse, err
 	// AUTHENTICATION PHASE - Uses ONLY users table
 	// ============================================================
 
+	// Step 0: Refuse clients that have failed too often recently
+	if err := s.checkIPThrottle(client.IP); err != nil {
+		log.Printf("[AUTH] Login refused: Too many failures from %s", client.IP)
+		return nil, nil, err
+	}
+
 	// Step 1: Get user by email from users table
 	user, err := s.authRepo.GetUserByEmail(req.Email)
 	if err != nil {
 		log.Printf("[AUTH] Login failed: User not found - %s", req.Email)
-		return nil, utils.ErrInvalidCredentials
+		s.recordIPFailure(client.IP, req.Email)
+		return nil, nil, utils.ErrInvalidCredentials
 	}
 
 	log.Printf("[AUTH] User found: ID=%d, Email=%s, Role=%s", user.ID, user.Email, user.Role)
@@ -99,47 +186,326 @@ func (s *AuthService) Login(req models.LoginRequest) (*models.LoginResponse, err
 	// Step 2: Check if user is active
 	if !user.IsActive {
 		log.Printf("[AUTH] Login failed: User inactive - %s", req.Email)
-		return nil, utils.ErrUserInactive
+		return nil, nil, utils.ErrUserInactive
+	}
+
+	// Step 2b: Check lockout before looking at the password so a locked
+	// account cannot be used to keep guessing
+	if user.IsLocked(time.Now()) {
+		log.Printf("[AUTH] Login failed: Account locked until %s - %s", user.LockedUntil.Time.Format(time.RFC3339), req.Email)
+		return nil, nil, utils.ErrAccountLocked
 	}
 
 	// Step 3: Verify password
 	if !utils.CheckPassword(req.Password, user.PasswordHash) {
 		log.Printf("[AUTH] Login failed: Invalid password - %s", req.Email)
-		return nil, utils.ErrInvalidCredentials
+		return nil, nil, s.recordFailedLogin(user, client.IP)
+	}
+
+	// Step 3a: Upgrade hashes made with an old algorithm or cost
+	s.upgradePasswordHash(user, req.Password)
+
+	// Step 3b: An administrator may have invalidated the password
+	if user.PasswordResetRequired {
+		log.Printf("[AUTH] Login failed: Password reset required - %s", req.Email)
+		return nil, nil, utils.ErrPasswordResetRequired
+	}
+
+	// Step 3c: Passwords older than the policy's maximum age must be reset
+	if s.cfg.PasswordPolicy.Expired(user.PasswordChangedAt, time.Now()) {
+		log.Printf("[AUTH] Login failed: Password expired - %s", req.Email)
+		return nil, nil, utils.ErrPasswordExpired
+	}
+
+	// Step 4: Check email verification
+	if s.cfg.RequireEmailVerification && !user.EmailVerified {
+		log.Printf("[AUTH] Login failed: Email not verified - %s", req.Email)
+		return nil, nil, utils.ErrEmailNotVerified
+	}
+
+	// Step 5: Require the second factor if the user has enrolled
+	challenge, err := s.mfaChallenge(user)
+	if err != nil || challenge != nil {
+		if challenge != nil {
+			log.Printf("[AUTH] Password accepted, MFA challenge issued for: %s", req.Email)
+		}
+		return nil, challenge, err
 	}
 
 	log.Printf("[AUTH] ✅ Authentication successful for user: %s", req.Email)
 
+	response, err := s.completeLogin(user, client)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, nil, nil
+}
+
+// mfaChallenge issues an MFA challenge when the user has enrolled a second
+// factor; it returns nil when the login can complete without one
+func (s *AuthService) mfaChallenge(user *models.User) (*models.MFAChallengeResponse, error) {
+	mfa, err := s.authRepo.GetMFA(user.ID)
+	if err != nil && !errors.Is(err, utils.ErrMFANotEnrolled) {
+		return nil, err
+	}
+	if mfa == nil || !mfa.Enabled {
+		return nil, nil
+	}
+
+	mfaToken, expiresAt, err := utils.GenerateMFAChallengeToken(user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate MFA challenge: %w", err)
+	}
+
+	return &models.MFAChallengeResponse{
+		MFARequired: true,
+		MFAToken:    mfaToken,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// LoginMFA completes a login by exchanging an MFA challenge token and a TOTP
+// or recovery code for the normal login response
+func (s *AuthService) LoginMFA(req models.MFALoginRequest, client models.ClientInfo) (*models.LoginResponse, error) {
+	if err := s.checkIPThrottle(client.IP); err != nil {
+		return nil, err
+	}
+
+	claims, err := utils.ValidateMFAChallengeToken(req.MFAToken)
+	if err != nil {
+		return nil, utils.ErrInvalidToken
+	}
+
+	user, err := s.authRepo.GetUserByID(claims.UserID)
+	if err != nil {
+		return nil, utils.ErrInvalidToken
+	}
+
+	if !user.IsActive {
+		return nil, utils.ErrUserInactive
+	}
+
+	if user.IsLocked(time.Now()) {
+		return nil, utils.ErrAccountLocked
+	}
+
+	mfa, err := s.authRepo.GetMFA(user.ID)
+	if errors.Is(err, utils.ErrMFANotEnrolled) || (err == nil && !mfa.Enabled) {
+		return nil, utils.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.verifyMFACode(mfa, req.Code); err != nil {
+		log.Printf("[AUTH] MFA failed for user: %d", user.ID)
+		if errors.Is(err, utils.ErrInvalidMFACode) {
+			if lockErr := s.recordFailedLogin(user, client.IP); errors.Is(lockErr, utils.ErrAccountLocked) {
+				return nil, lockErr
+			}
+		}
+		return nil, err
+	}
+
+	log.Printf("[AUTH] ✅ MFA successful for user: %s", user.Email)
+
+	return s.completeLogin(user, client)
+}
+
+// StartSSO begins an OIDC login. It returns the identity provider URL to
+// redirect to and a state token the caller must hand back to CompleteSSO.
+func (s *AuthService) StartSSO(ctx context.Context) (string, string, error) {
+	if s.cfg.SSO == nil {
+		return "", "", utils.ErrSSONotConfigured
+	}
+
+	state, err := utils.GenerateSecureToken(16)
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := utils.GenerateSecureToken(16)
+	if err != nil {
+		return "", "", err
+	}
+	verifier, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return "", "", err
+	}
+
+	stateToken, err := utils.GenerateSSOStateToken(state, nonce, verifier)
+	if err != nil {
+		return "", "", err
+	}
+
+	authURL, err := s.cfg.SSO.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return "", "", err
+	}
+
+	return authURL, stateToken, nil
+}
+
+// CompleteSSO finishes an OIDC login: the authorization code is exchanged,
+// the identity is mapped to a user and the normal token pair is issued.
+// Lockout applies as for password logins, and users with MFA enabled get a
+// challenge instead of tokens unless SSOTrustIdPMFA is set
+func (s *AuthService) CompleteSSO(ctx context.Context, code, state, stateToken string, client models.ClientInfo) (*models.LoginResponse, *models.MFAChallengeResponse, error) {
+	if s.cfg.SSO == nil {
+		return nil, nil, utils.ErrSSONotConfigured
+	}
+
+	st, err := utils.ValidateSSOStateToken(stateToken)
+	if err != nil || subtle.ConstantTimeCompare([]byte(st.State), []byte(state)) != 1 {
+		return nil, nil, utils.ErrInvalidToken
+	}
+
+	claims, err := s.cfg.SSO.Exchange(ctx, code, st.Verifier, st.Nonce)
+	if err != nil {
+		log.Printf("[AUTH] SSO exchange failed: %v", err)
+		return nil, nil, utils.ErrSSOFailed
+	}
+
+	user, err := s.resolveSSOUser(claims)
+	if err != nil {
+		log.Printf("[AUTH] SSO login failed for %s (%s): %v", claims.Subject, claims.Email, err)
+		return nil, nil, err
+	}
+
+	if !user.IsActive {
+		log.Printf("[AUTH] SSO login failed: User inactive - %s", user.Email)
+		return nil, nil, utils.ErrUserInactive
+	}
+
+	if user.IsLocked(time.Now()) {
+		log.Printf("[AUTH] SSO login failed: Account locked until %s - %s", user.LockedUntil.Time.Format(time.RFC3339), user.Email)
+		return nil, nil, utils.ErrAccountLocked
+	}
+
+	if !s.cfg.SSOTrustIdPMFA {
+		challenge, err := s.mfaChallenge(user)
+		if err != nil || challenge != nil {
+			if challenge != nil {
+				log.Printf("[AUTH] SSO identity accepted, MFA challenge issued for: %s", user.Email)
+			}
+			return nil, challenge, err
+		}
+	}
+
+	log.Printf("[AUTH] ✅ SSO authentication successful for user: %s", user.Email)
+
+	response, err := s.completeLogin(user, client)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, nil, nil
+}
+
+// resolveSSOUser maps an IdP identity to a user: first by linked subject,
+// then by an email verified on both sides (linking it), then by provisioning
+// a clinician
+func (s *AuthService) resolveSSOUser(claims *oidc.Claims) (*models.User, error) {
+	user, err := s.authRepo.GetUserByIdentity(claims.Issuer, claims.Subject)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, utils.ErrUserNotFound) {
+		return nil, err
+	}
+
+	// Only an address the IdP has verified may claim an existing account
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, utils.ErrSSOAccountNotFound
+	}
+
+	user, err = s.authRepo.GetUserByEmail(claims.Email)
+	if err == nil {
+		// An unverified local account may have been registered by someone
+		// who does not own the address; linking it would hand them the
+		// IdP user's sign-in
+		if !user.EmailVerified {
+			log.Printf("[AUTH] Refusing to link SSO identity %s to unverified user %d", claims.Subject, user.ID)
+			return nil, utils.ErrSSOAccountNotFound
+		}
+		if err := s.authRepo.LinkIdentity(user.ID, claims.Issuer, claims.Subject, claims.Email); err != nil {
+			return nil, err
+		}
+		log.Printf("[AUTH] Linked SSO identity %s to user %d", claims.Subject, user.ID)
+		return user, nil
+	}
+	if !errors.Is(err, utils.ErrUserNotFound) {
+		return nil, err
+	}
+
+	if !s.cfg.SSOProvisionClinicians {
+		return nil, utils.ErrSSOAccountNotFound
+	}
+
+	return s.provisionSSOClinician(claims)
+}
+
+// provisionSSOClinician creates a clinician for a first-time SSO user. The
+// random password is never shown; the user signs in through the IdP.
+func (s *AuthService) provisionSSOClinician(claims *oidc.Claims) (*models.User, error) {
+	firstName, lastName := claims.GivenName, claims.FamilyName
+	if firstName == "" && lastName == "" {
+		firstName, lastName, _ = strings.Cut(claims.Name, " ")
+	}
+
+	password, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.authRepo.CreateUser(claims.Email, password, models.RoleClinician, firstName, lastName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authRepo.LinkIdentity(user.ID, claims.Issuer, claims.Subject, claims.Email); err != nil {
+		return nil, err
+	}
+	if err := s.authRepo.MarkEmailVerified(user.ID); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[AUTH] Provisioned clinician %d from SSO identity %s", user.ID, claims.Subject)
+	return s.authRepo.GetUserByID(user.ID)
+}
+
+// completeLogin issues tokens and loads the profile for an authenticated user
+func (s *AuthService) completeLogin(user *models.User, client models.ClientInfo) (*models.LoginResponse, error) {
 	// ============================================================
 	// AUTHENTICATION COMPLETE
 	// Everything below uses authenticated user info
 	// ============================================================
 
-	// Step 4: Generate tokens
-	accessToken, err := utils.GenerateAccessToken(user.ID, user.Email, user.Role)
+	// Step 6: Generate tokens
+	accessToken, err := s.generateAccessToken(user)
 	if err != nil {
 		log.Printf("[AUTH] Failed to generate access token: %v", err)
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
-	refreshToken, err := utils.GenerateRefreshToken(user.ID)
+	// Step 7: Generate and save refresh token (starts a new token family)
+	refreshToken, err := s.startSession(user.ID, client)
 	if err != nil {
-		log.Printf("[AUTH] Failed to generate refresh token: %v", err)
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+		log.Printf("[AUTH] Failed to create refresh token: %v", err)
+		return nil, fmt.Errorf("failed to create refresh token: %w", err)
 	}
 
-	// Step 5: Save refresh token
-	expiresAt := time.Now().Add(utils.RefreshTokenExpiry)
-	if err := s.authRepo.SaveRefreshToken(user.ID, refreshToken, expiresAt); err != nil {
-		log.Printf("[AUTH] Warning: Failed to save refresh token: %v", err)
-		// Continue anyway - user can still use access token
+	// Reset the failure counter only once every factor has passed, so a known
+	// password cannot be used to keep retrying MFA codes
+	if user.FailedLoginAttempts > 0 {
+		if err := s.authRepo.ClearFailedLogins(user.ID); err != nil {
+			log.Printf("[AUTH] Warning: Failed to reset failed login count: %v", err)
+		}
 	}
 
 	// ============================================================
 	// PROFILE FETCHING - Separate from authentication
 	// ============================================================
 
-	// Step 6: Fetch profile from clinicians/patients table
+	// Step 8: Fetch profile from clinicians/patients table
 	log.Printf("[AUTH] Fetching profile for user: %d", user.ID)
 	profile, err := s.authRepo.GetUserWithProfile(user.ID)
 	if err != nil {
@@ -158,7 +524,7 @@ func (s *AuthService) Login(req models.LoginRequest) (*models.LoginResponse, err
 	}
 
 	log.Printf("[AUTH] ✅ Profile fetched: %s %s", profile.FirstName, profile.LastName)
-	log.Printf("[AUTH] ✅ Login complete for: %s", req.Email)
+	log.Printf("[AUTH] ✅ Login complete for: %s", user.Email)
 
 	return &models.LoginResponse{
 		User:         *profile,
@@ -167,14 +533,30 @@ func (s *AuthService) Login(req models.LoginRequest) (*models.LoginResponse, err
 	}, nil
 }
 
-// RefreshToken generates new tokens using a refresh token
-func (s *AuthService) RefreshToken(refreshToken string) (*models.LoginResponse, error) {
-	// Validate refresh token
-	rt, err := s.authRepo.ValidateRefreshToken(refreshToken)
+// RefreshToken rotates a refresh token: the presented token is revoked and a
+// new one in the same family is returned with a new access token.
+// Presenting an already-rotated token is treated as theft: the whole family
+// is revoked and a security event is recorded.
+func (s *AuthService) RefreshToken(refreshToken string, client models.ClientInfo) (*models.LoginResponse, error) {
+	// Look up refresh token by hash
+	rt, err := s.authRepo.GetRefreshToken(utils.HashToken(refreshToken))
 	if err != nil {
 		return nil, utils.ErrInvalidToken
 	}
 
+	// A rotated token presented again means it was stolen; one revoked by
+	// logout or a password change is simply no longer valid
+	if rt.Revoked {
+		if rt.Rotated {
+			s.handleTokenReuse(rt, client)
+		}
+		return nil, utils.ErrInvalidToken
+	}
+
+	if !rt.ExpiresAt.After(time.Now()) {
+		return nil, utils.ErrInvalidToken
+	}
+
 	// Get user
 	user, err := s.authRepo.GetUserByID(rt.UserID)
 	if err != nil {
@@ -186,7 +568,7 @@ func (s *AuthService) RefreshToken(refreshToken string) (*models.LoginResponse,
 	}
 
 	// Generate new tokens
-	newAccessToken, err := utils.GenerateAccessToken(user.ID, user.Email, user.Role)
+	newAccessToken, err := s.generateAccessToken(user)
 	if err != nil {
 		return nil, err
 	}
@@ -196,14 +578,14 @@ func (s *AuthService) RefreshToken(refreshToken string) (*models.LoginResponse,
 		return nil, err
 	}
 
-	// Revoke old refresh token
-	if err := s.authRepo.RevokeRefreshToken(refreshToken); err != nil {
-		return nil, err
-	}
-
-	// Save new refresh token
+	// Revoke old refresh token and save the new one in the same family
 	expiresAt := time.Now().Add(utils.RefreshTokenExpiry)
-	if err := s.authRepo.SaveRefreshToken(user.ID, newRefreshToken, expiresAt); err != nil {
+	err = s.authRepo.RotateRefreshToken(rt, utils.HashToken(newRefreshToken), expiresAt, client)
+	if errors.Is(err, utils.ErrTokenReused) {
+		s.handleTokenReuse(rt, client)
+		return nil, utils.ErrInvalidToken
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -220,9 +602,65 @@ func (s *AuthService) RefreshToken(refreshToken string) (*models.LoginResponse,
 	}, nil
 }
 
-// Logout revokes all user tokens
-func (s *AuthService) Logout(userID int) error {
-	return s.authRepo.RevokeAllUserTokens(userID)
+// startSession issues a refresh token in a new token family
+func (s *AuthService) startSession(userID int, client models.ClientInfo) (string, error) {
+	refreshToken, err := utils.GenerateRefreshToken(userID)
+	if err != nil {
+		return "", err
+	}
+
+	familyID, err := utils.GenerateSecureToken(16)
+	if err != nil {
+		return "", err
+	}
+
+	expiresAt := time.Now().Add(utils.RefreshTokenExpiry)
+	if err := s.authRepo.SaveRefreshToken(userID, utils.HashToken(refreshToken), familyID, expiresAt, client); err != nil {
+		return "", err
+	}
+
+	return refreshToken, nil
+}
+
+// handleTokenReuse revokes every token in the family of a reused refresh
+// token and records a security event
+func (s *AuthService) handleTokenReuse(rt *models.RefreshToken, client models.ClientInfo) {
+	log.Printf("[SECURITY] Refresh token reuse detected for user %d (token %d) from %s; revoking family",
+		rt.UserID, rt.ID, client.IP)
+
+	if err := s.authRepo.RevokeTokenFamily(rt.FamilyID); err != nil {
+		log.Printf("[SECURITY] Failed to revoke token family for user %d: %v", rt.UserID, err)
+	}
+
+	detail := fmt.Sprintf("revoked refresh token %d presented again; token family revoked", rt.ID)
+	if err := s.authRepo.RecordSecurityEvent(rt.UserID, models.SecurityEventRefreshTokenReuse, detail, client); err != nil {
+		log.Printf("[SECURITY] Failed to record security event for user %d: %v", rt.UserID, err)
+	}
+}
+
+// Logout revokes all user refresh tokens and the access token used for the
+// request (identified by its jti), which stops working immediately
+func (s *AuthService) Logout(userID int, tokenID string, tokenExpiresAt time.Time) error {
+	if err := s.authRepo.RevokeAllUserTokens(userID); err != nil {
+		return err
+	}
+	return s.denylist.RevokeToken(tokenID, tokenExpiresAt)
+}
+
+// ListSessions returns the user's active refresh-token sessions
+func (s *AuthService) ListSessions(userID int) ([]models.Session, error) {
+	return s.authRepo.ListSessions(userID)
+}
+
+// RevokeSession signs out a single session. Access tokens already issued to
+// it remain valid until they expire.
+func (s *AuthService) RevokeSession(userID, sessionID int) error {
+	if err := s.authRepo.RevokeSession(userID, sessionID); err != nil {
+		return err
+	}
+
+	log.Printf("[AUTH] Session %d revoked for user: %d", sessionID, userID)
+	return nil
 }
 
 // ChangePassword changes user password
@@ -239,12 +677,20 @@ func (s *AuthService) ChangePassword(userID int, req models.ChangePasswordReques
 	}
 
 	// Validate new password
-	if err := utils.ValidatePasswordStrength(req.NewPassword); err != nil {
+	if err := s.validateNewPassword(userID, req.NewPassword); err != nil {
 		return err
 	}
 
 	// Update password in users table
-	return s.authRepo.UpdatePassword(userID, req.NewPassword)
+	if err := s.authRepo.UpdatePassword(userID, req.NewPassword); err != nil {
+		return err
+	}
+
+	// Sign out every session, including tokens obtained with the old password
+	if err := revokeUserAccess(s.authRepo, s.denylist, userID); err != nil {
+		return fmt.Errorf("password changed but failed to revoke sessions: %w", err)
+	}
+	return nil
 }
 
 // GetUserProfile gets user profile by ID
@@ -256,3 +702,434 @@ func (s *AuthService) GetUserProfile(userID int) (*models.UserWithProfile, error
 	}
 	return profile, nil
 }
+
+// UpdateProfile updates the user's name and/or email.
+// Changing the email marks it unverified and sends a new verification link.
+func (s *AuthService) UpdateProfile(userID int, req models.UpdateProfileRequest) (*models.UserWithProfile, error) {
+	user, err := s.authRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	newEmail := ""
+	if req.Email != "" && !strings.EqualFold(req.Email, user.Email) {
+		exists, err := s.authRepo.EmailExists(req.Email)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, utils.ErrEmailExists
+		}
+		newEmail = req.Email
+	}
+
+	if err := s.authRepo.UpdateProfile(userID, user.Role, req.FirstName, req.LastName, newEmail); err != nil {
+		return nil, err
+	}
+
+	if newEmail != "" {
+		log.Printf("[AUTH] User %d changed email from %s to %s", userID, user.Email, newEmail)
+
+		user.Email = newEmail
+		if err := s.sendVerificationEmail(user); err != nil {
+			log.Printf("[AUTH] Warning: Failed to send verification email to %s: %v", newEmail, err)
+		}
+	}
+
+	return s.GetUserProfile(userID)
+}
+
+// VerifyEmail consumes a verification token and marks the user's email verified
+func (s *AuthService) VerifyEmail(token string) error {
+	userID, err := s.authRepo.VerifyEmail(utils.HashToken(token))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[AUTH] Email verified for user: %d", userID)
+	return nil
+}
+
+// ResendVerification sends a fresh verification link.
+// Unknown and already verified addresses are ignored so the endpoint cannot
+// be used to probe for accounts.
+func (s *AuthService) ResendVerification(email string) error {
+	user, err := s.authRepo.GetUserByEmail(email)
+	if errors.Is(err, utils.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if user.EmailVerified {
+		return nil
+	}
+
+	return s.sendVerificationEmail(user)
+}
+
+// sendVerificationEmail issues a single-use token and mails the verification link
+func (s *AuthService) sendVerificationEmail(user *models.User) error {
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(utils.EmailVerificationExpiry)
+	if err := s.authRepo.SaveEmailVerificationToken(user.ID, utils.HashToken(token), expiresAt); err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/api/v1/auth/verify-email?token=%s", s.cfg.AppBaseURL, token)
+
+	return s.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your WoundIQ email address",
+		Body: fmt.Sprintf("Welcome to WoundIQ.\n\nConfirm your email address by opening this link:\n%s\n\n"+
+			"The link expires in %s. If you did not create an account, ignore this message.",
+			link, utils.EmailVerificationExpiry),
+	})
+}
+
+// ForgotPassword emails a single-use reset link.
+// Unknown or inactive accounts are ignored so the endpoint cannot be used to probe for accounts.
+func (s *AuthService) ForgotPassword(req models.ResetPasswordRequest) error {
+	user, err := s.authRepo.GetUserByEmail(req.Email)
+	if errors.Is(err, utils.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !user.IsActive {
+		log.Printf("[AUTH] Password reset requested for inactive user: %s", req.Email)
+		return nil
+	}
+
+	return s.sendPasswordResetEmail(user)
+}
+
+// ResetPassword sets a new password using a reset token and revokes every
+// refresh and access token so existing sessions must log in again. Access
+// tokens are denylisted first and the repository revokes refresh tokens in
+// the same transaction as the password change, so a failure never leaves
+// the new password set with old sessions still alive.
+func (s *AuthService) ResetPassword(req models.ConfirmResetPasswordRequest) error {
+	tokenHash := utils.HashToken(req.Token)
+
+	userID, err := s.authRepo.GetPasswordResetUserID(tokenHash)
+	if err != nil {
+		return err
+	}
+
+	if err := s.validateNewPassword(userID, req.NewPassword); err != nil {
+		return err
+	}
+
+	if err := s.denylist.RevokeUserTokens(userID, time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+
+	if _, err := s.authRepo.ResetPassword(tokenHash, req.NewPassword); err != nil {
+		return err
+	}
+
+	log.Printf("[AUTH] Password reset for user: %d", userID)
+	return nil
+}
+
+// upgradePasswordHash rehashes a just-verified password with the configured
+// hasher when the stored hash is outdated. Failures are logged only; the
+// old hash keeps working.
+func (s *AuthService) upgradePasswordHash(user *models.User, password string) {
+	if !utils.PasswordNeedsRehash(user.PasswordHash) {
+		return
+	}
+
+	newHash, err := utils.HashPassword(password)
+	if err == nil {
+		err = s.authRepo.ReplacePasswordHash(user.ID, user.PasswordHash, newHash)
+	}
+	if err != nil {
+		log.Printf("[AUTH] Failed to upgrade password hash for user %d: %v", user.ID, err)
+		return
+	}
+
+	log.Printf("[AUTH] Upgraded password hash for user %d", user.ID)
+}
+
+// validateNewPassword applies the password policy, including the reuse
+// check against the user's recent password hashes
+func (s *AuthService) validateNewPassword(userID int, password string) error {
+	policy := s.cfg.PasswordPolicy
+	if err := policy.Validate(password); err != nil {
+		return err
+	}
+
+	hashes, err := s.authRepo.GetPasswordHistory(userID, policy.HistorySize)
+	if err != nil {
+		return err
+	}
+	for _, hash := range hashes {
+		if utils.CheckPassword(password, hash) {
+			return policy.ReuseViolation()
+		}
+	}
+	return nil
+}
+
+// RequirePasswordReset blocks password login for a user, signs them out
+// everywhere and emails a reset link. Used when an administrator suspects
+// the password is compromised.
+func (s *AuthService) RequirePasswordReset(userID int) error {
+	if err := s.authRepo.SetPasswordResetRequired(userID); err != nil {
+		return err
+	}
+
+	if err := revokeUserAccess(s.authRepo, s.denylist, userID); err != nil {
+		return fmt.Errorf("password reset required but failed to revoke sessions: %w", err)
+	}
+
+	user, err := s.authRepo.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+	return s.sendPasswordResetEmail(user)
+}
+
+// sendPasswordResetEmail issues a single-use reset token and mails the reset link
+func (s *AuthService) sendPasswordResetEmail(user *models.User) error {
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(utils.PasswordResetExpiry)
+	if err := s.authRepo.SavePasswordResetToken(user.ID, utils.HashToken(token), expiresAt); err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s?token=%s", s.cfg.PasswordResetURL, token)
+
+	return s.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your WoundIQ password",
+		Body: fmt.Sprintf("A password reset was requested for your WoundIQ account.\n\n"+
+			"Choose a new password by opening this link:\n%s\n\n"+
+			"The link expires in %s and can be used once. If you did not request a reset, ignore this message.",
+			link, utils.PasswordResetExpiry),
+	})
+}
+
+// EnrollMFA starts TOTP enrollment by generating a new secret. MFA is not
+// enforced until the user confirms a code with ConfirmMFA.
+func (s *AuthService) EnrollMFA(userID int) (*models.MFAEnrollResponse, error) {
+	user, err := s.authRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authRepo.SaveMFASecret(userID, secret); err != nil {
+		return nil, err
+	}
+
+	return &models.MFAEnrollResponse{
+		Secret:     secret,
+		OTPAuthURI: utils.TOTPURI(s.cfg.MFAIssuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmMFA enables MFA once the user proves their authenticator works.
+// It returns the recovery codes (shown only once) and an access token that
+// is no longer MFA-pending.
+func (s *AuthService) ConfirmMFA(userID int, req models.MFAConfirmRequest) (*models.MFAConfirmResponse, error) {
+	user, err := s.authRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	mfa, err := s.authRepo.GetMFA(userID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa.Enabled {
+		return nil, utils.ErrMFAAlreadyEnabled
+	}
+
+	step, ok := utils.ValidateTOTPCode(mfa.Secret, req.Code, time.Now())
+	if !ok {
+		return nil, utils.ErrInvalidMFACode
+	}
+
+	codes, err := utils.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = utils.HashToken(code)
+	}
+
+	if err := s.authRepo.EnableMFA(userID, step, hashes); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[AUTH] MFA enabled for user: %d", userID)
+
+	accessToken, err := utils.GenerateAccessToken(user.ID, user.Email, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MFAConfirmResponse{
+		RecoveryCodes: codes,
+		Token:         accessToken,
+	}, nil
+}
+
+// DisableMFA removes the user's MFA enrollment after re-checking their
+// password and a current code. Users whose role requires MFA cannot disable it.
+func (s *AuthService) DisableMFA(userID int, req models.MFADisableRequest) error {
+	user, err := s.authRepo.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if s.mfaRequired(user.Role) {
+		return utils.ErrMFARequiredByPolicy
+	}
+
+	if !utils.CheckPassword(req.Password, user.PasswordHash) {
+		return utils.ErrInvalidCredentials
+	}
+
+	mfa, err := s.authRepo.GetMFA(userID)
+	if err != nil {
+		return err
+	}
+	if !mfa.Enabled {
+		return utils.ErrMFANotEnrolled
+	}
+
+	if err := s.verifyMFACode(mfa, req.Code); err != nil {
+		return err
+	}
+
+	if err := s.authRepo.DeleteMFA(userID); err != nil {
+		return err
+	}
+
+	log.Printf("[AUTH] MFA disabled for user: %d", userID)
+	return nil
+}
+
+// verifyMFACode accepts either a TOTP code or an unused recovery code.
+// Accepted TOTP steps and recovery codes cannot be used again.
+func (s *AuthService) verifyMFACode(mfa *models.UserMFA, code string) error {
+	code = strings.TrimSpace(code)
+
+	if len(code) == utils.TOTPDigits {
+		step, ok := utils.ValidateTOTPCode(mfa.Secret, code, time.Now())
+		if !ok {
+			return utils.ErrInvalidMFACode
+		}
+		return s.authRepo.UpdateMFALastStep(mfa.UserID, step)
+	}
+
+	if err := s.authRepo.UseRecoveryCode(mfa.UserID, utils.HashToken(strings.ToLower(code))); err != nil {
+		return err
+	}
+
+	log.Printf("[AUTH] Recovery code used by user: %d", mfa.UserID)
+	return nil
+}
+
+// checkIPThrottle returns ErrTooManyAttempts if the client IP has reached the
+// failure threshold within the throttle window
+func (s *AuthService) checkIPThrottle(clientIP string) error {
+	if s.cfg.IPThrottleThreshold <= 0 || clientIP == "" {
+		return nil
+	}
+
+	failures, err := s.authRepo.CountIPLoginFailures(clientIP, time.Now().Add(-s.cfg.IPThrottleWindow))
+	if err != nil {
+		return err
+	}
+	if failures >= s.cfg.IPThrottleThreshold {
+		return utils.ErrTooManyAttempts
+	}
+	return nil
+}
+
+// recordIPFailure logs a failed attempt against the client IP. Errors are
+// logged rather than returned so they never change the login response.
+func (s *AuthService) recordIPFailure(clientIP, email string) {
+	if s.cfg.IPThrottleThreshold <= 0 || clientIP == "" {
+		return
+	}
+	if err := s.authRepo.RecordIPLoginFailure(clientIP, email, time.Now().Add(-s.cfg.IPThrottleWindow)); err != nil {
+		log.Printf("[AUTH] Warning: Failed to record login failure for %s: %v", clientIP, err)
+	}
+}
+
+// recordFailedLogin counts a failed factor for the user and client IP. It
+// returns ErrAccountLocked if this failure locked the account and
+// ErrInvalidCredentials otherwise.
+func (s *AuthService) recordFailedLogin(user *models.User, clientIP string) error {
+	s.recordIPFailure(clientIP, user.Email)
+
+	lockedUntil, err := s.authRepo.RecordFailedLogin(user.ID, s.cfg.LockoutThreshold, s.cfg.LockoutDuration)
+	if err != nil {
+		log.Printf("[AUTH] Warning: Failed to record failed login for user %d: %v", user.ID, err)
+		return utils.ErrInvalidCredentials
+	}
+
+	if lockedUntil.Valid && lockedUntil.Time.After(time.Now()) {
+		log.Printf("[AUTH] Account locked until %s - %s", lockedUntil.Time.Format(time.RFC3339), user.Email)
+		return utils.ErrAccountLocked
+	}
+	return utils.ErrInvalidCredentials
+}
+
+// generateAccessToken issues an access token, restricted to MFA enrollment
+// when the user's role requires MFA and they have not enabled it yet
+func (s *AuthService) generateAccessToken(user *models.User) (string, error) {
+	if s.mfaRequired(user.Role) {
+		mfa, err := s.authRepo.GetMFA(user.ID)
+		if err != nil && !errors.Is(err, utils.ErrMFANotEnrolled) {
+			return "", err
+		}
+		if mfa == nil || !mfa.Enabled {
+			return utils.GenerateMFAPendingAccessToken(user.ID, user.Email, user.Role)
+		}
+	}
+
+	return utils.GenerateAccessToken(user.ID, user.Email, user.Role)
+}
+
+// mfaRequired reports whether the org policy requires MFA for the role
+func (s *AuthService) mfaRequired(role string) bool {
+	for _, r := range s.cfg.MFARequiredRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// revokeUserAccess signs a user out everywhere: refresh tokens are revoked and
+// access tokens already issued are denylisted
+func revokeUserAccess(authRepo repository.AuthRepository, denylist utils.TokenDenylist, userID int) error {
+	if err := authRepo.RevokeAllUserTokens(userID); err != nil {
+		return err
+	}
+	return denylist.RevokeUserTokens(userID, time.Now())
+}
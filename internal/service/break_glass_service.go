@@ -0,0 +1,62 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+type BreakGlassService struct {
+	breakGlassRepo repository.BreakGlassRepository
+}
+
+func NewBreakGlassService(breakGlassRepo repository.BreakGlassRepository) *BreakGlassService {
+	return &BreakGlassService{breakGlassRepo: breakGlassRepo}
+}
+
+// Start records a break-glass event and issues an elevated access token
+// that expires after utils.BreakGlassExpiry
+func (s *BreakGlassService) Start(userID int, email, role, reason, clientIP string) (*models.BreakGlassResponse, error) {
+	expiresAt := time.Now().Add(utils.BreakGlassExpiry)
+
+	eventID, err := s.breakGlassRepo.CreateEvent(userID, reason, clientIP, expiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record break-glass event: %w", err)
+	}
+
+	token, err := utils.GenerateBreakGlassToken(userID, email, role, eventID, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[BREAK-GLASS] Event %d opened by user %d (%s) from %s until %s",
+		eventID, userID, email, clientIP, expiresAt.Format(time.RFC3339))
+
+	return &models.BreakGlassResponse{
+		EventID:   eventID,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// ListEvents returns break-glass events for the review report
+func (s *BreakGlassService) ListEvents(filter models.BreakGlassEventFilter) ([]models.BreakGlassEvent, int, error) {
+	return s.breakGlassRepo.ListEvents(filter)
+}
+
+// GetEvent returns one event with every record accessed under it
+func (s *BreakGlassService) GetEvent(eventID int) (*models.BreakGlassEvent, error) {
+	return s.breakGlassRepo.GetEvent(eventID)
+}
+
+// Review marks an event as reviewed
+func (s *BreakGlassService) Review(eventID, reviewerID int, notes string) (*models.BreakGlassEvent, error) {
+	if err := s.breakGlassRepo.ReviewEvent(eventID, reviewerID, notes); err != nil {
+		return nil, err
+	}
+	return s.breakGlassRepo.GetEvent(eventID)
+}
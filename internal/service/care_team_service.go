@@ -0,0 +1,92 @@
+package service
+
+import (
+	"time"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+type CareTeamService struct {
+	careTeamRepo repository.CareTeamRepository
+}
+
+func NewCareTeamService(careTeamRepo repository.CareTeamRepository) *CareTeamService {
+	return &CareTeamService{careTeamRepo: careTeamRepo}
+}
+
+// ListCareTeam returns every assignment (current, future and ended) for a patient
+func (s *CareTeamService) ListCareTeam(patientID int) ([]models.CareTeamAssignment, error) {
+	exists, err := s.careTeamRepo.PatientExists(patientID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, utils.ErrNotFound
+	}
+
+	return s.careTeamRepo.ListByPatient(patientID)
+}
+
+// Assign adds a clinician to a patient's care team. A clinician's
+// assignments to one patient may not overlap; an overlapping request
+// returns utils.ErrAlreadyAssigned.
+func (s *CareTeamService) Assign(patientID int, req models.AssignCareTeamRequest, assignedBy int) (*models.CareTeamAssignment, error) {
+	startDate := time.Now().UTC().Truncate(24 * time.Hour)
+	if req.StartDate != "" {
+		t, err := time.Parse(time.RFC3339, req.StartDate)
+		if err != nil {
+			return nil, utils.ErrBadRequest
+		}
+		startDate = t
+	}
+
+	var endDate *time.Time
+	if req.EndDate != "" {
+		t, err := time.Parse(time.RFC3339, req.EndDate)
+		if err != nil {
+			return nil, utils.ErrBadRequest
+		}
+		if t.Before(startDate) {
+			return nil, utils.ErrInvalidDateRange
+		}
+		endDate = &t
+	}
+
+	exists, err := s.careTeamRepo.PatientExists(patientID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, utils.ErrNotFound
+	}
+
+	exists, err = s.careTeamRepo.ClinicianExists(req.ClinicianID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, utils.ErrNotClinician
+	}
+
+	assigned, err := s.careTeamRepo.HasOverlappingAssignment(patientID, req.ClinicianID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	if assigned {
+		return nil, utils.ErrAlreadyAssigned
+	}
+
+	id, err := s.careTeamRepo.Assign(patientID, req.ClinicianID, startDate, endDate, assignedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.careTeamRepo.GetAssignment(patientID, id)
+}
+
+// Unassign ends an assignment as of today; the row is kept for history
+func (s *CareTeamService) Unassign(patientID, assignmentID int) error {
+	return s.careTeamRepo.EndAssignment(patientID, assignmentID)
+}
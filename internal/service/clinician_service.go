@@ -0,0 +1,79 @@
+package service
+
+import (
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository"
+)
+
+// ClinicianService manages clinician profiles
+type ClinicianService struct {
+	clinicianRepo repository.ClinicianRepository
+}
+
+func NewClinicianService(clinicianRepo repository.ClinicianRepository) *ClinicianService {
+	return &ClinicianService{clinicianRepo: clinicianRepo}
+}
+
+// ListClinicians returns a page of clinicians and the total count
+func (s *ClinicianService) ListClinicians(params *models.PaginationParams) ([]models.Clinician, int, error) {
+	return s.clinicianRepo.ListClinicians(params.GetLimit(), params.GetOffset())
+}
+
+// GetClinician returns utils.ErrNotFound if the clinician does not exist
+func (s *ClinicianService) GetClinician(clinicianID int) (*models.Clinician, error) {
+	return s.clinicianRepo.GetClinician(clinicianID)
+}
+
+func (s *ClinicianService) CreateClinician(req models.CreateClinicianRequest) (*models.Clinician, error) {
+	cl := models.Clinician{
+		FullName:      req.FullName,
+		Role:          req.Role,
+		Department:    req.Department,
+		ContactInfo:   req.ContactInfo,
+		LicenseNumber: req.LicenseNumber,
+	}
+
+	id, err := s.clinicianRepo.CreateClinician(cl)
+	if err != nil {
+		return nil, err
+	}
+
+	cl.ClinicianID = id
+	return &cl, nil
+}
+
+// UpdateClinician applies the non-empty fields of req to an existing clinician
+func (s *ClinicianService) UpdateClinician(clinicianID int, req models.UpdateClinicianRequest) (*models.Clinician, error) {
+	cl, err := s.clinicianRepo.GetClinician(clinicianID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.FullName != "" {
+		cl.FullName = req.FullName
+	}
+	if req.Role != "" {
+		cl.Role = req.Role
+	}
+	if req.Department != "" {
+		cl.Department = req.Department
+	}
+	if req.ContactInfo != "" {
+		cl.ContactInfo = req.ContactInfo
+	}
+	if req.LicenseNumber != "" {
+		cl.LicenseNumber = req.LicenseNumber
+	}
+
+	if err := s.clinicianRepo.UpdateClinician(*cl); err != nil {
+		return nil, err
+	}
+	return cl, nil
+}
+
+// DeleteClinician deletes a clinician in one transaction and reports what was
+// removed. Returns utils.ErrNotFound if the clinician does not exist, or a
+// *utils.DependentsError if they charted any assessments, deleted or not.
+func (s *ClinicianService) DeleteClinician(clinicianID int, mode models.DeleteMode) (*models.DeleteResult, error) {
+	return s.clinicianRepo.DeleteClinician(clinicianID, mode)
+}
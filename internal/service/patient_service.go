@@ -0,0 +1,115 @@
+package service
+
+import (
+	"time"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// PatientService manages patient demographics. Record-level access checks
+// stay with the caller (see AccessService); this service only enforces the
+// data rules.
+type PatientService struct {
+	patientRepo repository.PatientRepository
+}
+
+func NewPatientService(patientRepo repository.PatientRepository) *PatientService {
+	return &PatientService{patientRepo: patientRepo}
+}
+
+// ListPatients returns a page of patients and the total count. A non-zero
+// careTeamUserID limits the result to that clinician user's care team.
+func (s *PatientService) ListPatients(params *models.PaginationParams, careTeamUserID int) ([]models.Patient, int, error) {
+	return s.patientRepo.ListPatients(params.GetLimit(), params.GetOffset(), careTeamUserID)
+}
+
+// GetPatient returns utils.ErrNotFound if the patient does not exist
+func (s *PatientService) GetPatient(patientID int) (*models.Patient, error) {
+	return s.patientRepo.GetPatient(patientID)
+}
+
+// CreatePatient registers a patient. A non-zero careTeamClinicianID is put on
+// the new patient's care team atomically with the insert. Returns
+// utils.ErrBadRequest if the date of birth is not RFC 3339.
+func (s *PatientService) CreatePatient(req models.CreatePatientRequest, careTeamClinicianID, createdBy int) (*models.Patient, error) {
+	dob, err := time.Parse(time.RFC3339, req.DateOfBirth)
+	if err != nil {
+		return nil, utils.ErrBadRequest
+	}
+
+	id, err := s.patientRepo.CreatePatient(models.Patient{
+		FullName:            req.FullName,
+		DateOfBirth:         dob,
+		Gender:              req.Gender,
+		MedicalRecordNumber: req.MedicalRecordNumber,
+	}, careTeamClinicianID, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.patientRepo.GetPatient(id)
+}
+
+// UpdatePatient applies the non-empty fields of req to an existing patient
+func (s *PatientService) UpdatePatient(patientID int, req models.UpdatePatientRequest) (*models.Patient, error) {
+	patient, err := s.patientRepo.GetPatient(patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.FullName != "" {
+		patient.FullName = req.FullName
+	}
+	if req.DateOfBirth != "" {
+		dob, err := time.Parse(time.RFC3339, req.DateOfBirth)
+		if err != nil {
+			return nil, utils.ErrBadRequest
+		}
+		patient.DateOfBirth = dob
+	}
+	if req.Gender != "" {
+		patient.Gender = req.Gender
+	}
+	if req.MedicalRecordNumber != "" {
+		patient.MedicalRecordNumber = req.MedicalRecordNumber
+	}
+
+	if err := s.patientRepo.UpdatePatient(*patient); err != nil {
+		return nil, err
+	}
+	return patient, nil
+}
+
+// DeletePatient soft-deletes a patient in one transaction and reports what
+// was deleted. Returns utils.ErrNotFound if the patient does not exist, or a
+// *utils.DependentsError in restrict mode if they have assessments.
+func (s *PatientService) DeletePatient(patientID int, q models.DeleteQuery, deletedBy int) (*models.DeleteResult, error) {
+	return s.patientRepo.DeletePatient(patientID, q.GetMode(), deletedBy, q.Reason)
+}
+
+// ListDeletedPatients returns a page of deleted patients and the total count
+func (s *PatientService) ListDeletedPatients(params *models.PaginationParams) ([]models.DeletedPatient, int, error) {
+	return s.patientRepo.ListDeletedPatients(params.GetLimit(), params.GetOffset())
+}
+
+// RestorePatient undeletes a patient and the assessments deleted with them.
+// Returns utils.ErrNotFound if the patient is not deleted.
+func (s *PatientService) RestorePatient(patientID int) (*models.RestoreResult, error) {
+	return s.patientRepo.RestorePatient(patientID)
+}
+
+// GetWoundHistory returns a patient's assessments in date order.
+// Returns utils.ErrNotFound if the patient does not exist.
+func (s *PatientService) GetWoundHistory(patientID int) ([]models.WoundHistory, error) {
+	exists, err := s.patientRepo.PatientExists(patientID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, utils.ErrNotFound
+	}
+
+	return s.patientRepo.GetWoundHistory(patientID)
+}
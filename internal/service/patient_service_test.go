@@ -0,0 +1,127 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePatientRepo is a map-backed repository.PatientRepository
+type fakePatientRepo struct {
+	patients map[int]models.Patient
+	nextID   int
+}
+
+func newFakePatientRepo(patients ...models.Patient) *fakePatientRepo {
+	r := &fakePatientRepo{patients: map[int]models.Patient{}, nextID: 100}
+	for _, p := range patients {
+		r.patients[p.PatientID] = p
+	}
+	return r
+}
+
+func (r *fakePatientRepo) ListPatients(limit, offset, careTeamUserID int) ([]models.Patient, int, error) {
+	list := []models.Patient{}
+	for _, p := range r.patients {
+		list = append(list, p)
+	}
+	return list, len(list), nil
+}
+
+func (r *fakePatientRepo) GetPatient(patientID int) (*models.Patient, error) {
+	p, ok := r.patients[patientID]
+	if !ok {
+		return nil, utils.ErrNotFound
+	}
+	return &p, nil
+}
+
+func (r *fakePatientRepo) CreatePatient(p models.Patient, careTeamClinicianID, assignedBy int) (int, error) {
+	r.nextID++
+	p.PatientID = r.nextID
+	r.patients[p.PatientID] = p
+	return p.PatientID, nil
+}
+
+func (r *fakePatientRepo) UpdatePatient(p models.Patient) error {
+	if _, ok := r.patients[p.PatientID]; !ok {
+		return utils.ErrNotFound
+	}
+	r.patients[p.PatientID] = p
+	return nil
+}
+
+func (r *fakePatientRepo) DeletePatient(patientID int, mode models.DeleteMode, deletedBy int, reason string) (*models.DeleteResult, error) {
+	if _, ok := r.patients[patientID]; !ok {
+		return nil, utils.ErrNotFound
+	}
+	delete(r.patients, patientID)
+	result := models.NewDeleteResult(mode)
+	result.Add("patient", 1)
+	return result, nil
+}
+
+func (r *fakePatientRepo) ListDeletedPatients(limit, offset int) ([]models.DeletedPatient, int, error) {
+	return []models.DeletedPatient{}, 0, nil
+}
+
+func (r *fakePatientRepo) RestorePatient(patientID int) (*models.RestoreResult, error) {
+	return nil, utils.ErrNotFound
+}
+
+func (r *fakePatientRepo) PatientExists(patientID int) (bool, error) {
+	_, ok := r.patients[patientID]
+	return ok, nil
+}
+
+func (r *fakePatientRepo) GetWoundHistory(patientID int) ([]models.WoundHistory, error) {
+	return []models.WoundHistory{}, nil
+}
+
+func TestPatientServiceCreateParsesDateOfBirth(t *testing.T) {
+	svc := NewPatientService(newFakePatientRepo())
+
+	_, err := svc.CreatePatient(models.CreatePatientRequest{
+		FullName: "Ada Byron", DateOfBirth: "15/01/1990", Gender: "Female", MedicalRecordNumber: "MRN-1",
+	}, 0, 0)
+	assert.ErrorIs(t, err, utils.ErrBadRequest)
+
+	p, err := svc.CreatePatient(models.CreatePatientRequest{
+		FullName: "Ada Byron", DateOfBirth: "1990-01-15T00:00:00Z", Gender: "Female", MedicalRecordNumber: "MRN-1",
+	}, 0, 0)
+	require.NoError(t, err)
+	assert.NotZero(t, p.PatientID)
+	assert.Equal(t, time.Date(1990, 1, 15, 0, 0, 0, 0, time.UTC), p.DateOfBirth)
+}
+
+func TestPatientServiceUpdateKeepsOmittedFields(t *testing.T) {
+	repo := newFakePatientRepo(models.Patient{
+		PatientID: 1, FullName: "Ada Byron", Gender: "Female", MedicalRecordNumber: "MRN-1",
+	})
+	svc := NewPatientService(repo)
+
+	p, err := svc.UpdatePatient(1, models.UpdatePatientRequest{FullName: "Ada Lovelace"})
+	require.NoError(t, err)
+	assert.Equal(t, "Ada Lovelace", p.FullName)
+	assert.Equal(t, "MRN-1", p.MedicalRecordNumber)
+	assert.Equal(t, "Ada Lovelace", repo.patients[1].FullName)
+
+	_, err = svc.UpdatePatient(2, models.UpdatePatientRequest{FullName: "Nobody"})
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+}
+
+func TestPatientServiceWoundHistoryRequiresPatient(t *testing.T) {
+	svc := NewPatientService(newFakePatientRepo(models.Patient{PatientID: 1}))
+
+	_, err := svc.GetWoundHistory(2)
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+
+	history, err := svc.GetWoundHistory(1)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+}
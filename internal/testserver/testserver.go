@@ -0,0 +1,191 @@
+// Package testserver boots the full API router against the in-memory
+// repositories so handler tests exercise real routing, middleware and
+// services without a database.
+package testserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/handlers"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/mailer"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/middleware"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/models"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/repository/memory"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/router"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/service"
+	"github.com/vellalasantosh/wound_iq_api_claude/internal/utils"
+)
+
+// jwtSecret signs every token issued by a test server
+const jwtSecret = "testserver-secret"
+
+// tokenIssuer and tokenAudience are the iss and aud of test server tokens
+const (
+	tokenIssuer   = "http://testserver"
+	tokenAudience = "wound-iq-api"
+)
+
+// Server is a router wired to a fresh in-memory store
+type Server struct {
+	t testing.TB
+
+	Router *gin.Engine
+	Store  *memory.Store
+	Mail   *Mailbox
+	Auth   *service.AuthService
+}
+
+// New builds a server with default auth settings. Package-level hooks (token
+// denylist, API key verifier) are reset when the test ends, so tests using a
+// Server must not run in parallel.
+func New(t testing.TB) *Server {
+	return NewWithConfig(t, service.AuthConfig{
+		AppBaseURL:       "http://localhost",
+		PasswordResetURL: "http://localhost/reset-password",
+		MFAIssuer:        "WoundIQ",
+		PasswordPolicy:   utils.PasswordPolicy{MinLength: 6, MaxLength: 100},
+	})
+}
+
+// NewWithConfig builds a server with the given auth settings
+func NewWithConfig(t testing.TB, cfg service.AuthConfig) *Server {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	gin.DefaultWriter = io.Discard
+
+	utils.SetJWTSecret(jwtSecret)
+	utils.SetTokenIssuer(tokenIssuer, tokenAudience)
+	utils.SetPasswordHasher(utils.BcryptHasher{Cost: bcrypt.MinCost})
+
+	store := memory.NewStore()
+	repos := store.Repositories()
+	mail := &Mailbox{}
+	denylist := utils.NewMemoryDenylist()
+
+	middleware.SetTokenDenylist(denylist)
+	middleware.SetAPIKeyVerifier(service.NewAPIKeyService(repos.APIKeys))
+	t.Cleanup(func() {
+		middleware.SetTokenDenylist(nil)
+		middleware.SetAPIKeyVerifier(nil)
+	})
+
+	authService := service.NewAuthService(repos.Auth, mail, denylist, cfg)
+	authHandler := handlers.NewAuthHandler(authService)
+	adminHandler := handlers.NewAdminHandler(service.NewAdminService(repos.Auth, authService, denylist))
+
+	return &Server{
+		t:      t,
+		Router: router.SetupRouter(repos, authHandler, adminHandler),
+		Store:  store,
+		Mail:   mail,
+		Auth:   authService,
+	}
+}
+
+// Do sends a request through the router. body is marshalled to JSON unless
+// nil; token, if set, is sent as a bearer token.
+func (s *Server) Do(method, path string, body interface{}, token string) *httptest.ResponseRecorder {
+	s.t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			s.t.Fatalf("marshal request body: %v", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	w := httptest.NewRecorder()
+	s.Router.ServeHTTP(w, req)
+	return w
+}
+
+// Decode unmarshals a JSON response body into v, failing the test on error
+func (s *Server) Decode(w *httptest.ResponseRecorder, v interface{}) {
+	s.t.Helper()
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		s.t.Fatalf("decode response %q: %v", w.Body.String(), err)
+	}
+}
+
+// CreateUser registers a user with the given role directly in the store.
+// Admins cannot self-register, so they are created as clinicians and
+// promoted, as an operator would do in SQL.
+func (s *Server) CreateUser(email, password, role string) *models.User {
+	s.t.Helper()
+
+	repos := s.Store.Repositories()
+	createRole := role
+	if role == models.RoleAdmin {
+		createRole = models.RoleClinician
+	}
+
+	user, err := repos.Auth.CreateUser(email, password, createRole, "Test", "User")
+	if err != nil {
+		s.t.Fatalf("create user %s: %v", email, err)
+	}
+	if role == models.RoleAdmin {
+		if err := repos.Auth.ChangeUserRole(user.ID, role); err != nil {
+			s.t.Fatalf("promote %s to admin: %v", email, err)
+		}
+		user.Role = role
+	}
+	return user
+}
+
+// Login signs in through the API and returns the access token
+func (s *Server) Login(email, password string) string {
+	s.t.Helper()
+
+	w := s.Do(http.MethodPost, "/api/v1/auth/login", models.LoginRequest{Email: email, Password: password}, "")
+	if w.Code != http.StatusOK {
+		s.t.Fatalf("login %s: status %d: %s", email, w.Code, w.Body.String())
+	}
+
+	var resp models.LoginResponse
+	s.Decode(w, &resp)
+	if resp.Token == "" {
+		s.t.Fatalf("login %s: no token in %s", email, w.Body.String())
+	}
+	return resp.Token
+}
+
+// Mailbox records outgoing email instead of delivering it
+type Mailbox struct {
+	mu       sync.Mutex
+	messages []mailer.Message
+}
+
+// Send implements mailer.Mailer
+func (m *Mailbox) Send(msg mailer.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// Messages returns the email sent so far
+func (m *Mailbox) Messages() []mailer.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]mailer.Message(nil), m.messages...)
+}
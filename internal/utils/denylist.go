@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// TokenDenylist records access tokens that must be rejected before they expire.
+// Tokens are denied individually by jti, or per user for every token issued
+// before a cut-off (password change, deactivation).
+type TokenDenylist interface {
+	// RevokeToken denies the token with the given jti until expiresAt
+	RevokeToken(jti string, expiresAt time.Time) error
+
+	// RevokeUserTokens denies every token issued to the user before the given time
+	RevokeUserTokens(userID int, before time.Time) error
+
+	// IsRevoked reports whether a validated token has been denied
+	IsRevoked(claims *Claims) (bool, error)
+}
+
+// MemoryDenylist is a process-local TokenDenylist. Entries are lost on
+// restart and not shared between instances; use the Postgres-backed store
+// when running more than one instance.
+type MemoryDenylist struct {
+	mu     sync.RWMutex
+	tokens map[string]time.Time // jti -> token expiry
+	users  map[int]time.Time    // user ID -> revoke tokens issued before
+}
+
+// NewMemoryDenylist creates an empty in-memory denylist
+func NewMemoryDenylist() *MemoryDenylist {
+	return &MemoryDenylist{
+		tokens: make(map[string]time.Time),
+		users:  make(map[int]time.Time),
+	}
+}
+
+func (d *MemoryDenylist) RevokeToken(jti string, expiresAt time.Time) error {
+	if jti == "" {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.prune(time.Now())
+	d.tokens[jti] = expiresAt
+	return nil
+}
+
+func (d *MemoryDenylist) RevokeUserTokens(userID int, before time.Time) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.prune(time.Now())
+	d.users[userID] = RevocationCutoff(before)
+	return nil
+}
+
+func (d *MemoryDenylist) IsRevoked(claims *Claims) (bool, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if claims.ID != "" {
+		if _, ok := d.tokens[claims.ID]; ok {
+			return true, nil
+		}
+	}
+
+	if before, ok := d.users[claims.UserID]; ok {
+		return claims.IssuedAt == nil || claims.IssuedAt.Time.Before(before), nil
+	}
+
+	return false, nil
+}
+
+// prune drops entries that can no longer match an unexpired token.
+// The caller must hold the write lock.
+func (d *MemoryDenylist) prune(now time.Time) {
+	for jti, expiresAt := range d.tokens {
+		if now.After(expiresAt) {
+			delete(d.tokens, jti)
+		}
+	}
+	for userID, before := range d.users {
+		if now.After(before.Add(AccessTokenExpiry)) {
+			delete(d.users, userID)
+		}
+	}
+}
+
+// RevocationCutoff truncates a per-user revocation time to whole seconds,
+// the resolution of the iat claim, so tokens issued in the same second as
+// the revocation (e.g. the login right after a password reset) stay valid
+func RevocationCutoff(t time.Time) time.Time {
+	return t.Truncate(time.Second)
+}
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryDenylist(t *testing.T) {
+	now := time.Now()
+	claims := func(jti string, userID int, issuedAt time.Time) *Claims {
+		return &Claims{UserID: userID, RegisteredClaims: jwt.RegisteredClaims{
+			ID:       jti,
+			IssuedAt: jwt.NewNumericDate(issuedAt),
+		}}
+	}
+
+	t.Run("revoked jti is denied", func(t *testing.T) {
+		d := NewMemoryDenylist()
+		assert.NoError(t, d.RevokeToken("abc", now.Add(time.Hour)))
+
+		revoked, err := d.IsRevoked(claims("abc", 1, now))
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+
+		revoked, _ = d.IsRevoked(claims("other", 1, now))
+		assert.False(t, revoked)
+	})
+
+	t.Run("user cut-off denies only earlier tokens", func(t *testing.T) {
+		d := NewMemoryDenylist()
+		assert.NoError(t, d.RevokeUserTokens(7, now))
+
+		revoked, _ := d.IsRevoked(claims("old", 7, now.Add(-time.Minute)))
+		assert.True(t, revoked)
+
+		revoked, _ = d.IsRevoked(claims("new", 7, now.Add(time.Second)))
+		assert.False(t, revoked)
+
+		revoked, _ = d.IsRevoked(claims("other-user", 8, now.Add(-time.Minute)))
+		assert.False(t, revoked)
+	})
+
+	t.Run("expired entries are pruned", func(t *testing.T) {
+		d := NewMemoryDenylist()
+		assert.NoError(t, d.RevokeToken("stale", now.Add(-time.Minute)))
+		assert.NoError(t, d.RevokeToken("fresh", now.Add(time.Hour)))
+
+		assert.NotContains(t, d.tokens, "stale")
+		assert.Contains(t, d.tokens, "fresh")
+	})
+}
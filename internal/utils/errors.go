@@ -1,24 +1,84 @@
 package utils
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
 
 var (
 	// Authentication errors
-	ErrInvalidCredentials = errors.New("invalid email or password")
-	ErrUserNotFound       = errors.New("user not found")
-	ErrUserInactive       = errors.New("user account is inactive")
-	ErrEmailExists        = errors.New("email already exists")
-	ErrInvalidToken       = errors.New("invalid or expired token")
-	ErrTokenExpired       = errors.New("token has expired")
-	ErrUnauthorized       = errors.New("unauthorized access")
+	ErrInvalidCredentials    = errors.New("invalid email or password")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrUserInactive          = errors.New("user account is inactive")
+	ErrEmailExists           = errors.New("email already exists")
+	ErrInvalidToken          = errors.New("invalid or expired token")
+	ErrTokenExpired          = errors.New("token has expired")
+	ErrUnauthorized          = errors.New("unauthorized access")
+	ErrEmailNotVerified      = errors.New("email address has not been verified")
+	ErrAccountLocked         = errors.New("account is temporarily locked after too many failed login attempts")
+	ErrTooManyAttempts       = errors.New("too many failed login attempts, try again later")
+	ErrTokenReused           = errors.New("refresh token has already been used")
+	ErrPasswordResetRequired = errors.New("password must be reset before logging in; check your email for a reset link")
+
+	// Single sign-on errors
+	ErrSSONotConfigured   = errors.New("single sign-on is not configured")
+	ErrSSOFailed          = errors.New("single sign-on failed")
+	ErrSSOAccountNotFound = errors.New("no account is linked to this identity")
+
+	// API key errors
+	ErrInvalidAPIKey = errors.New("invalid, expired or revoked API key")
+
+	// Multi-factor errors
+	ErrInvalidMFACode      = errors.New("invalid authentication code")
+	ErrMFAAlreadyEnabled   = errors.New("multi-factor authentication is already enabled")
+	ErrMFANotEnrolled      = errors.New("multi-factor authentication is not enrolled")
+	ErrMFARequiredByPolicy = errors.New("multi-factor authentication is required for your role")
 
 	// Password errors
-	ErrPasswordTooShort = errors.New("password must be at least 6 characters")
-	ErrPasswordTooLong  = errors.New("password must not exceed 100 characters")
+	ErrPasswordPolicy   = errors.New("password does not meet the password policy")
+	ErrPasswordExpired  = errors.New("password has expired; reset it using the forgot-password link")
 	ErrPasswordMismatch = errors.New("passwords do not match")
 
+	// Care team errors
+	ErrAlreadyAssigned  = errors.New("clinician already has an overlapping assignment to this patient")
+	ErrInvalidDateRange = errors.New("end date must not be before start date")
+	ErrNotClinician     = errors.New("user has no clinician profile")
+
+	// Assessment errors
+	ErrInvalidPatient   = errors.New("patient does not exist")
+	ErrInvalidClinician = errors.New("clinician does not exist")
+	ErrPatientMismatch  = errors.New("an assessment cannot be moved to another patient")
+	ErrHasDependents    = errors.New("record has dependent records")
+	ErrPatientDeleted   = errors.New("the assessment's patient is deleted; restore the patient first")
+
 	// General errors
 	ErrInternalServer = errors.New("internal server error")
 	ErrBadRequest     = errors.New("bad request")
 	ErrNotFound       = errors.New("resource not found")
 )
+
+// DependentsError lists the records that blocked a restrict-mode delete, as
+// row counts per table. errors.Is(err, ErrHasDependents) matches it.
+type DependentsError struct {
+	Dependents map[string]int
+}
+
+func (e *DependentsError) Error() string {
+	tables := make([]string, 0, len(e.Dependents))
+	for table := range e.Dependents {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	counts := make([]string, len(tables))
+	for i, table := range tables {
+		counts[i] = fmt.Sprintf("%d %s", e.Dependents[table], table)
+	}
+	return ErrHasDependents.Error() + ": " + strings.Join(counts, ", ")
+}
+
+func (e *DependentsError) Is(target error) bool {
+	return target == ErrHasDependents
+}
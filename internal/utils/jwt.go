@@ -2,6 +2,7 @@ package utils
 
 import (
 	"errors"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -14,54 +15,199 @@ var (
 	// Token expiration times
 	AccessTokenExpiry  = time.Hour * 24     // 24 hours
 	RefreshTokenExpiry = time.Hour * 24 * 7 // 7 days
+	BreakGlassExpiry   = time.Hour          // 1 hour
+	MFAChallengeExpiry = time.Minute * 5    // 5 minutes
+	SSOStateExpiry     = time.Minute * 10   // 10 minutes
+
+	// tokenIssuer and tokenAudience are the iss claim of every token we sign
+	// and the aud claim of access tokens; empty omits and skips the check
+	tokenIssuer   string
+	tokenAudience string
 )
 
+// TokenUseMFAChallenge marks a token that only proves the password step of a
+// login and can only be exchanged at /auth/login/mfa
+const TokenUseMFAChallenge = "mfa_challenge"
+
+// TokenUseSSOState marks the token that carries OIDC login state between the
+// redirect to the identity provider and the callback
+const TokenUseSSOState = "sso_state"
+
+// TokenUseRefresh marks a refresh token; it is only ever redeemed at
+// /auth/refresh and must not authenticate API requests
+const TokenUseRefresh = "refresh"
+
+// SSOStateClaims holds the values needed to finish an OIDC login. The token
+// travels in an HttpOnly cookie, so the PKCE verifier never reaches the IdP.
+type SSOStateClaims struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+	TokenUse string `json:"token_use"`
+	jwt.RegisteredClaims
+}
+
 // Claims represents the JWT claims
 type Claims struct {
 	UserID int    `json:"user_id"`
 	Email  string `json:"email"`
 	Role   string `json:"role"`
+
+	// BreakGlassID is set on emergency-access tokens and identifies the
+	// break-glass event every request made with the token is audited against
+	BreakGlassID int `json:"break_glass_id,omitempty"`
+
+	// MFAPending is set when the user's role requires MFA but they have not
+	// enrolled yet; such tokens may only reach the /auth endpoints
+	MFAPending bool `json:"mfa_pending,omitempty"`
+
+	// TokenUse is empty for access tokens and names the purpose of special tokens
+	TokenUse string `json:"token_use,omitempty"`
 	jwt.RegisteredClaims
 }
 
 // GenerateAccessToken generates a new JWT access token
 func GenerateAccessToken(userID int, email, role string) (string, error) {
-	claims := Claims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
+	return signAccessToken(Claims{UserID: userID, Email: email, Role: role}, AccessTokenExpiry)
+}
+
+// GenerateMFAPendingAccessToken generates an access token restricted to
+// MFA enrollment for users whose role requires MFA
+func GenerateMFAPendingAccessToken(userID int, email, role string) (string, error) {
+	return signAccessToken(Claims{UserID: userID, Email: email, Role: role, MFAPending: true}, AccessTokenExpiry)
+}
+
+// GenerateMFAChallengeToken generates the short-lived token returned after the
+// password step of a login for users with MFA enabled
+func GenerateMFAChallengeToken(userID int) (string, time.Time, error) {
+	expiresAt := time.Now().Add(MFAChallengeExpiry)
+	token, err := signAccessToken(Claims{UserID: userID, TokenUse: TokenUseMFAChallenge}, MFAChallengeExpiry)
+	return token, expiresAt, err
+}
+
+// GenerateSSOStateToken signs the state of an OIDC login in progress
+func GenerateSSOStateToken(state, nonce, verifier string) (string, error) {
+	now := time.Now()
+	return signToken(SSOStateClaims{
+		State:    state,
+		Nonce:    nonce,
+		Verifier: verifier,
+		TokenUse: TokenUseSSOState,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenExpiry)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			Issuer:    tokenIssuer,
+			ExpiresAt: jwt.NewNumericDate(now.Add(SSOStateExpiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
+	})
+}
+
+// ValidateSSOStateToken validates a token issued by GenerateSSOStateToken
+func ValidateSSOStateToken(tokenString string) (*SSOStateClaims, error) {
+	claims := &SSOStateClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, verificationKey, parserOptions(false)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Valid || claims.TokenUse != TokenUseSSOState {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
+
+// GenerateBreakGlassToken generates a short-lived access token tied to a break-glass event
+func GenerateBreakGlassToken(userID int, email, role string, eventID int, expiresAt time.Time) (string, error) {
+	claims := Claims{UserID: userID, Email: email, Role: role, BreakGlassID: eventID}
+	return signAccessToken(claims, time.Until(expiresAt))
+}
+
+func signAccessToken(claims Claims, expiry time.Duration) (string, error) {
+	// jti identifies the token so it can be denylisted (see TokenDenylist)
+	jti, err := GenerateSecureToken(16)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        jti,
+		Issuer:    tokenIssuer,
+		ExpiresAt: jwt.NewNumericDate(now.Add(expiry)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+	}
+
+	// Only access tokens are meant for us and for services trusting our
+	// JWKS; special-purpose tokens carry no audience
+	if claims.TokenUse == "" && tokenAudience != "" {
+		claims.Audience = jwt.ClaimStrings{tokenAudience}
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(jwtSecret)
+	return signToken(claims)
+}
+
+// refreshClaims are the claims of a refresh token. The token_use tag stops
+// ValidateToken, and anyone verifying against our JWKS, from accepting it
+// as an access token.
+type refreshClaims struct {
+	TokenUse string `json:"token_use"`
+	jwt.RegisteredClaims
 }
 
 // GenerateRefreshToken generates a new JWT refresh token
 func GenerateRefreshToken(userID int) (string, error) {
-	claims := jwt.RegisteredClaims{
-		Subject:   string(rune(userID)),
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(RefreshTokenExpiry)),
-		IssuedAt:  jwt.NewNumericDate(time.Now()),
+	// A random ID keeps tokens issued in the same second distinct
+	jti, err := GenerateSecureToken(16)
+	if err != nil {
+		return "", err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(jwtSecret)
+	claims := refreshClaims{
+		TokenUse: TokenUseRefresh,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Issuer:    tokenIssuer,
+			Subject:   strconv.Itoa(userID),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(RefreshTokenExpiry)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	return signToken(claims)
 }
 
-// ValidateToken validates and parses a JWT token
+// ValidateToken validates and parses a JWT access token
 func ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		// Verify signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return jwtSecret, nil
-	})
+	claims, err := parseClaims(tokenString, parserOptions(true)...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Special-purpose tokens are not accepted as access tokens
+	if claims.TokenUse != "" {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
+
+// ValidateMFAChallengeToken validates a token issued by GenerateMFAChallengeToken
+func ValidateMFAChallengeToken(tokenString string) (*Claims, error) {
+	claims, err := parseClaims(tokenString, parserOptions(false)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.TokenUse != TokenUseMFAChallenge {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
+
+func parseClaims(tokenString string, opts ...jwt.ParserOption) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, verificationKey, opts...)
 
 	if err != nil {
 		return nil, err
@@ -76,9 +222,7 @@ func ValidateToken(tokenString string) (*Claims, error) {
 
 // ExtractUserIDFromToken extracts user ID from token without full validation
 func ExtractUserIDFromToken(tokenString string) (int, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return jwtSecret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, verificationKey)
 
 	if err != nil {
 		return 0, err
@@ -91,6 +235,27 @@ func ExtractUserIDFromToken(tokenString string) (int, error) {
 	return 0, errors.New("invalid token claims")
 }
 
+// parserOptions requires the configured issuer and, for access tokens, the
+// configured audience
+func parserOptions(accessToken bool) []jwt.ParserOption {
+	var opts []jwt.ParserOption
+	if tokenIssuer != "" {
+		opts = append(opts, jwt.WithIssuer(tokenIssuer))
+	}
+	if accessToken && tokenAudience != "" {
+		opts = append(opts, jwt.WithAudience(tokenAudience))
+	}
+	return opts
+}
+
+// SetTokenIssuer sets the iss claim of issued tokens and the aud claim of
+// access tokens (call this from main.go). Tokens without matching claims
+// are rejected from then on.
+func SetTokenIssuer(issuer, audience string) {
+	tokenIssuer = issuer
+	tokenAudience = audience
+}
+
 // SetJWTSecret sets the JWT secret key (call this from main.go with env variable)
 func SetJWTSecret(secret string) {
 	if secret != "" {
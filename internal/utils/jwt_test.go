@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenIssuerAndAudience(t *testing.T) {
+	defer SetTokenIssuer("", "")
+
+	SetTokenIssuer("https://woundiq.example", "wound-iq-api")
+
+	token, err := GenerateAccessToken(1, "a@example.com", "clinician")
+	require.NoError(t, err)
+	claims, err := ValidateToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "https://woundiq.example", claims.Issuer)
+	assert.Equal(t, jwt.ClaimStrings{"wound-iq-api"}, claims.Audience)
+
+	challenge, _, err := GenerateMFAChallengeToken(1)
+	require.NoError(t, err)
+	claims, err = ValidateMFAChallengeToken(challenge)
+	require.NoError(t, err)
+	assert.Empty(t, claims.Audience, "only access tokens are addressed to the API")
+	_, err = ValidateToken(challenge)
+	assert.Error(t, err)
+
+	refresh, err := GenerateRefreshToken(1)
+	require.NoError(t, err)
+	_, err = ValidateToken(refresh)
+	assert.Error(t, err, "refresh tokens are not access tokens")
+
+	t.Run("another audience is rejected", func(t *testing.T) {
+		SetTokenIssuer("https://woundiq.example", "billing-api")
+		_, err := ValidateToken(token)
+		assert.Error(t, err)
+	})
+
+	t.Run("another issuer is rejected", func(t *testing.T) {
+		SetTokenIssuer("https://other.example", "wound-iq-api")
+		_, err := ValidateToken(token)
+		assert.Error(t, err)
+	})
+}
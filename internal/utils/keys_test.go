@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0o600))
+}
+
+func TestKeySetSigningAndRotation(t *testing.T) {
+	defer SetKeySet(nil)
+
+	dir := t.TempDir()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaDER, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	require.NoError(t, err)
+	writePEM(t, dir, "rsa-2024.pem", "PRIVATE KEY", rsaDER)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edDER, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+	writePEM(t, dir, "ed-2025.pem", "PRIVATE KEY", edDER)
+
+	// Sign with the RSA key first
+	ks, err := LoadKeySet(dir, "rsa-2024")
+	require.NoError(t, err)
+	SetKeySet(ks)
+
+	oldToken, err := GenerateAccessToken(1, "a@example.com", "clinician")
+	require.NoError(t, err)
+
+	claims, err := ValidateToken(oldToken)
+	require.NoError(t, err)
+	assert.Equal(t, 1, claims.UserID)
+
+	// Rotate: Ed25519 becomes active and the RSA key is retired to its public half
+	require.NoError(t, os.Remove(filepath.Join(dir, "rsa-2024.pem")))
+	pubDER, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+	writePEM(t, dir, "rsa-2024.pem", "PUBLIC KEY", pubDER)
+
+	ks, err = LoadKeySet(dir, "ed-2025")
+	require.NoError(t, err)
+	SetKeySet(ks)
+
+	newToken, err := GenerateAccessToken(2, "b@example.com", "admin")
+	require.NoError(t, err)
+
+	claims, err = ValidateToken(newToken)
+	require.NoError(t, err)
+	assert.Equal(t, 2, claims.UserID)
+
+	claims, err = ValidateToken(oldToken)
+	require.NoError(t, err, "tokens signed with a retired key still verify")
+	assert.Equal(t, 1, claims.UserID)
+
+	// HS256 tokens are rejected once a key set is configured
+	SetKeySet(nil)
+	hsToken, err := GenerateAccessToken(3, "c@example.com", "patient")
+	require.NoError(t, err)
+	SetKeySet(ks)
+	_, err = ValidateToken(hsToken)
+	assert.Error(t, err)
+
+	jwks := PublicJWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "ed-2025", jwks.Keys[0].Kid)
+	assert.Equal(t, "OKP", jwks.Keys[0].Kty)
+	assert.Equal(t, "EdDSA", jwks.Keys[0].Alg)
+	assert.Equal(t, "rsa-2024", jwks.Keys[1].Kid)
+	assert.Equal(t, "RSA", jwks.Keys[1].Kty)
+	assert.Equal(t, "AQAB", jwks.Keys[1].E)
+}
+
+func TestLoadKeySetRequiresPrivateActiveKey(t *testing.T) {
+	dir := t.TempDir()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	writePEM(t, dir, "retired.pem", "PUBLIC KEY", der)
+
+	_, err = LoadKeySet(dir, "retired")
+	assert.Error(t, err)
+
+	_, err = LoadKeySet(dir, "missing")
+	assert.Error(t, err)
+}
@@ -1,31 +1,173 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
-// HashPassword hashes a plain text password using bcrypt
+// PasswordHasher creates and checks password hashes for one algorithm and
+// set of parameters
+type PasswordHasher interface {
+	// Hash returns a self-describing hash of the password
+	Hash(password string) (string, error)
+
+	// Verify reports whether the password matches a hash produced by this
+	// algorithm (with any parameters)
+	Verify(password, hash string) bool
+
+	// NeedsRehash reports whether a hash was made with another algorithm or
+	// weaker parameters than this hasher would use today
+	NeedsRehash(hash string) bool
+}
+
+// passwordHasher hashes new passwords; replace it with SetPasswordHasher
+var passwordHasher PasswordHasher = BcryptHasher{Cost: bcrypt.DefaultCost}
+
+// SetPasswordHasher sets the hasher used for new passwords (call this from
+// main.go). Existing hashes of every supported algorithm keep verifying.
+func SetPasswordHasher(hasher PasswordHasher) {
+	if hasher != nil {
+		passwordHasher = hasher
+	}
+}
+
+// HashPassword hashes a plain text password with the configured hasher
 func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return passwordHasher.Hash(password)
+}
+
+// CheckPassword compares a plain text password with a hashed password.
+// The algorithm is taken from the hash, so bcrypt and argon2id hashes both
+// verify whichever hasher is configured.
+func CheckPassword(password, hash string) bool {
+	switch {
+	case strings.HasPrefix(hash, argon2idPrefix):
+		return Argon2idHasher{}.Verify(password, hash)
+	default:
+		return BcryptHasher{}.Verify(password, hash)
+	}
+}
+
+// PasswordNeedsRehash reports whether a stored hash should be replaced with
+// one from the configured hasher
+func PasswordNeedsRehash(hash string) bool {
+	return passwordHasher.NeedsRehash(hash)
+}
+
+// BcryptHasher hashes with bcrypt at the given cost
+type BcryptHasher struct {
+	Cost int
+}
+
+func (h BcryptHasher) Hash(password string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
 	if err != nil {
 		return "", err
 	}
 	return string(bytes), nil
 }
 
-// CheckPassword compares a plain text password with a hashed password
-func CheckPassword(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+func (h BcryptHasher) Verify(password, hash string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+func (h BcryptHasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost < h.Cost
+}
+
+// argon2idPrefix starts every PHC-formatted argon2id hash
+const argon2idPrefix = "$argon2id$"
+
+// Argon2idHasher hashes with argon2id. Hashes use the PHC string format:
+// $argon2id$v=19$m=<KiB>,t=<iterations>,p=<parallelism>$<salt>$<key>
+type Argon2idHasher struct {
+	Memory      uint32 // KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2idHasher uses the OWASP-recommended baseline parameters
+func DefaultArgon2idHasher() Argon2idHasher {
+	return Argon2idHasher{Memory: 64 * 1024, Iterations: 3, Parallelism: 2, SaltLength: 16, KeyLength: 32}
 }
 
-// ValidatePasswordStrength validates password strength
-func ValidatePasswordStrength(password string) error {
-	if len(password) < 6 {
-		return ErrPasswordTooShort
+func (h Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.Iterations, h.Memory, h.Parallelism, h.KeyLength)
+
+	b64 := base64.RawStdEncoding
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2idPrefix, argon2.Version,
+		h.Memory, h.Iterations, h.Parallelism, b64.EncodeToString(salt), b64.EncodeToString(key)), nil
+}
+
+func (h Argon2idHasher) Verify(password, hash string) bool {
+	params, salt, key, err := decodeArgon2idHash(hash)
+	if err != nil {
+		return false
+	}
+
+	candidate := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(candidate, key) == 1
+}
+
+func (h Argon2idHasher) NeedsRehash(hash string) bool {
+	params, salt, key, err := decodeArgon2idHash(hash)
+	if err != nil {
+		return true
+	}
+	return params.Memory < h.Memory || params.Iterations < h.Iterations || params.Parallelism < h.Parallelism ||
+		uint32(len(salt)) < h.SaltLength || uint32(len(key)) < h.KeyLength
+}
+
+var errInvalidArgon2idHash = errors.New("invalid argon2id hash")
+
+// decodeArgon2idHash parses a PHC argon2id string into its parameters
+func decodeArgon2idHash(hash string) (Argon2idHasher, []byte, []byte, error) {
+	var params Argon2idHasher
+
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, errInvalidArgon2idHash
 	}
-	if len(password) > 100 {
-		return ErrPasswordTooLong
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, errInvalidArgon2idHash
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, errInvalidArgon2idHash
 	}
-	return nil
+	// argon2.IDKey panics on zero iterations or parallelism; RFC 9106 also
+	// requires at least 8 KiB of memory per lane
+	if params.Iterations < 1 || params.Parallelism < 1 || params.Memory < 8*uint32(params.Parallelism) {
+		return params, nil, nil, errInvalidArgon2idHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, errInvalidArgon2idHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, errInvalidArgon2idHash
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+	return params, salt, key, nil
 }
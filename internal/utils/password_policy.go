@@ -0,0 +1,171 @@
+package utils
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PasswordPolicy describes the rules a new password must satisfy.
+// Reuse (HistorySize) is checked by the auth service, which has the stored hashes.
+type PasswordPolicy struct {
+	MinLength     int
+	MaxLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+
+	// HistorySize rejects the current password and the previous
+	// HistorySize-1 passwords; 0 allows reuse
+	HistorySize int
+
+	// MaxAge expires a password this long after it was set; 0 disables expiry
+	MaxAge time.Duration
+
+	// Breached rejects passwords found in a known-breach list; nil disables the check
+	Breached BreachedPasswordList
+}
+
+// DefaultPasswordPolicy is the length-only policy used when nothing is configured
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{MinLength: 6, MaxLength: 100}
+}
+
+// PasswordPolicyError lists every rule a password breaks.
+// errors.Is(err, ErrPasswordPolicy) matches it.
+type PasswordPolicyError struct {
+	Violations []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return ErrPasswordPolicy.Error() + ": " + strings.Join(e.Violations, "; ")
+}
+
+func (e *PasswordPolicyError) Is(target error) bool {
+	return target == ErrPasswordPolicy
+}
+
+// Validate checks length, character classes and the breached-password list,
+// returning a *PasswordPolicyError describing every violation
+func (p PasswordPolicy) Validate(password string) error {
+	var violations []string
+
+	length := utf8.RuneCountInString(password)
+	if p.MinLength > 0 && length < p.MinLength {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters", p.MinLength))
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		violations = append(violations, fmt.Sprintf("must not exceed %d characters", p.MaxLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r), unicode.IsSymbol(r), unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if p.RequireUpper && !hasUpper {
+		violations = append(violations, "must contain an uppercase letter")
+	}
+	if p.RequireLower && !hasLower {
+		violations = append(violations, "must contain a lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, "must contain a digit")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		violations = append(violations, "must contain a symbol")
+	}
+
+	if p.Breached.Contains(password) {
+		violations = append(violations, "appears in a list of breached passwords; choose a different one")
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// ReuseViolation is the error returned when a password matches the history
+func (p PasswordPolicy) ReuseViolation() error {
+	return &PasswordPolicyError{Violations: []string{
+		fmt.Sprintf("must not match any of your last %d passwords", p.HistorySize),
+	}}
+}
+
+// Expired reports whether a password set at changedAt has passed MaxAge at t
+func (p PasswordPolicy) Expired(changedAt, t time.Time) bool {
+	return p.MaxAge > 0 && !changedAt.IsZero() && t.After(changedAt.Add(p.MaxAge))
+}
+
+// BreachedPasswordList is a set of SHA-1 password hashes (upper-case hex)
+type BreachedPasswordList map[string]struct{}
+
+// LoadBreachedPasswordList reads a breached-password file. Each line is
+// either a plaintext password or a 40-character SHA-1 hex digest, optionally
+// followed by ":count" as in the Have I Been Pwned downloads. Blank lines
+// and lines starting with # are ignored.
+func LoadBreachedPasswordList(path string) (BreachedPasswordList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	list := BreachedPasswordList{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if digest, _, _ := strings.Cut(line, ":"); isSHA1Hex(digest) {
+			list[strings.ToUpper(digest)] = struct{}{}
+			continue
+		}
+		list[sha1Hex(line)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	return list, nil
+}
+
+// Contains reports whether the password is in the list
+func (l BreachedPasswordList) Contains(password string) bool {
+	if len(l) == 0 {
+		return false
+	}
+	_, found := l[sha1Hex(password)]
+	return found
+}
+
+func sha1Hex(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+func isSHA1Hex(s string) bool {
+	if len(s) != 2*sha1.Size {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy := PasswordPolicy{
+		MinLength:     10,
+		MaxLength:     64,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+	}
+
+	assert.NoError(t, policy.Validate("Wound-care-2024"))
+
+	err := policy.Validate("short")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrPasswordPolicy))
+
+	var policyErr *PasswordPolicyError
+	require.True(t, errors.As(err, &policyErr))
+	assert.Equal(t, []string{
+		"must be at least 10 characters",
+		"must contain an uppercase letter",
+		"must contain a digit",
+		"must contain a symbol",
+	}, policyErr.Violations)
+}
+
+func TestPasswordPolicy_DefaultMatchesLegacyLimits(t *testing.T) {
+	policy := DefaultPasswordPolicy()
+
+	assert.Error(t, policy.Validate("12345"))
+	assert.NoError(t, policy.Validate("123456"))
+	assert.Error(t, policy.Validate(string(make([]byte, 101))))
+}
+
+func TestPasswordPolicy_Expired(t *testing.T) {
+	now := time.Now()
+	policy := PasswordPolicy{MaxAge: 90 * 24 * time.Hour}
+
+	assert.False(t, policy.Expired(now.Add(-89*24*time.Hour), now))
+	assert.True(t, policy.Expired(now.Add(-91*24*time.Hour), now))
+	assert.False(t, PasswordPolicy{}.Expired(now.Add(-1000*24*time.Hour), now))
+}
+
+func TestLoadBreachedPasswordList(t *testing.T) {
+	// SHA-1("password123") in lower case with a HIBP-style count
+	content := "# common passwords\nletmein\n\ncbfdac6008f9cab4083784cbd1874f76618d2a97:250000\n"
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	list, err := LoadBreachedPasswordList(path)
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+
+	assert.True(t, list.Contains("letmein"))
+	assert.True(t, list.Contains("password123"))
+	assert.False(t, list.Contains("Wound-care-2024"))
+
+	policy := DefaultPasswordPolicy()
+	policy.Breached = list
+	assert.ErrorIs(t, policy.Validate("password123"), ErrPasswordPolicy)
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fastArgon2id keeps the tests quick; production uses DefaultArgon2idHasher
+var fastArgon2id = Argon2idHasher{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestBcryptHasher(t *testing.T) {
+	hasher := BcryptHasher{Cost: bcrypt.MinCost}
+
+	hash, err := hasher.Hash("password123")
+	require.NoError(t, err)
+
+	assert.True(t, hasher.Verify("password123", hash))
+	assert.False(t, hasher.Verify("password124", hash))
+	assert.False(t, hasher.NeedsRehash(hash))
+	assert.True(t, BcryptHasher{Cost: bcrypt.MinCost + 1}.NeedsRehash(hash))
+}
+
+func TestArgon2idHasher(t *testing.T) {
+	hash, err := fastArgon2id.Hash("password123")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	assert.True(t, fastArgon2id.Verify("password123", hash))
+	assert.False(t, fastArgon2id.Verify("password124", hash))
+	assert.False(t, fastArgon2id.NeedsRehash(hash))
+
+	stronger := fastArgon2id
+	stronger.Iterations = 2
+	assert.True(t, stronger.NeedsRehash(hash))
+
+	assert.False(t, fastArgon2id.Verify("password123", "$argon2id$v=19$garbage"))
+
+	// Stored parameters that argon2 cannot run with are rejected, not used
+	rest := strings.SplitN(hash, "$", 5)[4]
+	for _, params := range []string{"m=1024,t=0,p=1", "m=1024,t=1,p=0", "m=15,t=1,p=2"} {
+		bad := "$argon2id$v=19$" + params + "$" + rest
+		assert.NotPanics(t, func() {
+			assert.False(t, fastArgon2id.Verify("password123", bad), params)
+		})
+	}
+}
+
+func TestCheckPassword_AnyAlgorithm(t *testing.T) {
+	bcryptHash, err := BcryptHasher{Cost: bcrypt.MinCost}.Hash("password123")
+	require.NoError(t, err)
+	argonHash, err := fastArgon2id.Hash("password123")
+	require.NoError(t, err)
+
+	defer SetPasswordHasher(passwordHasher)
+	SetPasswordHasher(fastArgon2id)
+
+	assert.True(t, CheckPassword("password123", bcryptHash))
+	assert.True(t, CheckPassword("password123", argonHash))
+	assert.False(t, CheckPassword("wrong", argonHash))
+
+	// Switching algorithm marks the old hashes for upgrade
+	assert.True(t, PasswordNeedsRehash(bcryptHash))
+	assert.False(t, PasswordNeedsRehash(argonHash))
+}
@@ -0,0 +1,16 @@
+package utils
+
+import "unicode/utf8"
+
+// TruncateUTF8 cuts s to at most maxBytes bytes without splitting a
+// multi-byte character, so a valid UTF-8 input stays valid
+func TruncateUTF8(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut]
+}
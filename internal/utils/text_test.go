@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateUTF8(t *testing.T) {
+	assert.Equal(t, "short", TruncateUTF8("short", 10))
+	assert.Equal(t, "abcd", TruncateUTF8("abcdef", 4))
+
+	// "é" is two bytes and "€" three; neither may be split
+	assert.Equal(t, "ab", TruncateUTF8("abé", 3))
+	assert.Equal(t, "a", TruncateUTF8("a€", 3))
+	assert.Equal(t, "a€", TruncateUTF8("a€b", 4))
+
+	ua := "Mozilla/5.0 (Linux; Android 14; 日本語) 🚀🚀🚀"
+	for max := 0; max <= len(ua); max++ {
+		got := TruncateUTF8(ua, max)
+		assert.LessOrEqual(t, len(got), max)
+		assert.True(t, utf8.ValidString(got), "max %d: %q", max, got)
+	}
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+var (
+	// One-time token expiration times
+	EmailVerificationExpiry = time.Hour * 48 // 48 hours
+	PasswordResetExpiry     = time.Hour      // 1 hour
+)
+
+// GenerateSecureToken returns a random URL-safe token with n bytes of entropy
+func GenerateSecureToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// GenerateRecoveryCodes returns n single-use MFA recovery codes formatted
+// as xxxxx-xxxxx for easier transcription
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, n)
+	for i := range codes {
+		raw, err := GenerateSecureToken(5)
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = raw[:5] + "-" + raw[5:]
+	}
+	return codes, nil
+}
+
+// HashToken returns the SHA-256 hex digest of a token.
+// Only the digest is stored so a database leak does not expose usable tokens.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
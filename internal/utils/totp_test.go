@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 6238 Appendix B test vectors (SHA1), truncated to 6 digits
+func TestGenerateTOTPCode_RFC6238Vectors(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, want := range vectors {
+		got, err := GenerateTOTPCode(secret, time.Unix(unix, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, want, got, "time %d", unix)
+	}
+}
+
+func TestValidateTOTPCode(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	assert.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	code, err := GenerateTOTPCode(secret, now)
+	assert.NoError(t, err)
+
+	t.Run("Current step", func(t *testing.T) {
+		step, ok := ValidateTOTPCode(secret, code, now)
+		assert.True(t, ok)
+		assert.Equal(t, now.Unix()/TOTPPeriod, step)
+	})
+
+	t.Run("Within skew", func(t *testing.T) {
+		_, ok := ValidateTOTPCode(secret, code, now.Add(TOTPPeriod*time.Second))
+		assert.True(t, ok)
+	})
+
+	t.Run("Outside skew", func(t *testing.T) {
+		_, ok := ValidateTOTPCode(secret, code, now.Add(3*TOTPPeriod*time.Second))
+		assert.False(t, ok)
+	})
+
+	t.Run("Malformed code", func(t *testing.T) {
+		_, ok := ValidateTOTPCode(secret, "12ab", now)
+		assert.False(t, ok)
+	})
+}